@@ -0,0 +1,277 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/app"
+	"youGo/internal/config"
+	"youGo/internal/modules"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/logger"
+	"youGo/internal/platform/migrate"
+	"youGo/internal/platform/tracing"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// globalOptions are the flags shared by every command.
+type globalOptions struct {
+	configDir  string
+	configName string
+}
+
+// loadConfig reads the configuration file, then applies environment overrides.
+func loadConfig(opts *globalOptions) (*config.Config, error) {
+	cfg, err := config.Load(opts.configDir, opts.configName)
+	if err != nil {
+		return nil, err
+	}
+	applyEnvOverrides(cfg)
+	return cfg, nil
+}
+
+// applyEnvOverrides applies the environment variables that don't follow viper's APP_ naming
+// (DB_*, as set by docker-compose) and those viper can't see without a config file key.
+func applyEnvOverrides(cfg *config.Config) {
+	// Override config values with environment variables if present
+	if dbHost := os.Getenv("DB_HOST"); dbHost != "" {
+		cfg.Database.Host = dbHost
+	}
+	if dbPortStr := os.Getenv("DB_PORT"); dbPortStr != "" {
+		if dbPort, err := strconv.Atoi(dbPortStr); err == nil {
+			cfg.Database.Port = fmt.Sprintf("%d", dbPort)
+		} else {
+			// Logger isn't up yet; config validate/serve report the effective value anyway
+			fmt.Fprintf(os.Stderr, "Warning: invalid DB_PORT %q, using config value\n", dbPortStr)
+		}
+	}
+	if dbUser := os.Getenv("DB_USER"); dbUser != "" {
+		cfg.Database.User = dbUser
+	}
+	if dbPassword := os.Getenv("DB_PASSWORD"); dbPassword != "" {
+		cfg.Database.Password = dbPassword
+	}
+	if dbname := os.Getenv("DB_NAME"); dbname != "" {
+		cfg.Database.DBName = dbname
+	}
+	if dbSslMode := os.Getenv("DB_SSLMODE"); dbSslMode != "" {
+		cfg.Database.SSLMode = dbSslMode
+	}
+
+	if accessTokenDuration := os.Getenv("APP_AUTH_ACCESS_TOKEN_DURATION"); accessTokenDuration != "" {
+		cfg.Auth.AccessTokenDuration = accessTokenDuration
+	}
+	if refreshTokenDuration := os.Getenv("APP_AUTH_REFRESH_TOKEN_DURATION"); refreshTokenDuration != "" {
+		cfg.Auth.RefreshTokenDuration = refreshTokenDuration
+	}
+	if corsAllowedOrigins := os.Getenv("APP_SERVER_CORS_ALLOWED_ORIGINS"); corsAllowedOrigins != "" {
+		cfg.Server.CORSAllowedOrigins = strings.Split(corsAllowedOrigins, ",") // Split the string
+	}
+	if trustedProxies := os.Getenv("APP_SERVER_TRUSTED_PROXIES"); trustedProxies != "" {
+		cfg.Server.TrustedProxies = strings.Split(trustedProxies, ",")
+	}
+	if jwtSecret := os.Getenv("APP_AUTH_JWT_SECRET"); jwtSecret != "" {
+		cfg.Auth.JWTSecret = jwtSecret
+	}
+	if port := os.Getenv("APP_SERVER_PORT"); port != "" {
+		cfg.Server.Port = port
+	}
+}
+
+// application is the wiring every database-backed command shares: configuration, logger,
+// database, and the container the modules register their repositories and services in.
+type application struct {
+	cfg      *config.Config
+	logger   *zap.Logger
+	db       *gorm.DB
+	sqlDB    *sql.DB
+	replicas *database.ReplicaSet // nil without read replicas
+	modules  *app.App
+	// Flushes the pending spans; nil without tracing
+	shutdownTracing func(context.Context) error
+}
+
+// newApplication loads the configuration, connects to the database and assembles the modules.
+// Components are built on first use, so a command only constructs what it needs. Callers must Close it.
+func newApplication(opts *globalOptions) (*application, error) {
+	// 1. Load Configuration
+	cfg, err := loadConfig(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	// 2. Initialize Logger
+	appLogger, err := logger.New(cfg.Log.Level, cfg.Log.Format, cfg.App.Env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	appLogger.Debug("Loaded config", zap.Any("config", cfg.Redacted()))
+
+	// 3. Setup Database Connection
+	if cfg.Database.DriverName() == config.DriverSQLite {
+		appLogger.Info("Opening SQLite database", zap.String("file", cfg.Database.DBName))
+	} else {
+		appLogger.Info("Connecting to database",
+			zap.String("host", cfg.Database.Host),
+			zap.String("port", cfg.Database.Port),
+			zap.String("user", cfg.Database.User),
+			zap.String("dbname", cfg.Database.DBName),
+			zap.String("sslmode", cfg.Database.SSLMode),
+		)
+	}
+	dbInstance, err := database.NewGORMConnection(cfg.Database, appLogger)
+	if err != nil {
+		_ = appLogger.Sync()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	sqlDB, err := dbInstance.DB()
+	if err != nil {
+		_ = appLogger.Sync()
+		return nil, fmt.Errorf("could not get underlying *sql.DB from GORM: %w", err)
+	}
+	if cfg.Database.DriverName() == config.DriverSQLite {
+		// The migrations are written for Postgres; the SQLite schema is kept in step by hand
+		if err := sqlite.Migrate(context.Background(), dbInstance); err != nil {
+			_ = sqlDB.Close()
+			_ = appLogger.Sync()
+			return nil, err
+		}
+	}
+	appLogger.Info("✅ Database connection pool established", zap.String("driver", cfg.Database.DriverName()))
+
+	var replicaSet *database.ReplicaSet
+	if len(cfg.Database.Replicas) > 0 {
+		if replicaSet, err = useReplicas(cfg.Database, dbInstance, appLogger); err != nil {
+			_ = sqlDB.Close()
+			_ = appLogger.Sync()
+			return nil, err
+		}
+	}
+
+	var shutdownTracing func(context.Context) error
+	if cfg.Tracing.Enabled {
+		if shutdownTracing, err = setupTracing(cfg, dbInstance, appLogger); err != nil {
+			if replicaSet != nil {
+				_ = replicaSet.Close()
+			}
+			_ = sqlDB.Close()
+			_ = appLogger.Sync()
+			return nil, err
+		}
+	}
+
+	// 4. Dependency Injection: shared values, then every module's providers
+	c := app.NewContainer()
+	app.Supply(c, cfg)
+	app.Supply(c, appLogger)
+	app.Supply(c, dbInstance)
+	app.Supply(c, repoImpl.NewTxManager(dbInstance, 0)) // Unit of work: repositories join the transaction carried in the context
+	if replicaSet != nil {
+		app.Supply(c, replicaSet)
+	}
+
+	return &application{
+		cfg:      cfg,
+		logger:   appLogger,
+		db:       dbInstance,
+		sqlDB:    sqlDB,
+		replicas: replicaSet,
+		modules:  app.New(c, appLogger, modules.Default()...),
+
+		shutdownTracing: shutdownTracing,
+	}, nil
+}
+
+// setupTracing installs the tracer provider of the configuration and traces the statements of db.
+func setupTracing(cfg *config.Config, db *gorm.DB, logger *zap.Logger) (func(context.Context) error, error) {
+	shutdown, err := tracing.Setup(context.Background(), cfg.Tracing, cfg.App.Env)
+	if err != nil {
+		return nil, err
+	}
+	if err := tracing.InstrumentGORM(db); err != nil {
+		_ = shutdown(context.Background())
+		return nil, err
+	}
+	logger.Info("✅ Tracing configured",
+		zap.String("exporter", cfg.Tracing.ExporterName()),
+		zap.Float64("sample_ratio", cfg.Tracing.SampleRatioOrDefault()),
+	)
+	return shutdown, nil
+}
+
+// useReplicas routes the reads of db to the configured read replicas.
+func useReplicas(cfg config.Database, db *gorm.DB, logger *zap.Logger) (*database.ReplicaSet, error) {
+	replicas, err := database.OpenReplicas(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var interval time.Duration
+	if cfg.ReplicaCheckInterval != "" {
+		interval, _ = time.ParseDuration(cfg.ReplicaCheckInterval) // Checked by Validate
+	}
+	set, err := database.UseReplicas(db, replicas, interval, logger)
+	if err != nil {
+		for _, r := range replicas {
+			_ = r.DB.Close()
+		}
+		return nil, err
+	}
+	logger.Info("✅ Read replicas configured", zap.Any("replicas", set.Status()))
+	return set, nil
+}
+
+// resolve returns the component of type T, see app.Resolve.
+func resolve[T any](a *application) (T, error) {
+	return app.Resolve[T](a.modules.Container)
+}
+
+// migrationRunner returns a runner for the migrations of every module, embedded in the binary.
+func (a *application) migrationRunner() (*migrate.Runner, error) {
+	if a.cfg.Database.DriverName() != config.DriverPostgres {
+		return nil, fmt.Errorf("migrations need the postgres driver; the %s schema is created on startup", a.cfg.Database.DriverName())
+	}
+	runner, err := migrate.NewRunner(a.sqlDB, a.modules.Migrations(), a.logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded migrations: %w", err)
+	}
+	return runner, nil
+}
+
+// Close releases the database pool and flushes the logger.
+func (a *application) Close() {
+	a.logger.Debug("Closing database connection pool...")
+	if a.replicas != nil {
+		if err := a.replicas.Close(); err != nil {
+			a.logger.Error("Error closing read replica connections", zap.Error(err))
+		}
+	}
+	if err := a.sqlDB.Close(); err != nil {
+		a.logger.Error("Error closing database connection", zap.Error(err))
+	}
+	if a.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.shutdownTracing(ctx); err != nil {
+			a.logger.Error("Error flushing spans", zap.Error(err))
+		}
+	}
+	_ = a.logger.Sync()
+}
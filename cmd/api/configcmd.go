@@ -0,0 +1,92 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/cobra"
+
+	"youGo/internal/config"
+)
+
+// newConfigCommand groups the commands that inspect the effective configuration
+// (file plus environment overrides). They don't connect to the database.
+func newConfigCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Validate or print the effective configuration",
+	}
+
+	validate := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration and report every problem found",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(opts)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration:\n%w", err)
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Configuration is valid")
+			return nil
+		},
+	}
+
+	var (
+		redacted bool
+		format   string
+	)
+	printCmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(opts)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			if redacted {
+				*cfg = cfg.Redacted()
+			}
+			out, err := marshalConfig(cfg, format)
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+	printCmd.Flags().BoolVar(&redacted, "redacted", true, "mask secrets such as passwords and the JWT secret")
+	printCmd.Flags().StringVar(&format, "format", "yaml", "output format: yaml or json")
+
+	cmd.AddCommand(validate, printCmd)
+	return cmd
+}
+
+// marshalConfig renders cfg with the same keys as the configuration file.
+func marshalConfig(cfg *config.Config, format string) ([]byte, error) {
+	var m map[string]interface{}
+	if err := mapstructure.Decode(cfg, &m); err != nil {
+		return nil, fmt.Errorf("encoding configuration: %w", err)
+	}
+	body, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding configuration: %w", err)
+	}
+	switch format {
+	case "json":
+		return append(body, '\n'), nil
+	case "yaml":
+		return yaml.JSONToYAML(body)
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected yaml or json", format)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"youGo/internal/scaffold"
+)
+
+// newGenerateCommand groups the code generators. They work on the source tree and need no configuration.
+func newGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate code for new features",
+	}
+
+	var opts scaffold.Options
+	resource := &cobra.Command{
+		Use:   "resource <Name> <field:type>...",
+		Short: "Scaffold a CRUD resource: domain, repository, service, DTOs, handler, module, migration and test",
+		Long: "Scaffold a CRUD resource: domain entity, Postgres repository, service with audit records, request and\n" +
+			"response DTOs, handler with Swagger annotations, module, migration and a service test.\n" +
+			"The module is added to modules.Default() and served under /api/v1/<plural> to logged-in users.\n\n" +
+			"Field types: " + strings.Join(scaffold.FieldTypeNames(), ", "),
+		Example: "  you-go-server generate resource Product name:string description:text unit_price:float active:bool",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := scaffold.ParseResource(args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			paths, err := scaffold.Generate(r, opts)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			verb := "Wrote"
+			if opts.DryRun {
+				verb = "Would write"
+			}
+			for _, path := range paths {
+				_, _ = fmt.Fprintf(out, "%s %s\n", verb, path)
+			}
+			if !opts.DryRun {
+				_, _ = fmt.Fprintf(out, "\nNext: regenerate the API docs (swag init -g cmd/api/main.go -o docs --parseDependency --parseInternal)\n"+
+					"and apply the migration (you-go-server migrate up).\n")
+			}
+			return nil
+		},
+	}
+	resource.Flags().StringVar(&opts.Dir, "dir", ".", "project root, the directory of go.mod")
+	resource.Flags().BoolVar(&opts.Force, "force", false, "overwrite existing files")
+	resource.Flags().BoolVar(&opts.DryRun, "dry-run", false, "list the files without writing them")
+
+	cmd.AddCommand(resource)
+	return cmd
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"youGo/internal/platform/migrate"
+)
+
+// newMigrateCommand groups the commands that run the migrations embedded in the binary.
+func newMigrateCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, revert or inspect database migrations",
+	}
+
+	// withRunner wires the application, runs fn with a migration runner and closes everything again.
+	withRunner := func(fn func(cmd *cobra.Command, runner *migrate.Runner, args []string) error) func(*cobra.Command, []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			app, err := newApplication(opts)
+			if err != nil {
+				return err
+			}
+			defer app.Close()
+			runner, err := app.migrationRunner()
+			if err != nil {
+				return err
+			}
+			return fn(cmd, runner, args)
+		}
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: withRunner(func(cmd *cobra.Command, runner *migrate.Runner, _ []string) error {
+				n, err := runner.Up(cmd.Context())
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Applied %d migration(s)\n", n)
+				return nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "down [N]",
+			Short: "Revert the last N migrations (default: 1)",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: withRunner(func(cmd *cobra.Command, runner *migrate.Runner, args []string) error {
+				steps := 1
+				if len(args) > 0 {
+					v, err := strconv.Atoi(args[0])
+					if err != nil || v < 1 {
+						return fmt.Errorf("invalid number of steps %q", args[0])
+					}
+					steps = v
+				}
+				n, err := runner.Down(cmd.Context(), steps)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Reverted %d migration(s)\n", n)
+				return nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "goto <version>",
+			Short: "Migrate up or down to a version (0 reverts everything)",
+			Args:  cobra.ExactArgs(1),
+			RunE: withRunner(func(cmd *cobra.Command, runner *migrate.Runner, args []string) error {
+				version, err := parseMigrationVersion(args[0])
+				if err != nil {
+					return err
+				}
+				n, err := runner.Goto(cmd.Context(), version)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Ran %d migration(s), now at version %d\n", n, version)
+				return nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "force <version>",
+			Short: "Mark a version as applied and clean without running SQL (after a failed migration)",
+			Args:  cobra.ExactArgs(1),
+			RunE: withRunner(func(cmd *cobra.Command, runner *migrate.Runner, args []string) error {
+				version, err := parseMigrationVersion(args[0])
+				if err != nil {
+					return err
+				}
+				if err := runner.Force(cmd.Context(), version); err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Forced version %d\n", version)
+				return nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show the current version and every migration's state",
+			Args:  cobra.NoArgs,
+			RunE: withRunner(func(cmd *cobra.Command, runner *migrate.Runner, _ []string) error {
+				status, err := runner.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				return printMigrationStatus(cmd.OutOrStdout(), status)
+			}),
+		},
+	)
+	return cmd
+}
+
+func parseMigrationVersion(s string) (uint64, error) {
+	version, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid version %q", s)
+	}
+	return version, nil
+}
+
+func printMigrationStatus(out io.Writer, status migrate.Status) error {
+	_, _ = fmt.Fprintf(out, "Version: %d (dirty: %t)\n\n", status.Version, status.Dirty)
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATE")
+	for _, m := range status.Migrations {
+		state := "pending"
+		if m.Applied {
+			state = "applied"
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, m.Name, state)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"youGo/docs"
+)
+
+// newOpenAPICommand groups the commands working on the API description generated by swag.
+func newOpenAPICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Work with the OpenAPI (Swagger 2.0) description of the API",
+	}
+
+	var (
+		format string
+		output string
+	)
+	export := &cobra.Command{
+		Use:   "export",
+		Short: "Write the API description compiled into this binary",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			body := []byte(docs.SwaggerInfo.ReadDoc())
+			switch format {
+			case "json":
+			case "yaml":
+				var err error
+				if body, err = yaml.JSONToYAML(body); err != nil {
+					return fmt.Errorf("converting API description to YAML: %w", err)
+				}
+			default:
+				return fmt.Errorf("unknown format %q, expected json or yaml", format)
+			}
+
+			if output == "" || output == "-" {
+				_, err := cmd.OutOrStdout().Write(body)
+				return err
+			}
+			if err := os.WriteFile(output, body, 0o644); err != nil {
+				return fmt.Errorf("writing %s: %w", output, err)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", output)
+			return nil
+		},
+	}
+	export.Flags().StringVar(&format, "format", "json", "output format: json or yaml")
+	export.Flags().StringVarP(&output, "output", "o", "", "file to write (default: stdout)")
+
+	cmd.AddCommand(export)
+	return cmd
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"youGo/internal/domain"
+)
+
+// newOutboxCommand groups the transactional outbox commands.
+func newOutboxCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outbox",
+		Short: "Manage the transactional outbox",
+	}
+	cmd.AddCommand(newRequeueCommand(opts))
+	return cmd
+}
+
+func newRequeueCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "requeue <event-id>",
+		Short: "Retry a dead outbox event",
+		Long: "Retry an outbox event the relay gave up on after outbox.max_attempts.\n" +
+			"Its attempts are reset, and the relay publishes it, then the later events of its aggregate it held back, on its next poll.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			eventID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid event ID %q: %w", args[0], err)
+			}
+
+			a, err := newApplication(opts)
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+			outbox, err := resolve[domain.OutboxRepository](a)
+			if err != nil {
+				return err
+			}
+
+			if err := outbox.Requeue(cmd.Context(), eventID); err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return fmt.Errorf("no dead outbox event %s", eventID)
+				}
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Requeued outbox event %s\n", eventID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"youGo/internal/platform/buildinfo"
+)
+
+// newRootCommand builds the you-go-server command tree.
+// Every command loads the configuration the same way, and those that need the database
+// share the wiring in newApplication.
+func newRootCommand() *cobra.Command {
+	opts := &globalOptions{}
+	root := &cobra.Command{
+		Use:   "you-go-server",
+		Short: "youGo API server and administration commands",
+		// Running the binary without a command serves the API, as it always has.
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runServe(opts)
+		},
+		SilenceUsage:  true, // Errors are not usage mistakes once a command runs
+		SilenceErrors: true, // main prints the error
+		// Adds --version, printing e.g. "you-go-server 1.2.0 (commit 4f2a9c1, built ..., go1.24.2 linux/amd64)"
+		Version: buildinfo.Get().String(),
+	}
+	root.SetVersionTemplate("{{.Name}} {{.Version}}\n")
+	root.PersistentFlags().StringVar(&opts.configDir, "config-dir", "./configs", "directory containing the configuration file")
+	root.PersistentFlags().StringVar(&opts.configName, "config-name", "config", "configuration file name, without extension")
+
+	root.AddCommand(
+		newServeCommand(opts),
+		newMigrateCommand(opts),
+		newSeedCommand(opts),
+		newUsersCommand(opts),
+		newOutboxCommand(opts),
+		newConfigCommand(opts),
+		newOpenAPICommand(),
+		newGenerateCommand(),
+	)
+	return root
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"youGo/internal/api/request"
+	"youGo/internal/domain"
+	"youGo/internal/service"
+)
+
+// seedPassword is the password of every seeded account. Development data only.
+const seedPassword = "password123"
+
+// seedUser is one account created by the seed command.
+type seedUser struct {
+	request.CreateUserRequest
+	Role string
+}
+
+var seedUsers = []seedUser{
+	{CreateUserRequest: request.CreateUserRequest{Name: "Admin", Email: "admin@example.com"}, Role: domain.RoleAdmin},
+	{CreateUserRequest: request.CreateUserRequest{Name: "Alice Example", Email: "alice@example.com"}, Role: domain.RoleUser},
+	{CreateUserRequest: request.CreateUserRequest{Name: "Bob Example", Email: "bob@example.com"}, Role: domain.RoleUser},
+}
+
+func newSeedCommand(opts *globalOptions) *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Load development data (idempotent)",
+		Long: "Create an admin and a few demo users, all with the password \"" + seedPassword + "\".\n" +
+			"Accounts that already exist are left untouched, so seeding twice is safe.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			a, err := newApplication(opts)
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+			userSvc, err := resolve[service.UserService](a)
+			if err != nil {
+				return err
+			}
+			if a.cfg.App.Env == "production" && !force {
+				return errors.New("refusing to seed a production environment (use --force to override)")
+			}
+			ctx := cliContext(cmd.Context(), "seed")
+
+			for _, u := range seedUsers {
+				req := u.CreateUserRequest
+				req.Password = seedPassword
+				user, err := userSvc.Create(ctx, &req)
+				if errors.Is(err, domain.ErrDuplicateEntry) {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Skipped %s (already exists)\n", req.Email)
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("seeding %s: %w", req.Email, err)
+				}
+				if u.Role != user.Role {
+					role := u.Role
+					if _, err := userSvc.Update(ctx, uuid.MustParse(user.ID), &request.UpdateUserRequest{Role: &role}); err != nil {
+						return fmt.Errorf("seeding %s: %w", req.Email, err)
+					}
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Created %s (%s)\n", req.Email, u.Role)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "seed even when app.env is production")
+	return cmd
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/config"
+	"youGo/internal/platform/health"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/validator"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newServeCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the HTTP API server (the default command)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runServe(opts)
+		},
+	}
+}
+
+// runServe starts the API and the modules' background workers and blocks until SIGINT/SIGTERM.
+func runServe(opts *globalOptions) error {
+	a, err := newApplication(opts)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+	cfg, appLogger := a.cfg, a.logger
+	appLogger.Info("✅ Logger initialized", zap.String("level", cfg.Log.Level), zap.String("env", cfg.App.Env))
+
+	// --- Migrations ---
+	// The SQL files of every module are embedded in the binary; `migrate` runs them on demand
+	// and database.auto_migrate applies pending ones before serving (SQLite: see newApplication).
+	if cfg.Database.AutoMigrate && cfg.Database.DriverName() == config.DriverPostgres {
+		runner, err := a.migrationRunner()
+		if err != nil {
+			return err
+		}
+		applied, err := runner.Up(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to apply database migrations: %w", err)
+		}
+		appLogger.Info("✅ Database migrations up to date", zap.Int("applied", applied))
+	}
+
+	// --- Probes ---
+	// Readiness also waits for the schema this build expects, e.g. while another instance migrates it
+	probes, err := resolve[*health.Registry](a)
+	if err != nil {
+		return err
+	}
+	if cfg.Database.DriverName() == config.DriverPostgres {
+		runner, err := a.migrationRunner()
+		if err != nil {
+			return err
+		}
+		probes.Register("migrations", runner.CheckUpToDate)
+	}
+
+	// --- Background Workers ---
+	// Modules register theirs first so that they start before, and stop after, the HTTP server.
+	if a.replicas != nil {
+		a.modules.Lifecycle.Go("read replica health checks", a.replicas.Run)
+	}
+	if err := a.modules.RegisterHooks(); err != nil {
+		return err
+	}
+
+	// 5. Set up Echo instance
+	e := echo.New()
+	// Hide the Echo startup banner
+	e.HideBanner = true
+	e.HidePort = true
+	// Using go-playground/validator:
+	e.Validator = validator.NewValidator()
+	// Client IPs (rate limits, logs, audit trail) are only taken from the headers of trusted proxies
+	e.IPExtractor = middleware.ClientIP(cfg.Server.TrustedProxies)
+	// Errors returned by handlers and middleware become RFC 7807 problem details, in the
+	// language the user chose, or else the one of Accept-Language
+	e.HTTPErrorHandler = problem.Handler(appLogger, i18n.Default(), problem.ActorLocale)
+
+	e.Use(echomiddleware.Logger())
+	e.Use(echomiddleware.CORS())
+
+	// --- Standard Middleware ---
+	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.Tracing())        // Server span, continuing the caller's traceparent (no-op without tracing)
+	e.Use(middleware.RequestContext()) // Exposes request ID and client IP to services (audit trail)
+	e.Use(middleware.ReadYourWrites()) // Reads after a write in the same request see the primary
+	metrics, err := resolve[router.Metrics](a)
+	if err != nil {
+		return err
+	}
+	e.Use(echo.MiddlewareFunc(metrics)) // Prometheus request metrics, counting rejected requests too
+	e.Use(echomiddleware.Recover())
+	e.Use(middleware.RequestLogger(appLogger)) // Logger will now pick up request ID
+	rateLimit, err := resolve[router.RateLimit](a)
+	if err != nil {
+		return err
+	}
+	e.Use(echo.MiddlewareFunc(rateLimit)) // Policies of the rate_limit configuration, by path prefix
+	metering, err := resolve[router.Metering](a)
+	if err != nil {
+		return err
+	}
+	e.Use(echo.MiddlewareFunc(metering)) // Billable API calls and the quotas of plans
+	appLogger.Info("✅ Standard and custom middleware configured")
+
+	// 6. Configure Routing: shared routes, then every module's
+	if err := a.modules.SetupRoutes(e); err != nil {
+		return err
+	}
+
+	// 7. HTTP server, appended last: started once everything else runs, stopped first
+	serverAddr := fmt.Sprintf(":%s", cfg.Server.Port)
+	serverErr := make(chan error, 1)
+	a.modules.Lifecycle.Append(app.Hook{
+		Name: "http server",
+		OnStart: func(context.Context) error {
+			// Listen synchronously so that a port already in use fails the start
+			listener, err := net.Listen("tcp", serverAddr)
+			if err != nil {
+				return err
+			}
+			e.Listener = listener
+			appLogger.Info("🚀 Starting server...", zap.String("address", serverAddr), zap.String("env", cfg.App.Env))
+			go func() {
+				if err := e.Start(serverAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					serverErr <- err
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return e.Shutdown(ctx)
+		},
+	})
+
+	if err := a.modules.Lifecycle.Start(context.Background()); err != nil {
+		return err
+	}
+
+	// 8. Graceful Shutdown
+	// Wait for interrupt signal (Ctrl+C) or SIGTERM
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	var runErr error
+	select {
+	case err := <-serverErr:
+		runErr = fmt.Errorf("server failed: %w", err)
+	case <-quit:
+		appLogger.Info("🚦 Received shutdown signal. Starting graceful shutdown...")
+	}
+
+	// Readiness fails first, so that load balancers stop sending requests before the server
+	// stops accepting them. A second signal skips the wait.
+	probes.StartShutdown()
+	if shutdownDelay, _ := time.ParseDuration(cfg.Health.ShutdownDelay); shutdownDelay > 0 && runErr == nil {
+		appLogger.Info("⏳ Readiness failing, waiting before stopping the server", zap.Duration("delay", shutdownDelay))
+		select {
+		case <-time.After(shutdownDelay):
+		case <-quit:
+		}
+	}
+
+	// Give active requests and workers time to finish
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Stops the server first, then the workers, in reverse start order
+	if err := a.modules.Lifecycle.Stop(ctx); err != nil {
+		return errors.Join(runErr, fmt.Errorf("graceful shutdown failed: %w", err))
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	appLogger.Info("✅ Server gracefully stopped")
+	return nil
+}
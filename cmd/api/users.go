@@ -0,0 +1,176 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"youGo/internal/api/request"
+	"youGo/internal/domain"
+	"youGo/internal/platform/requestctx"
+	"youGo/internal/service"
+)
+
+// cliUserAgent identifies changes made from the command line in the audit trail.
+const cliUserAgent = "you-go-server/cli"
+
+// cliContext returns a context carrying request metadata, so that audit records
+// written by commands can be told apart from API requests.
+func cliContext(ctx context.Context, command string) context.Context {
+	return requestctx.WithMetadata(ctx, requestctx.Metadata{
+		RequestID: "cli-" + command + "-" + uuid.NewString(),
+		UserAgent: cliUserAgent,
+	})
+}
+
+// newUsersCommand groups the user administration commands.
+func newUsersCommand(opts *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "Manage user accounts",
+	}
+	cmd.AddCommand(
+		newCreateAdminCommand(opts),
+		newSetRoleCommand(opts),
+		newDeactivateCommand(opts),
+	)
+	return cmd
+}
+
+func newCreateAdminCommand(opts *globalOptions) *cobra.Command {
+	var req request.CreateUserRequest
+	cmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "Create a user with the admin role",
+		Long: "Create a user with the admin role.\n" +
+			"The password is read from --password or, to keep it out of the shell history, the ADMIN_PASSWORD environment variable.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if req.Password == "" {
+				req.Password = os.Getenv("ADMIN_PASSWORD")
+			}
+			if err := validator.New().Struct(&req); err != nil {
+				return fmt.Errorf("invalid admin user: %w", err)
+			}
+
+			a, err := newApplication(opts)
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+			userSvc, err := resolve[service.UserService](a)
+			if err != nil {
+				return err
+			}
+			ctx := cliContext(cmd.Context(), "create-admin")
+
+			user, err := userSvc.CreateWithRole(ctx, &req, domain.RoleAdmin)
+			if err != nil {
+				if errors.Is(err, domain.ErrDuplicateEntry) {
+					return fmt.Errorf("a user with email %s already exists, use `users set-role` to promote it", req.Email)
+				}
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Created admin %s (%s)\n", req.Email, user.ID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&req.Email, "email", "", "email address (required)")
+	cmd.Flags().StringVar(&req.Name, "name", "Administrator", "display name")
+	cmd.Flags().StringVar(&req.Password, "password", "", "password, at least 8 characters (default $ADMIN_PASSWORD)")
+	_ = cmd.MarkFlagRequired("email")
+	return cmd
+}
+
+func newSetRoleCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-role <email|id> <admin|user>",
+		Short: "Change a user's role",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			role := args[1]
+			if role != domain.RoleAdmin && role != domain.RoleUser {
+				return fmt.Errorf("unknown role %q, expected %q or %q", role, domain.RoleAdmin, domain.RoleUser)
+			}
+
+			a, err := newApplication(opts)
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+			userSvc, err := resolve[service.UserService](a)
+			if err != nil {
+				return err
+			}
+			ctx := cliContext(cmd.Context(), "set-role")
+
+			user, err := findUser(ctx, a, args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := userSvc.Update(ctx, user.ID, &request.UpdateUserRequest{Role: &role}); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Set role of %s to %s\n", user.Email, role)
+			return nil
+		},
+	}
+}
+
+func newDeactivateCommand(opts *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deactivate <email|id>",
+		Short: "Deactivate a user account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := newApplication(opts)
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+			userSvc, err := resolve[service.UserService](a)
+			if err != nil {
+				return err
+			}
+			ctx := cliContext(cmd.Context(), "deactivate")
+
+			user, err := findUser(ctx, a, args[0])
+			if err != nil {
+				return err
+			}
+			inactive := false
+			if _, err := userSvc.Update(ctx, user.ID, &request.UpdateUserRequest{IsActive: &inactive}); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Deactivated %s\n", user.Email)
+			return nil
+		},
+	}
+}
+
+// findUser looks a user up by ID when ref parses as a UUID, by email otherwise.
+func findUser(ctx context.Context, a *application, ref string) (*domain.User, error) {
+	repo, err := resolve[domain.UserRepository](a)
+	if err != nil {
+		return nil, err
+	}
+	var user *domain.User
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		user, err = repo.FindByID(ctx, id)
+	} else {
+		user, err = repo.FindByEmail(ctx, ref)
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("user %q not found", ref)
+	}
+	return user, err
+}
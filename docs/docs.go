@@ -20,33 +20,75 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticates a user and returns access/refresh tokens.",
-                "consumes": [
-                    "application/json"
+        "/admin/audit-events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Returns audit events, newest first, filtered by actor, action, target and time range.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Log in a user",
+                "summary": "List audit events",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_request.LoginRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Actor user ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. user.updated",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target type, e.g. user",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive lower bound (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive upper bound (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of events to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful, tokens provided\" // Corrected: Matches code returning wrapped response.LoginResponse",
+                        "description": "Audit events",
                         "schema": {
                             "allOf": [
                                 {
@@ -56,7 +98,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/youGo_internal_api_response.LoginResponse"
+                                            "$ref": "#/definitions/youGo_internal_api_response.AuditEventListResponse"
                                         }
                                     }
                                 }
@@ -64,53 +106,131 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid input data (validation error)\" // Note: Code returns 422 for validation",
+                        "description": "Invalid filter",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     }
                 }
             }
         },
-        "/auth/signup": {
-            "post": {
-                "description": "Creates a new user account.",
-                "consumes": [
-                    "application/json"
+        "/admin/audit-events/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Streams every matching audit event as CSV or newline-delimited JSON.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Export audit events",
                 "parameters": [
                     {
-                        "description": "User Registration Details",
-                        "name": "register",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Actor user ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. user.updated",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target type, e.g. user",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Inclusive lower bound (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exclusive upper bound (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "csv (default) or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit export",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_request.CreateUserRequest"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     }
+                }
+            }
+        },
+        "/admin/diagnostics/database": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the effective connection settings (pool, timeouts, retries, slow query threshold), the statistics of the connection pool and the health of the read replicas.",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Database diagnostics",
                 "responses": {
-                    "201": {
-                        "description": "User registered successfully\" // Correct: Matches code returning wrapped response.UserResponse (assuming registerResp is compatible)",
+                    "200": {
+                        "description": "Database diagnostics",
                         "schema": {
                             "allOf": [
                                 {
@@ -120,7 +240,65 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                                            "$ref": "#/definitions/youGo_internal_api_response.DatabaseDiagnosticsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "503": {
+                        "description": "Database connection unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/usage/{subject}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the billable usage of a subject in the current billing period, with the quotas of its plan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get the usage of a user or organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user:\u003cid\u003e or org:\u003cid\u003e",
+                        "name": "subject",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usage report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.UsageReportResponse"
                                         }
                                     }
                                 }
@@ -128,34 +306,34 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid input data (validation error)",
+                        "description": "Invalid subject",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
-                    "409": {
-                        "description": "User with this email already exists",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     }
                 }
             }
         },
-        "/users": {
+        "/admin/users": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Adds a new user to the system. Typically used by administrators.",
+                "description": "Adds a new user to the system, for administrators. A retry with the same Idempotency-Key gets the first response again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -175,95 +353,151 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/youGo_internal_api_request.CreateUserRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request, for safe retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "User created successfully\"       // UPDATED: Reference response DTO",
+                        "description": "User created successfully",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Invalid input data\"             // UPDATED: Reference response DTO",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "402": {
+                        "description": "Seats quota of the plan reached (see metering.seats_subject)",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
                     "409": {
-                        "description": "User conflict (e.g., email exists)\" // UPDATED: Reference response DTO",
+                        "description": "User conflict (e.g., email exists), or the same Idempotency-Key is in flight",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid input data, or Idempotency-Key reused with another request",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
                     "500": {
                         "description": "Internal server error\"          // UPDATED: Reference response DTO",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
+        "/admin/users/search": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves details for a specific user by their ID.",
+                "description": "Finds users by partial or misspelled name or email, or by email domain, most relevant first. On Postgres, hits are ranked by full-text and trigram similarity; other databases match substrings only. Highlights are HTML-escaped with the matches wrapped in \u003cmark\u003e tags.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Get a user by ID",
+                "summary": "Search users",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Search text, e.g. a name, an email or a domain",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of hits to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User details found\"      // Corrected: domain. prefix",
+                        "description": "Search hits",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.UserSearchResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid User ID format\" // Corrected: domain. prefix",
+                        "description": "Invalid search",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
-                    "404": {
-                        "description": "User not found\"         // Corrected: domain. prefix",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
                     "500": {
-                        "description": "Internal server error\"  // Corrected: domain. prefix",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Updates details for an existing user.",
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticates a user and returns access/refresh tokens.",
                 "consumes": [
                     "application/json"
                 ],
@@ -271,51 +505,881 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Update a user",
+                "summary": "Log in a user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User details to update",
-                        "name": "user",
+                        "description": "Login credentials",
+                        "name": "login",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_request.UpdateUserRequest"
+                            "$ref": "#/definitions/youGo_internal_api_request.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully\"    // Corrected: domain. prefix",
-                        "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input data or User ID format\" // Corrected: domain. prefix",
+                        "description": "Login successful, tokens provided\" // Corrected: Matches code returning wrapped response.LoginResponse",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found\"              // Corrected: domain. prefix",
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data (validation error)\" // Note: Code returns 422 for validation",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/signup": {
+            "post": {
+                "description": "Creates a new user account. A retry with the same Idempotency-Key gets the first response again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User Registration Details",
+                        "name": "register",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_request.CreateUserRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request, for safe retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User registered successfully\" // Correct: Matches code returning wrapped response.UserResponse (assuming registerResp is compatible)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data (validation error)",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "402": {
+                        "description": "Seats quota of the plan reached (see metering.seats_subject)",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "409": {
+                        "description": "User with this email already exists, or the same Idempotency-Key is in flight",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid input data, or Idempotency-Key reused with another request",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the billable usage of the caller in the current billing period: API calls by route class, with the quotas of their plan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usage"
+                ],
+                "summary": "Get my usage",
+                "responses": {
+                    "200": {
+                        "description": "Usage report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.UsageReportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves details for a specific user by their ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details found\"      // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid User ID format\" // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found\"         // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error\"  // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates details for an existing user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User details to update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully\"    // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data or User ID format\" // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found\"              // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error\"       // Corrected: domain. prefix",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Partially updates a user with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902).\nAdmins may patch /name, /isActive, /role and /locale of any user; other users may only patch their own /name and /locale.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Patch a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch, or an array of JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_request.UserPatchDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User patched successfully",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch document or User ID format",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "403": {
+                        "description": "Path not patchable for the caller's role",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported patch media type",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "422": {
+                        "description": "Patched user failed validation",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook endpoints",
+                "responses": {
+                    "200": {
+                        "description": "Webhook endpoints",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/youGo_internal_api_response.WebhookEndpointResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Subscribes a URL to the domain events of the caller. Deliveries are signed with HMAC-SHA256 over \"\u003ctimestamp\u003e.\u003cbody\u003e\" (X-Webhook-Timestamp, X-Webhook-Signature: v1=\u003chex\u003e). The secret is only returned by this call.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook endpoint",
+                "parameters": [
+                    {
+                        "description": "Endpoint to register",
+                        "name": "endpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_request.CreateWebhookEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Endpoint created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.WebhookEndpointSecretResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queues a delivery again with a fresh retry budget, typically after it went dead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Delivery queued",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.WebhookDeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook endpoint",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.WebhookEndpointResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint not found",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     },
                     "500": {
-                        "description": "Internal server error\"       // Corrected: domain. prefix",
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Changes the URL, subscribed event types, description or active flag. Omitted fields are left unchanged. The secret cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "endpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_request.UpdateWebhookEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Endpoint updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.WebhookEndpointResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes the endpoint together with its delivery log.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Endpoint deleted"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/youGo_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns the delivery log of an endpoint, newest first, with the result of the last attempt. The body of the receiver's response (lastResponse) is only shown to admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/youGo_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/youGo_internal_api_response.WebhookDeliveryListResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/youGo_internal_api_problem.Details"
                         }
                     }
                 }
@@ -323,6 +1387,83 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "youGo_internal_api_problem.Details": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Stable code of the problem, e.g. \"user.not_found\"",
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "Message of Code in the caller's language",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Invalid fields and why, for validation problems",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "instance": {
+                    "description": "Path of the request",
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Values in the message, e.g. {\"column\": \"email\"}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "requestId": {
+                    "description": "X-Request-ID of the request",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "violations": {
+                    "description": "Rules the fields failed, for validation problems",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/youGo_internal_api_problem.Violation"
+                    }
+                }
+            }
+        },
+        "youGo_internal_api_problem.Violation": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Rule, e.g. \"required\" or \"min\"",
+                    "type": "string"
+                },
+                "field": {
+                    "description": "JSON path, e.g. \"items[0].name\"",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "params": {
+                    "description": "Arguments of the rule, e.g. {\"limit\": \"8\"}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "youGo_internal_api_request.CreateUserRequest": {
             "type": "object",
             "required": [
@@ -339,7 +1480,36 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string",
-                    "minLength": 8
+                    "minLength": 8
+                }
+            }
+        },
+        "youGo_internal_api_request.CreateWebhookEndpointRequest": {
+            "type": "object",
+            "required": [
+                "eventTypes",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Generated when omitted",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
@@ -366,6 +1536,10 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
+                "locale": {
+                    "description": "\"\" follows Accept-Language again",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -374,18 +1548,184 @@ const docTemplate = `{
                 }
             }
         },
-        "youGo_internal_api_response.ErrorResponse": {
+        "youGo_internal_api_request.UpdateWebhookEndpointRequest": {
             "type": "object",
             "properties": {
-                "details": {
-                    "description": "Optional: Detailed error info (e.g., validation failures)"
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "message": {
-                    "description": "User-friendly error message",
+                "eventTypes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "youGo_internal_api_request.UserPatchDocument": {
+            "type": "object",
+            "required": [
+                "isActive",
+                "name",
+                "role"
+            ],
+            "properties": {
+                "isActive": {
+                    "type": "boolean"
+                },
+                "locale": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "Typically \"error\" or \"fail\" (for validation)",
+                "name": {
+                    "type": "string",
+                    "minLength": 2
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user"
+                    ]
+                }
+            }
+        },
+        "youGo_internal_api_response.AuditEventListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/youGo_internal_api_response.AuditEventResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "youGo_internal_api_response.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/youGo_internal_domain.FieldChange"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "targetId": {
+                    "type": "string"
+                },
+                "targetType": {
+                    "type": "string"
+                }
+            }
+        },
+        "youGo_internal_api_response.DatabaseDiagnosticsResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "type": "string"
+                },
+                "pool": {
+                    "$ref": "#/definitions/youGo_internal_api_response.DatabasePoolResponse"
+                },
+                "replicas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/youGo_internal_api_response.ReplicaStatusResponse"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/youGo_internal_api_response.DatabaseSettingsResponse"
+                }
+            }
+        },
+        "youGo_internal_api_response.DatabasePoolResponse": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer"
+                },
+                "inUse": {
+                    "type": "integer"
+                },
+                "maxIdleClosed": {
+                    "type": "integer"
+                },
+                "maxIdleTimeClosed": {
+                    "type": "integer"
+                },
+                "maxLifetimeClosed": {
+                    "type": "integer"
+                },
+                "openConnections": {
+                    "type": "integer"
+                },
+                "waitCount": {
+                    "type": "integer"
+                },
+                "waitDuration": {
+                    "type": "string"
+                }
+            }
+        },
+        "youGo_internal_api_response.DatabaseSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "connMaxIdleTime": {
+                    "type": "string"
+                },
+                "connMaxLifetime": {
+                    "type": "string"
+                },
+                "connectRetries": {
+                    "type": "integer"
+                },
+                "connectRetryBackoff": {
+                    "type": "string"
+                },
+                "maxIdleConns": {
+                    "type": "integer"
+                },
+                "maxOpenConns": {
+                    "type": "integer"
+                },
+                "slowQueryThreshold": {
+                    "type": "string"
+                },
+                "statementTimeout": {
                     "type": "string"
                 }
             }
@@ -415,6 +1755,17 @@ const docTemplate = `{
                 }
             }
         },
+        "youGo_internal_api_response.ReplicaStatusResponse": {
+            "type": "object",
+            "properties": {
+                "healthy": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "youGo_internal_api_response.SuccessResponse": {
             "type": "object",
             "properties": {
@@ -427,6 +1778,60 @@ const docTemplate = `{
                 }
             }
         },
+        "youGo_internal_api_response.UsageMeterResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "Quota of the plan; none when unlimited",
+                    "type": "integer"
+                },
+                "meter": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "youGo_internal_api_response.UsageReportResponse": {
+            "type": "object",
+            "properties": {
+                "meters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/youGo_internal_api_response.UsageMeterResponse"
+                    }
+                },
+                "periodEnd": {
+                    "type": "string"
+                },
+                "periodStart": {
+                    "type": "string"
+                },
+                "plan": {
+                    "description": "None when usage is unlimited",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "e.g. \"user:\u003cid\u003e\" or \"org:\u003cid\u003e\"",
+                    "type": "string"
+                }
+            }
+        },
+        "youGo_internal_api_response.UserHighlightsResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "youGo_internal_api_response.UserResponse": {
             "type": "object",
             "properties": {
@@ -443,6 +1848,10 @@ const docTemplate = `{
                 "isActive": {
                     "type": "boolean"
                 },
+                "locale": {
+                    "description": "Preferred language of messages, if any",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -453,6 +1862,180 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "youGo_internal_api_response.UserSearchHitResponse": {
+            "type": "object",
+            "properties": {
+                "highlights": {
+                    "$ref": "#/definitions/youGo_internal_api_response.UserHighlightsResponse"
+                },
+                "rank": {
+                    "description": "Higher is more relevant; 0 when the database doesn't rank",
+                    "type": "number"
+                },
+                "user": {
+                    "$ref": "#/definitions/youGo_internal_api_response.UserResponse"
+                }
+            }
+        },
+        "youGo_internal_api_response.UserSearchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/youGo_internal_api_response.UserSearchHitResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "youGo_internal_api_response.WebhookDeliveryListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/youGo_internal_api_response.WebhookDeliveryResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "youGo_internal_api_response.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "endpointId": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastAttemptAt": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastResponse": {
+                    "type": "string"
+                },
+                "lastStatusCode": {
+                    "type": "integer"
+                },
+                "nextAttemptAt": {
+                    "description": "Only while pending",
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "youGo_internal_api_response.WebhookEndpointResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "youGo_internal_api_response.WebhookEndpointSecretResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "youGo_internal_domain.FieldChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
         }
     }
 }`
@@ -0,0 +1,115 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package handler /youGo/internal/api/handler/audit_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/service"
+)
+
+// AuditHandler serves the admin API over the audit trail.
+type AuditHandler struct {
+	auditService service.AuditService
+}
+
+// NewAuditHandler creates a new instance of AuditHandler.
+func NewAuditHandler(auditSvc service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditSvc,
+	}
+}
+
+// ListAuditEvents godoc
+// @Summary      List audit events
+// @Description  Returns audit events, newest first, filtered by actor, action, target and time range.
+// @Tags         Admin
+// @Produce      json
+// @Param        actor_id    query string false "Actor user ID" format(uuid)
+// @Param        action      query string false "Action, e.g. user.updated"
+// @Param        target_type query string false "Target type, e.g. user"
+// @Param        target_id   query string false "Target ID"
+// @Param        from        query string false "Inclusive lower bound (RFC 3339)"
+// @Param        to          query string false "Exclusive upper bound (RFC 3339)"
+// @Param        limit       query int    false "Page size (default 50, max 500)"
+// @Param        offset      query int    false "Number of events to skip"
+// @Success      200 {object} response.SuccessResponse{data=response.AuditEventListResponse} "Audit events"
+// @Failure      400 {object} problem.Details "Invalid filter"
+// @Failure      403 {object} problem.Details "Admin role required"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /admin/audit-events [get]
+// @Security     ApiKeyAuth
+func (h *AuditHandler) ListAuditEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	req := new(request.ListAuditEventsRequest)
+
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidQuery, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	list, err := h.auditService.List(ctx, req)
+	if err != nil {
+		return fmt.Errorf("listing audit events: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(list))
+}
+
+// ExportAuditEvents godoc
+// @Summary      Export audit events
+// @Description  Streams every matching audit event as CSV or newline-delimited JSON.
+// @Tags         Admin
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        actor_id    query string false "Actor user ID" format(uuid)
+// @Param        action      query string false "Action, e.g. user.updated"
+// @Param        target_type query string false "Target type, e.g. user"
+// @Param        target_id   query string false "Target ID"
+// @Param        from        query string false "Inclusive lower bound (RFC 3339)"
+// @Param        to          query string false "Exclusive upper bound (RFC 3339)"
+// @Param        format      query string false "csv (default) or ndjson" Enums(csv, ndjson)
+// @Success      200 {file} file "Audit export"
+// @Failure      400 {object} problem.Details "Invalid filter"
+// @Failure      403 {object} problem.Details "Admin role required"
+// @Router       /admin/audit-events/export [get]
+// @Security     ApiKeyAuth
+func (h *AuditHandler) ExportAuditEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	req := new(request.ExportAuditEventsRequest)
+
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidQuery, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	contentType, ext := "text/csv", service.AuditExportCSV
+	if req.Format == service.AuditExportNDJSON {
+		contentType, ext = "application/x-ndjson", service.AuditExportNDJSON
+	}
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="audit-events-%s.%s"`, time.Now().UTC().Format("20060102T150405Z"), ext))
+	res.WriteHeader(http.StatusOK)
+
+	// Headers are already sent once streaming starts, so errors can only be logged from here on.
+	if err := h.auditService.Export(ctx, req, res); err != nil {
+		c.Logger().Error("Export audit events failed:", err)
+	}
+	return nil
+}
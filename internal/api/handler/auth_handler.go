@@ -6,6 +6,7 @@
 package handler
 
 import (
+	"youGo/internal/api/problem"  // RFC 7807 error responses
 	"youGo/internal/api/request"  // Request DTOs
 	"youGo/internal/api/response" // Response DTOs
 	"youGo/internal/auth"         // Interfaces for Auth Service
@@ -13,6 +14,7 @@ import (
 	"youGo/internal/service"      // Interfaces for Services lives here
 
 	"errors" // For error checking (errors.Is)
+	"fmt"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap" // Zap logger
@@ -38,15 +40,18 @@ func NewAuthHandler(authSvc auth.Service, userSvc service.UserService, logger *z
 
 // Register godoc
 // @Summary      Register a new user
-// @Description  Creates a new user account.
+// @Description  Creates a new user account. A retry with the same Idempotency-Key gets the first response again.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        register body request.CreateUserRequest true "User Registration Details"  // Correct: Matches code binding request.CreateUserRequest
+// @Param        Idempotency-Key header string false "Unique key of the request, for safe retries"
 // @Success      201 {object} response.SuccessResponse{data=response.UserResponse} "User registered successfully" // Correct: Matches code returning wrapped response.UserResponse (assuming registerResp is compatible)
-// @Failure      400 {object} response.ErrorResponse "Invalid input data (validation error)"
-// @Failure      409 {object} response.ErrorResponse "User with this email already exists"
-// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Failure      400 {object} problem.Details "Invalid input data (validation error)"
+// @Failure      402 {object} problem.Details "Seats quota of the plan reached (see metering.seats_subject)"
+// @Failure      409 {object} problem.Details "User with this email already exists, or the same Idempotency-Key is in flight"
+// @Failure      422 {object} problem.Details "Invalid input data, or Idempotency-Key reused with another request"
+// @Failure      500 {object} problem.Details "Internal server error"
 // @Router       /auth/signup [post]
 func (h *AuthHandler) Register(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -55,31 +60,24 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	// 1. Bind Request Body (now binds directly to request.CreateUserRequest)
 	if err := c.Bind(req); err != nil {
 		h.logger.Warn("Failed to bind registration request", zap.Error(err))
-		// Consider using domain.NewErrorResponse if defined and appropriate
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+		return problem.BadRequest(problem.CodeInvalidBody, err)
 	}
 
-	// 2. Validate Request Data (ensure validation tags exist on request.CreateUserRequest)
+	// 2. Validate Request Data (validation tags on request.CreateUserRequest); failures are 422 problems
 	if err := c.Validate(req); err != nil {
 		h.logger.Warn("Registration request validation failed", zap.Error(err))
-		// validationDetails := response.NewValidationError(err) // This might need adjustment if error format changes
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Input validation failed") // Keep simple or adjust error reporting
+		return err
 	}
 
 	// 3. Call Service Layer - 'req' is now the correct type (*request.CreateUserRequest)
 	registerResp, err := h.userService.Create(ctx, req)
 	if err != nil {
-		// 4. Handle Service Errors (remains mostly the same, ensure errors match domain errors)
-		switch {
-		case errors.Is(err, domain.ErrDuplicateEntry):
+		// 4. Domain errors are mapped by problem.Handler, which also logs internal ones
+		if errors.Is(err, domain.ErrDuplicateEntry) {
 			h.logger.Warn("Registration attempt failed: user already exists", zap.String("email", req.Email))
-			// Consider using domain.NewErrorResponse
-			return echo.NewHTTPError(http.StatusConflict, domain.ErrDuplicateEntry.Error()) // Use domain error message
-		default:
-			h.logger.Error("Internal error during user registration", zap.Error(err), zap.String("email", req.Email))
-			// Consider using response.NewErrorResponse
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to register user")
+			return problem.WithCode(err, problem.CodeUserEmailTaken)
 		}
+		return fmt.Errorf("registering user: %w", err)
 	}
 
 	// 5. Return Successful Response (registerResp is now *response.UserResponse)
@@ -97,9 +95,9 @@ func (h *AuthHandler) Register(c echo.Context) error {
 // @Produce      json
 // @Param        login body request.LoginRequest true "Login credentials" // Correct: Matches code binding request.LoginRequest
 // @Success      200 {object} response.SuccessResponse{data=response.LoginResponse} "Login successful, tokens provided" // Corrected: Matches code returning wrapped response.LoginResponse
-// @Failure      400 {object} response.ErrorResponse "Invalid input data (validation error)" // Note: Code returns 422 for validation
-// @Failure      401 {object} response.ErrorResponse "Invalid credentials"
-// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Failure      400 {object} problem.Details "Invalid input data (validation error)" // Note: Code returns 422 for validation
+// @Failure      401 {object} problem.Details "Invalid credentials"
+// @Failure      500 {object} problem.Details "Internal server error"
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -107,20 +105,13 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	// 1. Bind Request Body
 	if err := c.Bind(req); err != nil {
 		h.logger.Warn("Failed to bind login request", zap.Error(err))
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error()) // Include binding error detail
+		return problem.BadRequest(problem.CodeInvalidBody, err)
 	}
 
-	// 2. Validate Request Data
+	// 2. Validate Request Data; failures are 422 problems listing the invalid fields
 	if err := c.Validate(req); err != nil {
 		h.logger.Warn("Login request validation failed", zap.Error(err))
-		validationDetails := response.NewValidationError(err) // Assume returns map[string]string or similar
-		// Option 1: Pass details if your custom error handler can use them
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, validationDetails)
-
-		// Option 2: Convert details to a simple string message (loses structure)
-		// return echo.NewHTTPError(http.StatusUnprocessableEntity, fmt.Sprintf("Validation failed: %v", validationDetails))
-		// Option 3: Keep simple message if details aren't critical for the client
-		// return echo.NewHTTPError(http.StatusUnprocessableEntity, "Input validation failed") // Keep generic but clear
+		return err
 	}
 
 	// 3. Call Service Layer
@@ -132,10 +123,9 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		// TODO: Confirm domain/service error before using as condition.
 		case errors.Is(err, auth.ErrInvalidCredentials): // Use error from auth package
 			h.logger.Warn("Login attempt failed: invalid credentials", zap.String("email", req.Email))
-			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			return problem.Error(http.StatusUnauthorized, problem.CodeInvalidCredentials)
 		default:
-			h.logger.Error("Internal error during user login", zap.Error(err), zap.String("email", req.Email))
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to login due to an internal error")
+			return fmt.Errorf("logging in: %w", err)
 		}
 	}
 
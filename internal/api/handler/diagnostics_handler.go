@@ -0,0 +1,52 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package handler /youGo/internal/api/handler/diagnostics_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/api/response"
+	"youGo/internal/platform/database"
+)
+
+// DiagnosticsHandler serves the admin API describing how the application runs.
+type DiagnosticsHandler struct {
+	db       *gorm.DB
+	settings database.Settings
+	replicas *database.ReplicaSet
+}
+
+// NewDiagnosticsHandler creates a new instance of DiagnosticsHandler. replicas is nil without read replicas.
+func NewDiagnosticsHandler(db *gorm.DB, settings database.Settings, replicas *database.ReplicaSet) *DiagnosticsHandler {
+	return &DiagnosticsHandler{
+		db:       db,
+		settings: settings,
+		replicas: replicas,
+	}
+}
+
+// GetDatabaseDiagnostics godoc
+// @Summary      Database diagnostics
+// @Description  Returns the effective connection settings (pool, timeouts, retries, slow query threshold), the statistics of the connection pool and the health of the read replicas.
+// @Tags         Admin
+// @Produce      json
+// @Success      200 {object} response.SuccessResponse{data=response.DatabaseDiagnosticsResponse} "Database diagnostics"
+// @Failure      403 {object} problem.Details "Admin role required"
+// @Failure      503 {object} problem.Details "Database connection unavailable"
+// @Router       /admin/diagnostics/database [get]
+// @Security     ApiKeyAuth
+func (h *DiagnosticsHandler) GetDatabaseDiagnostics(c echo.Context) error {
+	diagnostics, err := database.Diagnose(h.db, h.settings, h.replicas)
+	if err != nil {
+		c.Logger().Error("Database diagnostics failed:", err)
+		return problem.Error(http.StatusServiceUnavailable, problem.CodeDatabaseUnavailable)
+	}
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(response.NewDatabaseDiagnosticsResponse(diagnostics)))
+}
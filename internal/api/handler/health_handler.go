@@ -0,0 +1,48 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package handler /youGo/internal/api/handler/health_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/platform/health"
+)
+
+// HealthHandler serves the liveness and readiness probes. Their reports are summed up without
+// the success envelope of the API: probes only look at the status code, people at the status
+// of each check. Why a check failed is in the logs only (see health.Options.Logger).
+type HealthHandler struct {
+	registry *health.Registry
+}
+
+// NewHealthHandler creates a new instance of HealthHandler.
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+	return &HealthHandler{
+		registry: registry,
+	}
+}
+
+// Liveness answers 200 while the process works, or 503 when one of the liveness checks fails.
+func (h *HealthHandler) Liveness(c echo.Context) error {
+	return writeHealthReport(c, h.registry.Liveness(c.Request().Context()))
+}
+
+// Readiness answers 200 when the instance can serve requests, or 503 when one of the checks
+// fails or the shutdown started.
+func (h *HealthHandler) Readiness(c echo.Context) error {
+	return writeHealthReport(c, h.registry.Readiness(c.Request().Context()))
+}
+
+func writeHealthReport(c echo.Context, report health.Report) error {
+	// Probes must see the state of now, not that of a cache on the way
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	if !report.OK() {
+		return c.JSON(http.StatusServiceUnavailable, report.Summary())
+	}
+	return c.JSON(http.StatusOK, report.Summary())
+}
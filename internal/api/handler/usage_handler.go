@@ -0,0 +1,73 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package handler /youGo/internal/api/handler/usage_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+	"youGo/internal/service"
+)
+
+// UsageHandler serves the usage reports of users and organizations.
+type UsageHandler struct {
+	meteringService service.MeteringService
+}
+
+// NewUsageHandler creates a new instance of UsageHandler.
+func NewUsageHandler(meteringSvc service.MeteringService) *UsageHandler {
+	return &UsageHandler{
+		meteringService: meteringSvc,
+	}
+}
+
+// GetMyUsage godoc
+// @Summary      Get my usage
+// @Description  Returns the billable usage of the caller in the current billing period: API calls by route class, with the quotas of their plan.
+// @Tags         Usage
+// @Produce      json
+// @Success      200 {object} response.SuccessResponse{data=response.UsageReportResponse} "Usage report"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /usage [get]
+// @Security     ApiKeyAuth
+func (h *UsageHandler) GetMyUsage(c echo.Context) error {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		return problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
+	}
+	report, err := h.meteringService.Report(c.Request().Context(), domain.UserSubject(userID))
+	if err != nil {
+		return fmt.Errorf("reporting usage: %w", err)
+	}
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(report))
+}
+
+// GetUsage godoc
+// @Summary      Get the usage of a user or organization
+// @Description  Returns the billable usage of a subject in the current billing period, with the quotas of its plan.
+// @Tags         Admin
+// @Produce      json
+// @Param        subject path string true "user:<id> or org:<id>"
+// @Success      200 {object} response.SuccessResponse{data=response.UsageReportResponse} "Usage report"
+// @Failure      400 {object} problem.Details "Invalid subject"
+// @Failure      403 {object} problem.Details "Admin role required"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /admin/usage/{subject} [get]
+// @Security     ApiKeyAuth
+func (h *UsageHandler) GetUsage(c echo.Context) error {
+	report, err := h.meteringService.Report(c.Request().Context(), c.Param("subject"))
+	if err != nil {
+		return fmt.Errorf("reporting usage: %w", err)
+	}
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(report))
+}
@@ -6,13 +6,22 @@
 package handler
 
 import (
-	"github.com/labstack/echo/v4"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+
+	"github.com/labstack/echo/v4"
 	"youGo/internal/domain"
 
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
 	"youGo/internal/api/request"
 	// --- Internal Imports ---
 	"youGo/internal/api/response"
+	"youGo/internal/platform/patch"
 	"youGo/internal/service"
 
 	"github.com/google/uuid"
@@ -21,47 +30,49 @@ import (
 // UserHandler handles user resource related HTTP requests.
 type UserHandler struct {
 	userService service.UserService // Dependency: UserService interface
+	txManager   domain.TxManager    // Makes the read-patch-write cycle of PatchUser atomic
 }
 
 // NewUserHandler creates a new instance of UserHandler.
-func NewUserHandler(userSvc service.UserService) *UserHandler {
+func NewUserHandler(userSvc service.UserService, txManager domain.TxManager) *UserHandler {
 	return &UserHandler{
 		userService: userSvc,
+		txManager:   txManager,
 	}
 }
 
 // CreateUser godoc
 // @Summary      Create a new user
-// @Description  Adds a new user to the system. Typically used by administrators.
+// @Description  Adds a new user to the system, for administrators. A retry with the same Idempotency-Key gets the first response again.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
 // @Param        user body request.CreateUserRequest true "User details for creation" // Request uses domain type - OK
-// @Success      201 {object} response.UserResponse "User created successfully"       // UPDATED: Reference response DTO
-// @Failure      400 {object} response.ErrorResponse "Invalid input data"             // UPDATED: Reference response DTO
-// @Failure      409 {object} response.ErrorResponse "User conflict (e.g., email exists)" // UPDATED: Reference response DTO
-// @Failure      500 {object} response.ErrorResponse "Internal server error"          // UPDATED: Reference response DTO
-// @Router       /users [post]
+// @Param        Idempotency-Key header string false "Unique key of the request, for safe retries"
+// @Success      201 {object} response.SuccessResponse{data=response.UserResponse} "User created successfully"
+// @Failure      400 {object} problem.Details "Invalid input data"             // UPDATED: Reference response DTO
+// @Failure      402 {object} problem.Details "Seats quota of the plan reached (see metering.seats_subject)"
+// @Failure      403 {object} problem.Details "Admin role required"
+// @Failure      409 {object} problem.Details "User conflict (e.g., email exists), or the same Idempotency-Key is in flight"
+// @Failure      422 {object} problem.Details "Invalid input data, or Idempotency-Key reused with another request"
+// @Failure      500 {object} problem.Details "Internal server error"          // UPDATED: Reference response DTO
+// @Router       /admin/users [post]
 // @Security     ApiKeyAuth
 func (h *UserHandler) CreateUser(c echo.Context) error {
 	ctx := c.Request().Context()
 	req := new(request.CreateUserRequest)
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", http.StatusBadRequest))
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidBody, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
 	}
 
-	// Optional: Validate DTO
-	// if err := c.Validate(&req); err != nil { ... }
-
+	// Domain errors (e.g. a taken email) are mapped to problem responses by problem.Handler
 	domainUser, err := h.userService.Create(ctx, req)
 	if err != nil {
-		// Handle errors (e.g., conflict, validation, internal)
-		// Map service.ErrUserAlreadyExists -> http.StatusConflict
-		// Map service.ErrValidation -> http.StatusBadRequest
-		// ... etc ...
-		c.Logger().Error("Create user service call failed:", err)
-		return c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create user", http.StatusInternalServerError)) // Placeholder
+		return fmt.Errorf("creating user: %w", err)
 	}
 
 	responseDto := response.NewSuccessResponse(domainUser)
@@ -76,9 +87,9 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 // @Produce      json
 // @Param        id path string true "User ID" format(uuid) // Added format(uuid)
 // @Success      200 {object} response.UserResponse "User details found"      // Corrected: domain. prefix
-// @Failure      400 {object} response.ErrorResponse "Invalid User ID format" // Corrected: domain. prefix
-// @Failure      404 {object} response.ErrorResponse "User not found"         // Corrected: domain. prefix
-// @Failure      500 {object} response.ErrorResponse "Internal server error"  // Corrected: domain. prefix
+// @Failure      400 {object} problem.Details "Invalid User ID format" // Corrected: domain. prefix
+// @Failure      404 {object} problem.Details "User not found"         // Corrected: domain. prefix
+// @Failure      500 {object} problem.Details "Internal server error"  // Corrected: domain. prefix
 // @Router       /users/{id} [get]
 // @Security     ApiKeyAuth
 func (h *UserHandler) GetUserByID(c echo.Context) error {
@@ -89,18 +100,13 @@ func (h *UserHandler) GetUserByID(c echo.Context) error {
 	parsedUUID, err := uuid.Parse(idStr) // Correctly parse to uuid.UUID type
 	if err != nil {
 		// Parsing failed, means idStr is not a valid UUID format
-		return c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid user ID format", http.StatusBadRequest))
+		return problem.Error(http.StatusBadRequest, problem.CodeInvalidID)
 	}
 
 	// 2. Call service with the parsed uuid.UUID
 	userResp, err := h.userService.GetByID(ctx, parsedUUID) // Pass the uuid.UUID type
 	if err != nil {
-		// Handle errors (map domain.ErrNotFound to http.StatusNotFound, etc.)
-		if err == domain.ErrNotFound { // Example check
-			return c.JSON(http.StatusNotFound, response.NewErrorResponse("User not found", http.StatusNotFound))
-		}
-		c.Logger().Error("Get user by ID service call failed:", err)
-		return c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve user", http.StatusInternalServerError))
+		return problem.WithCode(err, problem.CodeUserNotFound)
 	}
 
 	// 3. Return response
@@ -116,9 +122,9 @@ func (h *UserHandler) GetUserByID(c echo.Context) error {
 // @Param        id path string true "User ID" format(uuid) // Added format(uuid)
 // @Param        user body request.UpdateUserRequest true "User details to update" // Corrected: domain. prefix
 // @Success      200 {object} response.UserResponse "User updated successfully"    // Corrected: domain. prefix
-// @Failure      400 {object} response.ErrorResponse "Invalid input data or User ID format" // Corrected: domain. prefix
-// @Failure      404 {object} response.ErrorResponse "User not found"              // Corrected: domain. prefix
-// @Failure      500 {object} response.ErrorResponse "Internal server error"       // Corrected: domain. prefix
+// @Failure      400 {object} problem.Details "Invalid input data or User ID format" // Corrected: domain. prefix
+// @Failure      404 {object} problem.Details "User not found"              // Corrected: domain. prefix
+// @Failure      500 {object} problem.Details "Internal server error"       // Corrected: domain. prefix
 // @Router       /users/{id} [put] // Or PATCH
 // @Security     ApiKeyAuth
 func (h *UserHandler) UpdateUser(c echo.Context) error {
@@ -130,30 +136,141 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 	userID, err := uuid.Parse(idStr) // Correctly parse to uuid.UUID type
 
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid user ID format", http.StatusBadRequest))
+		return problem.Error(http.StatusBadRequest, problem.CodeInvalidID)
 	}
 
-	// 2. Bind request body
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", http.StatusBadRequest))
+	// 2. Bind and validate request body
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidBody, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
 	}
 
-	// 3. Optional: Validate DTO
-	// if err := c.Validate(&req); err != nil { ... }
-
-	// 4. Call service
+	// 3. Call service; a missing user is a 404, a taken email a 409 (see problem.FromError)
 	userResp, err := h.userService.Update(ctx, userID, req) // Pass ID and request DTO
 	if err != nil {
-		// Handle errors (e.g., not found, validation, internal)
-		// Map service.ErrNotFound -> http.StatusNotFound
-		// Map service.ErrValidation -> http.StatusBadRequest
-		// ... etc ...
-		c.Logger().Error("Update user service call failed:", err)
-		return c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update user", http.StatusInternalServerError)) // Placeholder
+		return fmt.Errorf("updating user: %w", err)
 	}
 
-	// 5. Return updated user data
+	// 4. Return updated user data
 	return c.JSON(http.StatusOK, userResp) // Use your UserResponse DTO
 }
 
+// PatchUser godoc
+// @Summary      Patch a user
+// @Description  Partially updates a user with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902).
+// @Description  Admins may patch /name, /isActive, /role and /locale of any user; other users may only patch their own /name and /locale.
+// @Tags         Users
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id path string true "User ID" format(uuid)
+// @Param        patch body request.UserPatchDocument true "Merge patch, or an array of JSON Patch operations"
+// @Success      200 {object} response.UserResponse "User patched successfully"
+// @Failure      400 {object} problem.Details "Invalid patch document or User ID format"
+// @Failure      401 {object} problem.Details "Not authenticated"
+// @Failure      403 {object} problem.Details "Path not patchable for the caller's role"
+// @Failure      404 {object} problem.Details "User not found"
+// @Failure      409 {object} problem.Details "JSON Patch test operation failed"
+// @Failure      415 {object} problem.Details "Unsupported patch media type"
+// @Failure      422 {object} problem.Details "Patched user failed validation"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /users/{id} [patch]
+// @Security     ApiKeyAuth
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// 1. Validate/Parse ID and patch format
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return problem.Error(http.StatusBadRequest, problem.CodeInvalidID)
+	}
+	mediaType, err := patch.MediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return problem.Error(http.StatusUnsupportedMediaType, problem.CodePatchUnsupportedMediaType,
+			"types", patch.MediaTypeMergePatch+", "+patch.MediaTypeJSONPatch)
+	}
+
+	// 2. Resolve the caller and the paths their role may patch
+	actorID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		return problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
+	}
+	actor, err := h.userService.GetByID(ctx, actorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
+		}
+		return fmt.Errorf("looking up patching user: %w", err)
+	}
+	if actor.Role != domain.RoleAdmin && actorID != userID {
+		return problem.WithCode(domain.ErrPermissionDenied, problem.CodeUserPatchNotOwner)
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return problem.BadRequest(problem.CodeInvalidBody, err)
+	}
+
+	// Steps 3 to 6 run in one transaction, the user locked from the read to the write: a "test"
+	// operation holds until the change is saved, and concurrent patches apply one after the other.
+	var userResp *response.UserResponse
+	err = h.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var patchErr error
+		userResp, patchErr = h.applyUserPatch(ctx, c, userID, mediaType, body, request.PatchableUserPaths[actor.Role])
+		return patchErr
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, userResp)
+}
+
+// applyUserPatch patches the user userID with body, restricted to the allowed paths, and saves it.
+func (h *UserHandler) applyUserPatch(ctx context.Context, c echo.Context, userID uuid.UUID, mediaType string, body []byte, allowed []string) (*response.UserResponse, error) {
+	// 3. Load the current state as the document to patch
+	current, err := h.userService.GetByIDForUpdate(ctx, userID)
+	if err != nil {
+		return nil, problem.WithCode(err, problem.CodeUserNotFound)
+	}
+	doc, err := json.Marshal(request.UserPatchDocument{
+		Name: current.Name, IsActive: &current.IsActive, Role: current.Role, Locale: current.Locale,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding user to patch: %w", err)
+	}
+
+	// 4. Apply the patch, restricted to the whitelisted paths
+	patched, err := patch.Apply(mediaType, doc, body, allowed)
+	if err != nil {
+		var pathErr *patch.PathNotAllowedError
+		switch {
+		case errors.As(err, &pathErr):
+			return nil, problem.WithCode(domain.ErrPermissionDenied, problem.CodeUserPatchPathForbidden, "path", pathErr.Path)
+		case errors.Is(err, patch.ErrTestFailed):
+			return nil, problem.Error(http.StatusConflict, problem.CodePatchTestFailed)
+		default:
+			return nil, problem.WithCode(echo.NewHTTPError(http.StatusBadRequest).SetInternal(err), problem.CodePatchInvalid)
+		}
+	}
+
+	// 5. Validate the result with the usual request rules
+	result := new(request.UserPatchDocument)
+	if err := json.Unmarshal(patched, result); err != nil {
+		return nil, problem.WithCode(echo.NewHTTPError(http.StatusUnprocessableEntity).SetInternal(err), problem.CodePatchInvalidResult)
+	}
+	if err := c.Validate(result); err != nil {
+		return nil, err
+	}
+
+	// 6. Persist through the regular update path
+	userResp, err := h.userService.Update(ctx, userID, result.ToUpdateUserRequest())
+	if err != nil {
+		return nil, fmt.Errorf("patching user: %w", err)
+	}
+	return userResp, nil
+}
+
 // Add other user-related handlers if needed (e.g., GetCurrentUser, ListUsers with pagination/filtering)
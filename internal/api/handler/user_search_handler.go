@@ -0,0 +1,63 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package handler /youGo/internal/api/handler/user_search_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/service"
+)
+
+// UserSearchHandler serves the admin user search.
+type UserSearchHandler struct {
+	searchService service.UserSearchService
+}
+
+// NewUserSearchHandler creates a new instance of UserSearchHandler.
+func NewUserSearchHandler(searchSvc service.UserSearchService) *UserSearchHandler {
+	return &UserSearchHandler{
+		searchService: searchSvc,
+	}
+}
+
+// SearchUsers godoc
+// @Summary      Search users
+// @Description  Finds users by partial or misspelled name or email, or by email domain, most relevant first. On Postgres, hits are ranked by full-text and trigram similarity; other databases match substrings only. Highlights are HTML-escaped with the matches wrapped in <mark> tags.
+// @Tags         Admin
+// @Produce      json
+// @Param        q      query string true  "Search text, e.g. a name, an email or a domain"
+// @Param        limit  query int    false "Page size (default 20, max 100)"
+// @Param        offset query int    false "Number of hits to skip"
+// @Success      200 {object} response.SuccessResponse{data=response.UserSearchResponse} "Search hits"
+// @Failure      400 {object} problem.Details "Invalid search"
+// @Failure      403 {object} problem.Details "Admin role required"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /admin/users/search [get]
+// @Security     ApiKeyAuth
+func (h *UserSearchHandler) SearchUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+	req := new(request.SearchUsersRequest)
+
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidQuery, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	result, err := h.searchService.Search(ctx, req)
+	if err != nil {
+		return fmt.Errorf("searching users: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(result))
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package handler /youGo/internal/api/handler/webhook_handler.go
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+	"youGo/internal/service"
+)
+
+// WebhookHandler serves the API for the webhook endpoints of the caller and their delivery log.
+type WebhookHandler struct {
+	webhookService service.WebhookService
+	userService    service.UserService // Tells admins, who see the bodies of the receivers' responses
+}
+
+// NewWebhookHandler creates a new instance of WebhookHandler.
+func NewWebhookHandler(webhookSvc service.WebhookService, userSvc service.UserService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookSvc,
+		userService:    userSvc,
+	}
+}
+
+// CreateEndpoint godoc
+// @Summary      Register a webhook endpoint
+// @Description  Subscribes a URL to the domain events of the caller. Deliveries are signed with HMAC-SHA256 over "<timestamp>.<body>" (X-Webhook-Timestamp, X-Webhook-Signature: v1=<hex>). The secret is only returned by this call.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        endpoint body request.CreateWebhookEndpointRequest true "Endpoint to register"
+// @Success      201 {object} response.SuccessResponse{data=response.WebhookEndpointSecretResponse} "Endpoint created"
+// @Failure      400 {object} problem.Details "Invalid input"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /webhooks [post]
+// @Security     ApiKeyAuth
+func (h *WebhookHandler) CreateEndpoint(c echo.Context) error {
+	ctx := c.Request().Context()
+	owner, err := webhookOwner(c)
+	if err != nil {
+		return err
+	}
+	req := new(request.CreateWebhookEndpointRequest)
+
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidBody, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(ctx, owner, req)
+	if err != nil {
+		return fmt.Errorf("creating webhook endpoint: %w", err)
+	}
+	return c.JSON(http.StatusCreated, response.NewSuccessResponse(endpoint))
+}
+
+// ListEndpoints godoc
+// @Summary      List webhook endpoints
+// @Tags         Webhooks
+// @Produce      json
+// @Success      200 {object} response.SuccessResponse{data=[]response.WebhookEndpointResponse} "Webhook endpoints"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /webhooks [get]
+// @Security     ApiKeyAuth
+func (h *WebhookHandler) ListEndpoints(c echo.Context) error {
+	owner, err := webhookOwner(c)
+	if err != nil {
+		return err
+	}
+	endpoints, err := h.webhookService.ListEndpoints(c.Request().Context(), owner)
+	if err != nil {
+		return fmt.Errorf("listing webhook endpoints: %w", err)
+	}
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(endpoints))
+}
+
+// GetEndpoint godoc
+// @Summary      Get a webhook endpoint
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id path string true "Endpoint ID" format(uuid)
+// @Success      200 {object} response.SuccessResponse{data=response.WebhookEndpointResponse} "Webhook endpoint"
+// @Failure      400 {object} problem.Details "Invalid ID"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      404 {object} problem.Details "Endpoint not found"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /webhooks/{id} [get]
+// @Security     ApiKeyAuth
+func (h *WebhookHandler) GetEndpoint(c echo.Context) error {
+	owner, err := webhookOwner(c)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return problem.BadRequest(problem.CodeInvalidID, err)
+	}
+
+	endpoint, err := h.webhookService.GetEndpoint(c.Request().Context(), owner, id)
+	if err != nil {
+		return webhookServiceError(err, "retrieving webhook endpoint")
+	}
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(endpoint))
+}
+
+// UpdateEndpoint godoc
+// @Summary      Update a webhook endpoint
+// @Description  Changes the URL, subscribed event types, description or active flag. Omitted fields are left unchanged. The secret cannot be changed.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id       path string                               true "Endpoint ID" format(uuid)
+// @Param        endpoint body request.UpdateWebhookEndpointRequest true "Fields to change"
+// @Success      200 {object} response.SuccessResponse{data=response.WebhookEndpointResponse} "Endpoint updated"
+// @Failure      400 {object} problem.Details "Invalid input"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      404 {object} problem.Details "Endpoint not found"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /webhooks/{id} [put]
+// @Security     ApiKeyAuth
+func (h *WebhookHandler) UpdateEndpoint(c echo.Context) error {
+	owner, err := webhookOwner(c)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return problem.BadRequest(problem.CodeInvalidID, err)
+	}
+	req := new(request.UpdateWebhookEndpointRequest)
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidBody, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(c.Request().Context(), owner, id, req)
+	if err != nil {
+		return webhookServiceError(err, "updating webhook endpoint")
+	}
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(endpoint))
+}
+
+// DeleteEndpoint godoc
+// @Summary      Delete a webhook endpoint
+// @Description  Removes the endpoint together with its delivery log.
+// @Tags         Webhooks
+// @Param        id path string true "Endpoint ID" format(uuid)
+// @Success      204 "Endpoint deleted"
+// @Failure      400 {object} problem.Details "Invalid ID"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      404 {object} problem.Details "Endpoint not found"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /webhooks/{id} [delete]
+// @Security     ApiKeyAuth
+func (h *WebhookHandler) DeleteEndpoint(c echo.Context) error {
+	owner, err := webhookOwner(c)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return problem.BadRequest(problem.CodeInvalidID, err)
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.Request().Context(), owner, id); err != nil {
+		return webhookServiceError(err, "deleting webhook endpoint")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Returns the delivery log of an endpoint, newest first, with the result of the last attempt. The body of the receiver's response (lastResponse) is only shown to admins.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id     path  string true  "Endpoint ID" format(uuid)
+// @Param        status query string false "Delivery status" Enums(pending, succeeded, dead)
+// @Param        limit  query int    false "Page size (default 50, max 500)"
+// @Param        offset query int    false "Number of deliveries to skip"
+// @Success      200 {object} response.SuccessResponse{data=response.WebhookDeliveryListResponse} "Deliveries"
+// @Failure      400 {object} problem.Details "Invalid input"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      404 {object} problem.Details "Endpoint not found"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /webhooks/{id}/deliveries [get]
+// @Security     ApiKeyAuth
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	owner, err := webhookOwner(c)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return problem.BadRequest(problem.CodeInvalidID, err)
+	}
+	req := new(request.ListWebhookDeliveriesRequest)
+	if err := c.Bind(req); err != nil {
+		return problem.BadRequest(problem.CodeInvalidQuery, err)
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	list, err := h.webhookService.ListDeliveries(c.Request().Context(), owner, id, req)
+	if err != nil {
+		return webhookServiceError(err, "listing webhook deliveries")
+	}
+	if !h.isAdmin(c) {
+		for i := range list.Items {
+			list.Items[i].LastResponse = ""
+		}
+	}
+	return c.JSON(http.StatusOK, response.NewSuccessResponse(list))
+}
+
+// Redeliver godoc
+// @Summary      Redeliver a webhook
+// @Description  Queues a delivery again with a fresh retry budget, typically after it went dead.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        deliveryId path string true "Delivery ID" format(uuid)
+// @Success      202 {object} response.SuccessResponse{data=response.WebhookDeliveryResponse} "Delivery queued"
+// @Failure      400 {object} problem.Details "Invalid ID"
+// @Failure      401 {object} problem.Details "Authentication required"
+// @Failure      404 {object} problem.Details "Delivery not found"
+// @Failure      500 {object} problem.Details "Internal server error"
+// @Router       /webhooks/deliveries/{deliveryId}/redeliver [post]
+// @Security     ApiKeyAuth
+func (h *WebhookHandler) Redeliver(c echo.Context) error {
+	owner, err := webhookOwner(c)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		return problem.BadRequest(problem.CodeInvalidID, err)
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request().Context(), owner, id)
+	if err != nil {
+		return webhookServiceError(err, "redelivering webhook")
+	}
+	if !h.isAdmin(c) {
+		delivery.LastResponse = ""
+	}
+	return c.JSON(http.StatusAccepted, response.NewSuccessResponse(delivery))
+}
+
+// webhookOwner returns the owner of the endpoints the caller manages: the caller themselves.
+// An application with organizations returns the caller's organization here.
+func webhookOwner(c echo.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		return "", problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
+	}
+	return domain.UserSubject(userID), nil
+}
+
+// isAdmin reports whether the caller is an admin. The bodies of the receivers' responses are
+// only shown to admins: an endpoint may point anywhere, and the status is enough to debug it.
+func (h *WebhookHandler) isAdmin(c echo.Context) bool {
+	userID, ok := middleware.GetUserIDFromContext(c)
+	if !ok {
+		return false
+	}
+	user, err := h.userService.GetByID(c.Request().Context(), userID)
+	return err == nil && user.Role == domain.RoleAdmin
+}
+
+// webhookServiceError names the missing resource of a not found error, and otherwise wraps err
+// with the failed action for problem.Handler to map.
+func webhookServiceError(err error, action string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return problem.WithCode(err, problem.CodeWebhookNotFound)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
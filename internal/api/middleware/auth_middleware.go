@@ -12,7 +12,9 @@ import (
 	"github.com/google/uuid" // Use UUID for consistency
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+	"youGo/internal/api/problem"
 	"youGo/internal/auth" // Import your auth service package
+	"youGo/internal/platform/requestctx"
 )
 
 // UserIDContextKey is the key used to store the authenticated user's ID in the Echo context.
@@ -30,38 +32,36 @@ func JWTAuth(authSvc auth.Service, log *zap.Logger) echo.MiddlewareFunc {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
 				log.Warn("AuthMiddleware: Missing authorization header")
-				// Return standard Echo HTTP error
-				return echo.NewHTTPError(http.StatusUnauthorized, "Missing or malformed authorization header")
+				// Return a problem the error handler describes in the caller's language
+				return problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
 			}
 
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			tokenString, ok := bearerToken(authHeader)
+			if !ok {
 				log.Warn("AuthMiddleware: Invalid Authorization header format", zap.String("header", authHeader))
-				return echo.NewHTTPError(http.StatusUnauthorized, "Missing or malformed authorization header")
-			}
-
-			tokenString := parts[1]
-			if tokenString == "" {
-				log.Warn("AuthMiddleware: Empty token in authorization header")
-				return echo.NewHTTPError(http.StatusUnauthorized, "Missing or malformed authorization header")
+				return problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
 			}
 
 			// Validate the token using the auth service
-			// Assumes ValidateToken returns userID (uuid.UUID) and error
-			userID, err := authSvc.ValidateToken(tokenString)
+			claims, err := authSvc.TokenClaims(tokenString)
 			if err != nil {
 				log.Warn("AuthMiddleware: Token validation failed", zap.Error(err))
 				// Check for specific token errors if needed (e.g., expired)
 				// For now, return a generic unauthorized error
 				// Consider mapping specific validation errors to different messages/codes
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired token") // Use error message if suitable: err.Error()
+				return problem.Error(http.StatusUnauthorized, problem.CodeInvalidToken)
 			}
 
 			// --- Token is valid ---
+			userID := claims.UserID
 			log.Debug("AuthMiddleware: Token validated successfully", zap.String("userID", userID.String()))
 
 			// Store the user ID (as uuid.UUID) in the Echo context
 			c.Set(string(UserIDContextKey), userID) // Use string(key) when setting
+			// Also expose it to the service layer (e.g. for audit events) via the request context,
+			// with the locale of the token for the messages of errors (see problem.ActorLocale)
+			ctx := requestctx.WithActor(c.Request().Context(), userID)
+			c.SetRequest(c.Request().WithContext(requestctx.WithLocale(ctx, claims.Locale)))
 
 			// Proceed to the next handler in the chain
 			return next(c)
@@ -69,6 +69,28 @@ func JWTAuth(authSvc auth.Service, log *zap.Logger) echo.MiddlewareFunc {
 	}
 }
 
+// BearerUser returns a function identifying the user of a request by its bearer token, for
+// middleware running before JWTAuth (e.g. RateLimit). Requests without a valid token have none.
+func BearerUser(authSvc auth.Service) func(r *http.Request) (uuid.UUID, bool) {
+	return func(r *http.Request) (uuid.UUID, bool) {
+		tokenString, ok := bearerToken(r.Header.Get("Authorization"))
+		if !ok {
+			return uuid.Nil, false
+		}
+		userID, err := authSvc.ValidateToken(tokenString)
+		return userID, err == nil
+	}
+}
+
+// bearerToken returns the token of an Authorization header in the "Bearer <token>" format.
+func bearerToken(authHeader string) (string, bool) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
 // GetUserIDFromContext is a helper function to retrieve the user ID from the Echo context.
 // Call this from your handlers that run *after* the JWTAuth middleware.
 func GetUserIDFromContext(c echo.Context) (uuid.UUID, bool) {
@@ -0,0 +1,31 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/client_ip.go
+package middleware
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ClientIP returns the echo.IPExtractor telling the client IP that c.RealIP returns, and so
+// the rate limits, logs and audit trail see. Without trusted proxies, it is the address of the
+// connection: X-Forwarded-For and X-Real-IP are set by clients as they please. Behind proxies,
+// given as CIDR ranges (checked by Config.Validate), it is the first address of
+// X-Forwarded-For, from the right, that isn't one of theirs.
+func ClientIP(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	// Only the ranges given are trusted, not echo's defaults (loopback, link-local, private)
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		if _, ipRange, err := net.ParseCIDR(cidr); err == nil {
+			options = append(options, echo.TrustIPRange(ipRange))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
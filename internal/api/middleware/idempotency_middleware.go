@@ -0,0 +1,182 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/idempotency_middleware.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/domain"
+	"youGo/internal/platform/requestctx"
+)
+
+// Headers of idempotent requests.
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed" // "true" on a replayed response
+)
+
+// MaxIdempotencyKeyLength is the length of the longest Idempotency-Key accepted.
+const MaxIdempotencyKeyLength = 255
+
+// Defaults of IdempotencyOptions.
+const (
+	DefaultIdempotencyTTL         = 24 * time.Hour
+	DefaultIdempotencyLockTimeout = time.Minute
+)
+
+// unreplayedHeaders are the response headers describing one response rather than the outcome
+// of the request.
+var unreplayedHeaders = []string{
+	echo.HeaderXRequestID, "Date", echo.HeaderContentLength, echo.HeaderSetCookie,
+	HeaderRateLimitLimit, HeaderRateLimitRemaining, HeaderRateLimitReset, HeaderRateLimitPolicy,
+}
+
+// IdempotencyOptions configure Idempotency. Zero values mean the defaults.
+type IdempotencyOptions struct {
+	TTL         time.Duration // How long a response is replayed
+	LockTimeout time.Duration // How long a request in flight holds its key, in case it never completes
+}
+
+// Idempotency creates an Echo middleware honouring the Idempotency-Key header, for routes
+// creating resources: the response to a request carrying a key is stored, and replayed with
+// the Idempotent-Replayed header to the retries of the request instead of handling them
+// again. Requests without the header are handled as usual.
+//
+// Keys are scoped to the authenticated user and the route. A key reused with another payload
+// is a 422; a retry arriving while the request is still in flight is a 409 with Retry-After.
+// Server errors aren't stored, so that the request can be retried.
+func Idempotency(store domain.IdempotencyStore, opts IdempotencyOptions, log *zap.Logger) echo.MiddlewareFunc {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultIdempotencyTTL
+	}
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = DefaultIdempotencyLockTimeout
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			clientKey := req.Header.Get(HeaderIdempotencyKey)
+			if clientKey == "" {
+				return next(c)
+			}
+			if len(clientKey) > MaxIdempotencyKeyLength {
+				return problem.Error(http.StatusBadRequest, problem.CodeIdempotencyKeyInvalid, "max", strconv.Itoa(MaxIdempotencyKeyLength))
+			}
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return problem.BadRequest(problem.CodeInvalidBody, err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			key := idempotencyKey(requestctx.FromContext(ctx).ActorID.String(), req.Method, req.URL.Path, clientKey)
+			fingerprint := idempotencyKey(req.Header.Get(echo.HeaderContentType), string(body))
+			record, err := store.Acquire(ctx, key, fingerprint, time.Now().Add(opts.LockTimeout))
+			if err != nil {
+				return fmt.Errorf("locking idempotency key: %w", err)
+			}
+			switch {
+			case record == nil:
+				return handleIdempotent(c, next, store, key, fingerprint, opts.TTL, log)
+			case record.Fingerprint != fingerprint:
+				return problem.Error(http.StatusUnprocessableEntity, problem.CodeIdempotencyKeyReused)
+			case !record.Completed():
+				retryAfter := max(1, int(time.Until(record.ExpiresAt).Seconds()))
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(min(retryAfter, 5)))
+				return problem.Error(http.StatusConflict, problem.CodeIdempotencyInProgress)
+			default:
+				return replay(c, record)
+			}
+		}
+	}
+}
+
+// handleIdempotent handles the request holding the lock of key, and stores its response.
+func handleIdempotent(c echo.Context, next echo.HandlerFunc, store domain.IdempotencyStore,
+	key, fingerprint string, ttl time.Duration, log *zap.Logger) error {
+	res := c.Response()
+	capture := &capturingWriter{ResponseWriter: res.Writer}
+	res.Writer = capture
+	err := next(c)
+	if err != nil {
+		c.Error(err) // Writes the problem now, so that it is stored too; the handler skips committed responses
+	}
+	res.Writer = capture.ResponseWriter
+
+	// The outcome is stored even if the client went away: that is when it retries
+	ctx := context.WithoutCancel(c.Request().Context())
+	if !res.Committed || res.Status >= http.StatusInternalServerError {
+		if releaseErr := store.Release(ctx, key); releaseErr != nil {
+			log.Error("Failed to release idempotency key", zap.Error(releaseErr))
+		}
+		return err
+	}
+	header := res.Header().Clone()
+	for _, name := range unreplayedHeaders {
+		header.Del(name)
+	}
+	record := &domain.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      res.Status,
+		Header:      header,
+		Body:        capture.body.Bytes(),
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+	if storeErr := store.Complete(ctx, record); storeErr != nil {
+		log.Warn("Failed to store idempotent response", zap.Int("status", res.Status), zap.Error(storeErr))
+	}
+	return err
+}
+
+// replay writes the stored response of record.
+func replay(c echo.Context, record *domain.IdempotencyRecord) error {
+	header := c.Response().Header()
+	for name, values := range record.Header {
+		header[name] = values
+	}
+	header.Set(HeaderIdempotentReplayed, "true")
+	c.Response().WriteHeader(record.Status)
+	_, err := c.Response().Write(record.Body)
+	return err
+}
+
+// idempotencyKey hashes parts, so that keys and fingerprints have a fixed length.
+func idempotencyKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		_, _ = fmt.Fprintf(h, "%d:%s", len(part), part) // Length-prefixed: parts can't run into each other
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// capturingWriter copies the body of a response as it is written.
+type capturingWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *capturingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
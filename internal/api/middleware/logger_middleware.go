@@ -6,16 +6,18 @@
 package middleware
 
 import (
-	"errors"
-	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/platform/tracing"
 )
 
 // RequestLogger creates an Echo middleware function that logs details about each request using Zap.
-// It logs method, path, status, latency, IP, user agent, response size, and request ID.
+// It logs method, path, status, latency, IP, user agent, response size, and request ID, plus the
+// trace and span IDs when the request is traced.
 func RequestLogger(log *zap.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -35,37 +37,27 @@ func RequestLogger(log *zap.Logger) echo.MiddlewareFunc {
 				requestID = req.Header.Get(echo.HeaderXRequestID) // Fallback if not in response yet
 			}
 
+			// Errors are written by the error handler, after this returns
+			statusCode := responseStatus(res, err)
+
 			// Prepare base log fields
 			fields := []zap.Field{
 				zap.String("method", req.Method),
 				zap.String("path", req.URL.Path),
-				zap.Int("status", res.Status), // Get status after handler execution
+				zap.Int("status", statusCode),
 				zap.Duration("latency", latency),
 				zap.String("ip", c.RealIP()),
 				zap.String("user_agent", req.UserAgent()),
 				zap.Int64("response_size", res.Size),
 				zap.String("request_id", requestID),
 			}
+			// Trace and span IDs of the request's span (see Tracing)
+			fields = append(fields, tracing.LogFields(req.Context())...)
 
 			// Handle potential errors returned by handlers/downstream middleware
-			statusCode := res.Status
 			if err != nil {
 				// Include the error in the log fields
 				fields = append(fields, zap.Error(err))
-
-				// Try to get status code from echo.HTTPError if available
-				var httpError *echo.HTTPError
-				if errors.As(err, &httpError) {
-					statusCode = httpError.Code
-					// Update status field if it differs from response status somehow
-					if res.Status != statusCode {
-						fields[2] = zap.Int("status", statusCode) // fields[2] is status field index
-					}
-				} else if statusCode < 400 {
-					// If it's a non-HTTP error and status wasn't set to error level, default to 500
-					statusCode = http.StatusInternalServerError
-					fields[2] = zap.Int("status", statusCode) // Update status field index
-				}
 			}
 
 			// Choose log level based on final status code
@@ -83,3 +75,13 @@ func RequestLogger(log *zap.Logger) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// responseStatus returns the status of the response to a request whose handler returned err.
+// The error handler only writes errors once the middleware chain returned, with the status
+// of the problem the error maps to, e.g. 404 for domain.ErrNotFound and 500 for unknown errors.
+func responseStatus(res *echo.Response, err error) int {
+	if err == nil || res.Committed {
+		return res.Status
+	}
+	return problem.FromError(err).Status
+}
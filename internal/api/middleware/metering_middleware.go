@@ -0,0 +1,77 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/metering_middleware.go
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/service"
+)
+
+// Metering creates an Echo middleware metering API calls as billable usage: a request under
+// one of the route classes counts as an "api_calls:<class>" event (the class of the longest
+// matching prefix) for each subject the request is billed to, as told by subjects, e.g. its
+// user and organization. Anonymous requests, and requests outside every class, aren't metered.
+//
+// A request exceeding the quota of a subject's plan is rejected without being counted: as a
+// 429 with Retry-After set to the end of the period, or a 402 when only a bigger plan lifts
+// the quota (see domain.QuotaExceededError.PaymentRequired). The check and the count are one
+// step (see service.MeteringService.Consume), so that concurrent requests can't all slip
+// under the quota. The metering failing lets requests through: it is logged, but an outage of
+// the counters shouldn't become an outage of the API.
+func Metering(svc service.MeteringService, classes []config.RouteClass, subjects func(r *http.Request) []string, log *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			class, ok := routeClass(classes, req.URL.Path)
+			if !ok {
+				return next(c)
+			}
+			billed := subjects(req)
+			if len(billed) == 0 {
+				return next(c)
+			}
+
+			meter := domain.APICallsMeter(class)
+			err := svc.Consume(req.Context(), meter, 1, billed...)
+			var quota *domain.QuotaExceededError
+			switch {
+			case errors.As(err, &quota):
+				if !quota.PaymentRequired() {
+					// Waiting doesn't help otherwise
+					retryAfter := seconds(time.Until(quota.Reset))
+					c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(1, retryAfter)))
+				}
+				log.Info("Quota exceeded", zap.String("subject", quota.Subject), zap.String("meter", meter), zap.String("plan", quota.Plan))
+				return err
+			case err != nil:
+				log.Warn("Quota check failed; request let through", zap.Error(err))
+			}
+			return next(c)
+		}
+	}
+}
+
+// routeClass returns the name of the class with the longest prefix path falls under.
+func routeClass(classes []config.RouteClass, path string) (string, bool) {
+	name, longest := "", -1
+	for _, class := range classes {
+		prefix := strings.TrimSuffix(class.Prefix, "/")
+		if (path == prefix || strings.HasPrefix(path, prefix+"/")) && len(prefix) > longest {
+			name, longest = class.Name, len(prefix)
+		}
+	}
+	return name, longest >= 0
+}
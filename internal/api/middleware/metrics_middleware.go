@@ -0,0 +1,42 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/metrics_middleware.go
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/platform/metrics"
+)
+
+// unmatchedRoute labels the requests no route matched, so that scanners probing random paths
+// don't create a series per path.
+const unmatchedRoute = "unmatched"
+
+// Metrics creates an Echo middleware counting requests and observing their latency by route
+// template (e.g. "/api/v1/users/:id"), method and status, and tracking those in flight.
+func Metrics(m *metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			m.HTTPInFlight.Inc()
+			defer m.HTTPInFlight.Dec()
+			start := time.Now()
+
+			err := next(c)
+
+			route := c.Path()
+			if route == "" || route == echo.RouteNotFound {
+				route = unmatchedRoute
+			}
+			status := strconv.Itoa(responseStatus(c.Response(), err))
+			m.HTTPRequests.WithLabelValues(route, c.Request().Method, status).Inc()
+			m.HTTPDuration.WithLabelValues(route, c.Request().Method, status).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
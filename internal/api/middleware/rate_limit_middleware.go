@@ -0,0 +1,150 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/rate_limit_middleware.go
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/config"
+	"youGo/internal/platform/ratelimit"
+)
+
+// Headers of rate limited responses, as drafted by the IETF httpapi working group.
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"  // Seconds until the window ends
+	HeaderRateLimitPolicy    = "RateLimit-Policy" // e.g. "10;w=60"
+	HeaderAPIKey             = "X-API-Key"
+)
+
+// RateLimitRule applies a policy to the routes under a path prefix.
+type RateLimitRule struct {
+	Prefix string
+	By     string // config.RateLimitByIP, config.RateLimitByUser or config.RateLimitByAPIKey
+	Policy ratelimit.Policy
+}
+
+// matches reports whether path is prefix or below it.
+func (r RateLimitRule) matches(path string) bool {
+	prefix := strings.TrimSuffix(r.Prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// RateLimitRules converts the policies of the configuration, checked by Config.Validate.
+func RateLimitRules(cfg config.RateLimitConfig) []RateLimitRule {
+	rules := make([]RateLimitRule, 0, len(cfg.Policies))
+	for _, p := range cfg.Policies {
+		window, _ := time.ParseDuration(p.Window)
+		rules = append(rules, RateLimitRule{
+			Prefix: p.Prefix,
+			By:     p.KeyName(),
+			Policy: ratelimit.Policy{Name: p.Name, Requests: p.Requests, Window: window},
+		})
+	}
+	return rules
+}
+
+// RateLimit creates an Echo middleware counting each request against the rules whose prefix
+// it falls under, per client: its IP, its user (identified by users, e.g. BearerUser) or its
+// API key (verified by apiKeys), falling back to the IP for anonymous requests. apiKeys may be
+// nil when the application issues no API keys: rules by API key then limit by IP, since a
+// key nothing verifies could be changed on every request. The RateLimit-* headers describe
+// the most constraining rule; a request over any limit is a 429 with Retry-After.
+//
+// The limiter failing (e.g. Redis being unreachable) lets requests through: it is logged, but
+// an outage of the counters shouldn't become an outage of the API.
+func RateLimit(limiter *ratelimit.Limiter, rules []RateLimitRule, users func(r *http.Request) (uuid.UUID, bool),
+	apiKeys func(r *http.Request) (string, bool), log *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			var (
+				binding  *ratelimit.Result // Denied, or else the fewest requests remaining
+				policies []string
+			)
+			for _, rule := range rules {
+				if !rule.matches(req.URL.Path) {
+					continue
+				}
+				result, err := limiter.Allow(req.Context(), rule.Policy, rateLimitKey(c, rule.By, users, apiKeys))
+				if err != nil {
+					log.Warn("Rate limiter unavailable; request let through",
+						zap.String("policy", rule.Policy.Name), zap.Error(err))
+					continue
+				}
+				policies = append(policies, rule.Policy.String())
+				if binding == nil || constrains(result, *binding) {
+					binding = &result
+				}
+			}
+			if binding == nil {
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(binding.Policy.Requests))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(binding.Remaining))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(seconds(binding.Reset)))
+			header.Set(HeaderRateLimitPolicy, strings.Join(policies, ", "))
+			if !binding.Allowed {
+				retryAfter := seconds(binding.RetryAfter)
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+				log.Info("Rate limit exceeded", zap.String("policy", binding.Policy.Name),
+					zap.String("ip", c.RealIP()), zap.String("path", req.URL.Path))
+				return problem.Error(http.StatusTooManyRequests, problem.CodeRateLimited, "retry_after", strconv.Itoa(retryAfter))
+			}
+			return next(c)
+		}
+	}
+}
+
+// constrains reports whether a is more constraining than b: denied, or with fewer requests left.
+func constrains(a, b ratelimit.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
+
+// rateLimitKey identifies the client of a request as rules keyed by by see it.
+func rateLimitKey(c echo.Context, by string, users func(r *http.Request) (uuid.UUID, bool), apiKeys func(r *http.Request) (string, bool)) string {
+	switch by {
+	case config.RateLimitByUser:
+		if users != nil {
+			if userID, ok := users(c.Request()); ok {
+				return "user:" + userID.String()
+			}
+		}
+	case config.RateLimitByAPIKey:
+		if apiKeys != nil {
+			if apiKey, ok := apiKeys(c.Request()); ok {
+				sum := sha256.Sum256([]byte(apiKey)) // Keys are secrets: keep them out of the store
+				return "key:" + hex.EncodeToString(sum[:16])
+			}
+		}
+	}
+	return "ip:" + c.RealIP()
+}
+
+// seconds rounds d up to whole seconds, as headers carry them.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,24 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/read_your_writes_middleware.go
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/platform/database"
+)
+
+// ReadYourWrites makes each request a read-your-writes scope: once the request wrote to the
+// database, its later reads go to the primary instead of a possibly lagging read replica.
+func ReadYourWrites() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(database.WithReadYourWrites(req.Context())))
+			return next(c)
+		}
+	}
+}
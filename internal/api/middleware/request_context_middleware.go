@@ -0,0 +1,66 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/request_context_middleware.go
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+
+	"youGo/internal/platform/requestctx"
+)
+
+// RequestContext copies the request ID, trace ID, client IP and user agent into the request's
+// context.Context so services and repositories can read them via requestctx.FromContext.
+// It must run after echo's RequestID middleware, and after Tracing: the trace ID is that of the
+// request's span, or else the one of its traceparent header.
+func RequestContext() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+			if requestID == "" {
+				requestID = req.Header.Get(echo.HeaderXRequestID)
+			}
+
+			ctx := requestctx.WithMetadata(req.Context(), requestctx.Metadata{
+				RequestID: requestID,
+				TraceID:   requestTraceID(req),
+				IP:        c.RealIP(),
+				UserAgent: req.UserAgent(),
+			})
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// requestTraceID returns the trace ID of the span of req, or without tracing, the one of its
+// traceparent header.
+func requestTraceID(req *http.Request) string {
+	if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() {
+		return sc.TraceID().String()
+	}
+	return traceID(req.Header.Get("traceparent"))
+}
+
+// traceID returns the trace ID of a W3C traceparent header ("00-<trace-id>-<parent-id>-<flags>"),
+// or "" if the header is missing or malformed.
+func traceID(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+	for _, r := range parts[1] {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return ""
+		}
+	}
+	return parts[1]
+}
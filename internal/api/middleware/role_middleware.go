@@ -0,0 +1,46 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package middleware /youGo/internal/api/middleware/role_middleware.go
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/service"
+)
+
+// RequireRole creates an Echo middleware that only lets through users holding one of the given roles.
+// It must run after JWTAuth, which stores the authenticated user ID in the context.
+func RequireRole(userSvc service.UserService, log *zap.Logger, roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := GetUserIDFromContext(c)
+			if !ok {
+				log.Warn("RoleMiddleware: No authenticated user in context")
+				return problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
+			}
+
+			// Look up the current role on every request so that role changes apply immediately
+			user, err := userSvc.GetByID(c.Request().Context(), userID)
+			if err != nil {
+				log.Warn("RoleMiddleware: Failed to load authenticated user", zap.String("userID", userID.String()), zap.Error(err))
+				return problem.Error(http.StatusUnauthorized, problem.CodeAuthRequired)
+			}
+
+			for _, role := range roles {
+				if user.Role == role {
+					return next(c)
+				}
+			}
+
+			log.Warn("RoleMiddleware: Insufficient role", zap.String("userID", userID.String()), zap.String("role", user.Role))
+			return problem.Error(http.StatusForbidden, problem.CodeInsufficientRole)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package problem /youGo/internal/api/problem/codes.go
+package problem
+
+import (
+	"net/http"
+
+	"youGo/internal/domain"
+)
+
+// Codes of problems: stable identifiers clients may rely on, and the keys of the messages
+// describing them in the i18n catalogue.
+const (
+	// Generic, by status or domain error
+	CodeBadRequest           = "bad_request"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeConflict             = "conflict"
+	CodeRequestTooLarge      = "request_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeUnprocessable        = "unprocessable"
+	CodeTooManyRequests      = "too_many_requests"
+	CodeInternal             = "internal_error"
+	CodeServiceUnavailable   = "service_unavailable"
+	CodeHTTPError            = "http_error" // Other statuses; the message gets the {status} parameter
+	CodeDuplicateEntry       = "duplicate_entry"
+	CodeInvalidReference     = "invalid_reference"
+	CodeConstraintViolation  = "constraint_violation"
+	CodePermissionDenied     = "permission_denied"
+	CodeEditConflict         = "edit_conflict"
+	CodeInvalidArgument      = "invalid_argument" // {argument} and its translated {reason}
+	CodeValidationFailed     = "validation_failed"
+
+	// Requests
+	CodeInvalidBody  = "request.invalid_body"
+	CodeInvalidQuery = "request.invalid_query"
+	CodeInvalidID    = "request.invalid_id"
+
+	// Authentication
+	CodeAuthRequired       = "auth.required"
+	CodeInvalidToken       = "auth.invalid_token"
+	CodeInvalidCredentials = "auth.invalid_credentials"
+	CodeInsufficientRole   = "auth.insufficient_role"
+
+	// Users
+	CodeUserNotFound           = "user.not_found"
+	CodeUserEmailTaken         = "user.email_taken"
+	CodeUserPatchNotOwner      = "user.patch_not_owner"
+	CodeUserPatchPathForbidden = "user.patch_path_forbidden" // {path}
+
+	// JSON and Merge Patch
+	CodePatchUnsupportedMediaType = "patch.unsupported_media_type" // {types}
+	CodePatchInvalid              = "patch.invalid"
+	CodePatchTestFailed           = "patch.test_failed"
+	CodePatchInvalidResult        = "patch.invalid_result"
+
+	// Idempotency-Key
+	CodeIdempotencyKeyInvalid = "idempotency.invalid_key" // {max}
+	CodeIdempotencyKeyReused  = "idempotency.key_reused"
+	CodeIdempotencyInProgress = "idempotency.in_progress"
+
+	// Rate limits
+	CodeRateLimited = "rate_limit.exceeded" // {retry_after}
+
+	// Quotas of plans
+	CodeQuotaExceeded        = "quota.exceeded"         // {meter} {limit} {plan} {reset}
+	CodeQuotaUpgradeRequired = "quota.upgrade_required" // {meter} {limit} {plan}
+
+	// Webhooks and diagnostics
+	CodeWebhookNotFound     = "webhook.not_found"
+	CodeDatabaseUnavailable = "database.unavailable"
+)
+
+// codesByStatus are the codes of HTTP errors without a more specific one.
+var codesByStatus = map[int]string{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodeRequestTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusUnprocessableEntity:   CodeUnprocessable,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeServiceUnavailable,
+}
+
+// Codes returns every message code problems may carry, including the reasons of
+// domain.InvalidArgumentError, for checking that each locale translates them.
+func Codes() []string {
+	codes := []string{
+		CodeHTTPError, CodeDuplicateEntry, CodeInvalidReference, CodeConstraintViolation, CodePermissionDenied,
+		CodeEditConflict, CodeInvalidArgument, CodeValidationFailed,
+		CodeInvalidBody, CodeInvalidQuery, CodeInvalidID,
+		CodeAuthRequired, CodeInvalidToken, CodeInvalidCredentials, CodeInsufficientRole,
+		CodeUserNotFound, CodeUserEmailTaken, CodeUserPatchNotOwner, CodeUserPatchPathForbidden,
+		CodePatchUnsupportedMediaType, CodePatchInvalid, CodePatchTestFailed, CodePatchInvalidResult,
+		CodeIdempotencyKeyInvalid, CodeIdempotencyKeyReused, CodeIdempotencyInProgress,
+		CodeRateLimited, CodeQuotaExceeded, CodeQuotaUpgradeRequired,
+		CodeWebhookNotFound, CodeDatabaseUnavailable,
+		domain.ReasonUUID, domain.ReasonTimestamp, domain.ReasonExportFormat, domain.ReasonSearchTerms,
+		domain.ReasonUsageSubject,
+	}
+	for _, code := range codesByStatus {
+		codes = append(codes, code)
+	}
+	return codes
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package problem /youGo/internal/api/problem/handler.go
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"youGo/internal/domain"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/requestctx"
+	"youGo/internal/platform/validator"
+)
+
+// LocalePreference returns the locale chosen by the caller of a request, or "" to negotiate
+// it from the Accept-Language header.
+type LocalePreference func(ctx context.Context) string
+
+// ActorLocale is the LocalePreference of the authenticated user, as of its access token (see
+// requestctx.WithLocale): a new choice applies from the next login. Errors are answered
+// without loading the user.
+func ActorLocale(ctx context.Context) string {
+	return requestctx.FromContext(ctx).Locale
+}
+
+// Handler returns the echo.HTTPErrorHandler writing the errors of handlers and middleware as
+// problem details (see FromError). Server errors are logged with the request ID; their
+// cause is never sent to the client. Messages are translated with messages into the locale
+// of preference, if any and available, or else the one negotiated from Accept-Language;
+// preference may be nil. The preference is only looked up for failed requests.
+func Handler(logger *zap.Logger, messages *i18n.Catalogue, preference LocalePreference) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+		req := c.Request()
+		p := FromError(err)
+		locale := negotiate(req, messages, preference)
+		localize(p, messages, locale)
+		c.Response().Header().Set("Content-Language", locale)
+		p.Instance = req.URL.Path
+		p.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+		if p.RequestID == "" {
+			p.RequestID = req.Header.Get(echo.HeaderXRequestID)
+		}
+		if p.Status >= http.StatusInternalServerError {
+			logger.Error("Request failed",
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.String("request_id", p.RequestID),
+				zap.Error(err),
+			)
+		}
+
+		if req.Method == http.MethodHead {
+			err = c.NoContent(p.Status)
+		} else {
+			err = write(c, p)
+		}
+		if err != nil {
+			logger.Error("Failed to write problem response", zap.Error(err))
+		}
+	}
+}
+
+func write(c echo.Context, p *Details) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return c.Blob(p.Status, ContentType, body)
+}
+
+// negotiate returns the locale of the messages answering req.
+func negotiate(req *http.Request, messages *i18n.Catalogue, preference LocalePreference) string {
+	if preference != nil {
+		if locale, ok := messages.Match(preference(req.Context())); ok {
+			return locale
+		}
+	}
+	return messages.Negotiate(req.Header.Get("Accept-Language"))
+}
+
+// localize sets the detail of p to the message of its code in locale, and translates the
+// reason of an invalid argument and the messages of the violations.
+func localize(p *Details, messages *i18n.Catalogue, locale string) {
+	if p.reason != "" {
+		if reason, ok := messages.Message(locale, p.reason, p.Params); ok {
+			p.Params["reason"] = reason
+			p.Errors[p.Params["argument"]] = []string{reason}
+		}
+	}
+	translated := make(map[string][]string)
+	for i, v := range p.Violations {
+		v.Message = validator.Message(messages, locale, domain.FieldViolation{Path: v.Field, Code: v.Code, Params: v.Params, Message: v.Message})
+		p.Violations[i] = v
+		translated[v.Field] = append(translated[v.Field], v.Message)
+	}
+	if len(translated) > 0 && p.Errors == nil {
+		p.Errors = make(map[string][]string, len(translated))
+	}
+	for field, fieldMessages := range translated {
+		p.Errors[field] = fieldMessages
+	}
+
+	params := p.Params
+	if p.Code == CodeValidationFailed { // "1 invalid field", "3 invalid fields"
+		params = map[string]string{i18n.CountParam: strconv.Itoa(len(p.Errors))}
+	}
+	if detail, ok := messages.Message(locale, p.Code, params); ok {
+		p.Detail = detail
+	} else if p.Detail == "" {
+		p.Detail = p.Title
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package problem /youGo/internal/api/problem/problem.go
+// Package problem writes every API error as an RFC 7807 problem details document
+// (application/problem+json). Handlers return errors, domain errors included, and Handler,
+// installed as echo's HTTPErrorHandler, maps them to a status, a problem type and a code,
+// whose message is translated into the caller's language.
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/domain"
+)
+
+// ContentType is the media type of problem details documents.
+const ContentType = "application/problem+json"
+
+// Problem types, relative to the API's base URL. HTTP errors without a more specific
+// type, such as a malformed request or a missing route, are "about:blank".
+const (
+	TypeBlank               = "about:blank"
+	TypeNotFound            = "/problems/not-found"
+	TypeDuplicateEntry      = "/problems/duplicate-entry"
+	TypeInvalidReference    = "/problems/invalid-reference"
+	TypeConstraintViolation = "/problems/constraint-violation"
+	TypePermissionDenied    = "/problems/permission-denied"
+	TypeEditConflict        = "/problems/edit-conflict"
+	TypeInvalidArgument     = "/problems/invalid-argument"
+	TypeValidation          = "/problems/validation-failed"
+	TypeQuotaExceeded       = "/problems/quota-exceeded"
+)
+
+// Details is a problem details document. Handlers may return one as an error to choose
+// the problem themselves; Detail is then the message of Code.
+type Details struct {
+	Type       string              `json:"type"`
+	Title      string              `json:"title"`
+	Status     int                 `json:"status"`
+	Code       string              `json:"code"`                 // Stable code of the problem, e.g. "user.not_found"
+	Detail     string              `json:"detail,omitempty"`     // Message of Code in the caller's language
+	Params     map[string]string   `json:"params,omitempty"`     // Values in the message, e.g. {"column": "email"}
+	Instance   string              `json:"instance,omitempty"`   // Path of the request
+	RequestID  string              `json:"requestId,omitempty"`  // X-Request-ID of the request
+	Errors     map[string][]string `json:"errors,omitempty"`     // Invalid fields and why, for validation problems
+	Violations []Violation         `json:"violations,omitempty"` // Rules the fields failed, for validation problems
+
+	reason string // Code of the reason of an invalid argument
+}
+
+// Violation is a validation rule a field of the request failed.
+type Violation struct {
+	Field   string            `json:"field"` // JSON path, e.g. "items[0].name"
+	Code    string            `json:"code"`  // Rule, e.g. "required" or "min"
+	Message string            `json:"message"`
+	Params  map[string]string `json:"params,omitempty"` // Arguments of the rule, e.g. {"limit": "8"}
+}
+
+// New creates a problem of the given type and code; the title is the status text.
+func New(status int, problemType, code string) *Details {
+	return &Details{Type: problemType, Title: http.StatusText(status), Status: status, Code: code}
+}
+
+// Error implements the error interface, so that handlers can return a Details.
+func (d *Details) Error() string {
+	return fmt.Sprintf("%d %s: %s", d.Status, d.Title, d.Code)
+}
+
+// Error returns an HTTP error of the given status, described by the message of code; params
+// fill the message's placeholders, given as name/value pairs.
+func Error(status int, code string, params ...string) error {
+	return WithCode(echo.NewHTTPError(status), code, params...)
+}
+
+// BadRequest is the error of a request that can't be decoded, e.g. when binding fails.
+// cause is kept for errors.Is and errors.As, but not disclosed.
+func BadRequest(code string, cause error) error {
+	return WithCode(echo.NewHTTPError(http.StatusBadRequest).SetInternal(cause), code)
+}
+
+// codedError replaces the generic code of the problem err maps to.
+type codedError struct {
+	err    error
+	code   string
+	params map[string]string
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+func (e *codedError) Unwrap() error { return e.err }
+
+// WithCode returns err with the code of the message describing it to the client, e.g.
+// CodeUserNotFound for domain.ErrNotFound; params fill the message's placeholders, given as
+// name/value pairs. The status and type are still those of err, and errors mapped to an
+// internal server error keep its generic code.
+func WithCode(err error, code string, params ...string) error {
+	if err == nil {
+		return nil
+	}
+	coded := &codedError{err: err, code: code}
+	if len(params) > 0 {
+		coded.params = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			coded.params[params[i]] = params[i+1]
+		}
+	}
+	return coded
+}
+
+// FromError maps err to a problem, without its messages (see Handler). Errors nothing is
+// known of are internal server errors, whose details are not disclosed.
+func FromError(err error) *Details {
+	p := fromError(err)
+	var coded *codedError
+	if errors.As(err, &coded) && p.Code != CodeInternal {
+		p.Code = coded.code
+		if coded.params != nil {
+			// Added to the params of the problem, e.g. the argument of an invalid argument
+			if p.Params == nil {
+				p.Params = make(map[string]string, len(coded.params))
+			}
+			maps.Copy(p.Params, coded.params)
+		}
+	}
+	return p
+}
+
+func fromError(err error) *Details {
+	var (
+		p          *Details
+		argErr     *domain.InvalidArgumentError
+		validation *domain.ValidationError
+		quota      *domain.QuotaExceededError
+		constraint *domain.ConstraintError
+		httpErr    *echo.HTTPError
+		params     map[string]string
+	)
+	if errors.As(err, &constraint) && constraint.Column != "" {
+		params = map[string]string{"column": constraint.Column}
+	}
+	switch {
+	case errors.As(err, &p):
+		clone := p.clone()
+		if clone.Type == "" {
+			clone.Type = TypeBlank
+		}
+		if clone.Title == "" {
+			clone.Title = http.StatusText(clone.Status)
+		}
+		if clone.Code == "" {
+			clone.Code = statusCode(clone.Status)
+		}
+		return clone
+	case errors.As(err, &validation):
+		p = New(http.StatusUnprocessableEntity, TypeValidation, CodeValidationFailed)
+		p.Errors = maps.Clone(validation.Failures)
+		for _, v := range validation.Violations {
+			p.Violations = append(p.Violations, Violation{Field: v.Path, Code: v.Code, Message: v.Message, Params: maps.Clone(v.Params)})
+		}
+		return p
+	case errors.As(err, &quota):
+		// Usage counted per period comes back with the next one; levels need a bigger plan
+		p = New(http.StatusTooManyRequests, TypeQuotaExceeded, CodeQuotaExceeded)
+		p.Params = map[string]string{"meter": quota.Meter, "limit": strconv.FormatInt(quota.Limit, 10), "plan": quota.Plan}
+		if quota.PaymentRequired() {
+			p.Status, p.Title, p.Code = http.StatusPaymentRequired, http.StatusText(http.StatusPaymentRequired), CodeQuotaUpgradeRequired
+		} else {
+			p.Params["reset"] = quota.Reset.UTC().Format(time.RFC3339)
+		}
+		return p
+	case errors.As(err, &argErr):
+		p = New(http.StatusBadRequest, TypeInvalidArgument, CodeInvalidArgument)
+		p.Params = map[string]string{"argument": argErr.ArgumentName, "reason": argErr.Reason}
+		p.Errors = map[string][]string{argErr.ArgumentName: {argErr.Reason}}
+		p.reason = argErr.Code
+		return p
+	case errors.Is(err, domain.ErrNotFound):
+		return New(http.StatusNotFound, TypeNotFound, CodeNotFound)
+	case errors.Is(err, domain.ErrDuplicateEntry):
+		p = New(http.StatusConflict, TypeDuplicateEntry, CodeDuplicateEntry)
+	case errors.Is(err, domain.ErrInvalidReference):
+		p = New(http.StatusConflict, TypeInvalidReference, CodeInvalidReference)
+	case errors.Is(err, domain.ErrConstraintViolation):
+		p = New(http.StatusUnprocessableEntity, TypeConstraintViolation, CodeConstraintViolation)
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return New(http.StatusForbidden, TypePermissionDenied, CodePermissionDenied)
+	case errors.Is(err, domain.ErrOptimisticLock):
+		return New(http.StatusConflict, TypeEditConflict, CodeEditConflict)
+	case errors.As(err, &httpErr):
+		p = New(httpErr.Code, TypeBlank, statusCode(httpErr.Code))
+		if p.Code == CodeHTTPError {
+			p.Params = map[string]string{"status": strconv.Itoa(p.Status)}
+		}
+		return p
+	default:
+		return New(http.StatusInternalServerError, TypeBlank, CodeInternal)
+	}
+	p.Params = params // Constraint violations
+	return p
+}
+
+// clone returns a deep copy of d, which localizing the problem may then change without
+// changing the one returned by the handler.
+func (d *Details) clone() *Details {
+	clone := *d
+	clone.Params = maps.Clone(d.Params)
+	if d.Errors != nil {
+		clone.Errors = make(map[string][]string, len(d.Errors))
+		for field, reasons := range d.Errors {
+			clone.Errors[field] = slices.Clone(reasons)
+		}
+	}
+	if d.Violations != nil {
+		clone.Violations = slices.Clone(d.Violations)
+		for i := range clone.Violations {
+			clone.Violations[i].Params = maps.Clone(d.Violations[i].Params)
+		}
+	}
+	return &clone
+}
+
+// statusCode returns the code of an HTTP error of the given status.
+func statusCode(status int) string {
+	if code, ok := codesByStatus[status]; ok {
+		return code
+	}
+	return CodeHTTPError
+}
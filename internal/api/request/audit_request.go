@@ -0,0 +1,30 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package request /youGo/internal/api/request/audit_request.go
+package request
+
+// AuditEventFilterRequest holds the query parameters used to filter audit events.
+// Timestamps are RFC 3339; "from" is inclusive and "to" is exclusive.
+type AuditEventFilterRequest struct {
+	ActorID    string `query:"actor_id" validate:"omitempty,uuid"`
+	Action     string `query:"action" validate:"omitempty,max=100"`
+	TargetType string `query:"target_type" validate:"omitempty,max=50"`
+	TargetID   string `query:"target_id" validate:"omitempty,max=255"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// ListAuditEventsRequest defines the query parameters for listing audit events page by page.
+type ListAuditEventsRequest struct {
+	AuditEventFilterRequest
+	Limit  int `query:"limit" validate:"omitempty,min=1,max=500"`
+	Offset int `query:"offset" validate:"omitempty,min=0"`
+}
+
+// ExportAuditEventsRequest defines the query parameters for exporting every matching audit event.
+type ExportAuditEventsRequest struct {
+	AuditEventFilterRequest
+	Format string `query:"format" validate:"omitempty,oneof=csv ndjson"` // Defaults to csv
+}
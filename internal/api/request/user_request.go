@@ -5,6 +5,8 @@
 // Package request /youGo/internal/api/request/user_request.go
 package request
 
+import "youGo/internal/domain"
+
 // UpdateUserProfileRequest defines the structure for updating user profile data.
 // Typically, sensitive fields like email or role are not updated here.
 // Use 'omitempty' if fields are optional
@@ -29,6 +31,36 @@ type UpdateUserRequest struct {
 	Name     *string `json:"name,omitempty"`
 	IsActive *bool   `json:"isActive,omitempty"`
 	Role     *string `json:"role,omitempty"`
+	Locale   *string `json:"locale,omitempty" validate:"omitempty,locale"` // "" follows Accept-Language again
+}
+
+// UserPatchDocument is the JSON document that PATCH /users/:id patches.
+// Merge Patch (RFC 7396) and JSON Patch (RFC 6902) bodies are applied to it, and the
+// result is validated with the same rules as any other request before being saved.
+// IsActive is a pointer so that removing it (e.g. {"isActive": null}) fails validation
+// instead of silently becoming false.
+type UserPatchDocument struct {
+	Name     string `json:"name" validate:"required,min=2"`
+	IsActive *bool  `json:"isActive" validate:"required"`
+	Role     string `json:"role" validate:"required,oneof=admin user"`
+	Locale   string `json:"locale" validate:"omitempty,locale"`
+}
+
+// PatchableUserPaths whitelists the JSON Pointers each role may patch.
+// Roles that are missing from the map may not patch anything.
+var PatchableUserPaths = map[string][]string{
+	domain.RoleAdmin: {"/name", "/isActive", "/role", "/locale"},
+	domain.RoleUser:  {"/name", "/locale"},
+}
+
+// ToUpdateUserRequest converts the patched document into an UpdateUserRequest.
+func (d *UserPatchDocument) ToUpdateUserRequest() *UpdateUserRequest {
+	return &UpdateUserRequest{
+		Name:     &d.Name,
+		IsActive: d.IsActive,
+		Role:     &d.Role,
+		Locale:   &d.Locale,
+	}
 }
 
 // ChangePasswordRequest defines the structure for a user changing their own password.
@@ -38,6 +70,13 @@ type ChangePasswordRequest struct {
 	NewPasswordConfirm string `json:"new_password_confirm" validate:"required,eqfield=NewPassword"`
 }
 
+// SearchUsersRequest defines the query parameters of a user search.
+type SearchUsersRequest struct {
+	Query  string `query:"q" validate:"required,max=200"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int    `query:"offset" validate:"omitempty,min=0"`
+}
+
 // CreateUserRequest defines the structure for an admin creating a user (example).
 // Might be different from SignupRequest (e.g., password might be auto-generated or set differently).
 // type CreateUserRequest struct {
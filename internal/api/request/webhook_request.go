@@ -0,0 +1,29 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package request /youGo/internal/api/request/webhook_request.go
+package request
+
+// CreateWebhookEndpointRequest registers a URL for a set of event types ("*" for all).
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,http_url"`
+	Secret      string   `json:"secret" validate:"omitempty,min=16,max=255"` // Generated when omitted
+	EventTypes  []string `json:"eventTypes" validate:"required,min=1,dive,oneof=* UserCreated UserUpdated UserDeleted UserLoggedIn"`
+	Description string   `json:"description" validate:"omitempty,max=255"`
+}
+
+// UpdateWebhookEndpointRequest changes an endpoint. Omitted fields are left as they are.
+type UpdateWebhookEndpointRequest struct {
+	URL         *string  `json:"url,omitempty" validate:"omitempty,http_url"`
+	EventTypes  []string `json:"eventTypes,omitempty" validate:"omitempty,min=1,dive,oneof=* UserCreated UserUpdated UserDeleted UserLoggedIn"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	IsActive    *bool    `json:"isActive,omitempty"`
+}
+
+// ListWebhookDeliveriesRequest defines the query parameters of the delivery log.
+type ListWebhookDeliveriesRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending succeeded dead"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=500"`
+	Offset int    `query:"offset" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package response /youGo/internal/api/response/audit_response.go
+package response
+
+import (
+	"time"
+
+	"youGo/internal/domain"
+)
+
+// AuditEventResponse represents a single audit event returned by the API.
+type AuditEventResponse struct {
+	ID         string                        `json:"id"`
+	ActorID    string                        `json:"actorId,omitempty"`
+	Action     string                        `json:"action"`
+	TargetType string                        `json:"targetType"`
+	TargetID   string                        `json:"targetId"`
+	Changes    map[string]domain.FieldChange `json:"changes,omitempty"`
+	RequestID  string                        `json:"requestId,omitempty"`
+	IP         string                        `json:"ip,omitempty"`
+	OccurredAt time.Time                     `json:"occurredAt"`
+}
+
+// AuditEventListResponse is a page of audit events.
+type AuditEventListResponse struct {
+	Items  []AuditEventResponse `json:"items"`
+	Total  int64                `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// NewAuditEventResponse creates an AuditEventResponse DTO from a domain.AuditEvent.
+func NewAuditEventResponse(event *domain.AuditEvent) AuditEventResponse {
+	resp := AuditEventResponse{
+		ID:         event.ID.String(),
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		Changes:    event.Changes,
+		RequestID:  event.RequestID,
+		IP:         event.IP,
+		OccurredAt: event.OccurredAt,
+	}
+	if event.ActorID != nil {
+		resp.ActorID = event.ActorID.String()
+	}
+	return resp
+}
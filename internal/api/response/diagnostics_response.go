@@ -0,0 +1,81 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package response /youGo/internal/api/response/diagnostics_response.go
+package response
+
+import (
+	"youGo/internal/platform/database"
+)
+
+// DatabaseDiagnosticsResponse describes the database connection. Durations are Go duration
+// strings, "0s" meaning no limit.
+type DatabaseDiagnosticsResponse struct {
+	Driver   string                   `json:"driver"`
+	Settings DatabaseSettingsResponse `json:"settings"`
+	Pool     DatabasePoolResponse     `json:"pool"`
+	Replicas []ReplicaStatusResponse  `json:"replicas"`
+}
+
+// DatabaseSettingsResponse holds the effective connection settings: the configuration completed with the defaults.
+type DatabaseSettingsResponse struct {
+	MaxOpenConns        int    `json:"maxOpenConns"`
+	MaxIdleConns        int    `json:"maxIdleConns"`
+	ConnMaxLifetime     string `json:"connMaxLifetime"`
+	ConnMaxIdleTime     string `json:"connMaxIdleTime"`
+	StatementTimeout    string `json:"statementTimeout"`
+	ConnectRetries      int    `json:"connectRetries"`
+	ConnectRetryBackoff string `json:"connectRetryBackoff"`
+	SlowQueryThreshold  string `json:"slowQueryThreshold"`
+}
+
+// DatabasePoolResponse holds the statistics of the primary's connection pool.
+type DatabasePoolResponse struct {
+	OpenConnections   int    `json:"openConnections"`
+	InUse             int    `json:"inUse"`
+	Idle              int    `json:"idle"`
+	WaitCount         int64  `json:"waitCount"`
+	WaitDuration      string `json:"waitDuration"`
+	MaxIdleClosed     int64  `json:"maxIdleClosed"`
+	MaxIdleTimeClosed int64  `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed int64  `json:"maxLifetimeClosed"`
+}
+
+// ReplicaStatusResponse tells whether a read replica currently serves reads.
+type ReplicaStatusResponse struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+}
+
+// NewDatabaseDiagnosticsResponse creates a DatabaseDiagnosticsResponse DTO from database.Diagnostics.
+func NewDatabaseDiagnosticsResponse(d database.Diagnostics) DatabaseDiagnosticsResponse {
+	replicas := make([]ReplicaStatusResponse, 0, len(d.Replicas))
+	for _, r := range d.Replicas {
+		replicas = append(replicas, ReplicaStatusResponse{Name: r.Name, Healthy: r.Healthy})
+	}
+	return DatabaseDiagnosticsResponse{
+		Driver: d.Settings.Driver,
+		Settings: DatabaseSettingsResponse{
+			MaxOpenConns:        d.Settings.MaxOpenConns,
+			MaxIdleConns:        d.Settings.MaxIdleConns,
+			ConnMaxLifetime:     d.Settings.ConnMaxLifetime.String(),
+			ConnMaxIdleTime:     d.Settings.ConnMaxIdleTime.String(),
+			StatementTimeout:    d.Settings.StatementTimeout.String(),
+			ConnectRetries:      d.Settings.ConnectRetries,
+			ConnectRetryBackoff: d.Settings.ConnectRetryBackoff.String(),
+			SlowQueryThreshold:  d.Settings.SlowQueryThreshold.String(),
+		},
+		Pool: DatabasePoolResponse{
+			OpenConnections:   d.Pool.OpenConnections,
+			InUse:             d.Pool.InUse,
+			Idle:              d.Pool.Idle,
+			WaitCount:         d.Pool.WaitCount,
+			WaitDuration:      d.Pool.WaitDuration.String(),
+			MaxIdleClosed:     d.Pool.MaxIdleClosed,
+			MaxIdleTimeClosed: d.Pool.MaxIdleTimeClosed,
+			MaxLifetimeClosed: d.Pool.MaxLifetimeClosed,
+		},
+		Replicas: replicas,
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package response /youGo/internal/api/response/usage_response.go
+package response
+
+import "time"
+
+// UsageReportResponse is the usage of a user or organization in the current billing period.
+type UsageReportResponse struct {
+	Subject     string               `json:"subject"`        // e.g. "user:<id>" or "org:<id>"
+	Plan        string               `json:"plan,omitempty"` // None when usage is unlimited
+	PeriodStart time.Time            `json:"periodStart"`
+	PeriodEnd   time.Time            `json:"periodEnd"`
+	Meters      []UsageMeterResponse `json:"meters"`
+}
+
+// UsageMeterResponse is the usage of a meter, e.g. "api_calls:standard" or "seats".
+// Levels (seats) are current values rather than totals of the period.
+type UsageMeterResponse struct {
+	Meter     string `json:"meter"`
+	Used      int64  `json:"used"`
+	Limit     *int64 `json:"limit,omitempty"` // Quota of the plan; none when unlimited
+	Remaining *int64 `json:"remaining,omitempty"`
+}
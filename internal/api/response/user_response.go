@@ -18,6 +18,7 @@ type UserResponse struct {
 	Email     string    `json:"email"`
 	IsActive  bool      `json:"isActive"`
 	Role      string    `json:"role"`
+	Locale    string    `json:"locale,omitempty"` // Preferred language of messages, if any
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 	// Role string    `json:"role,omitempty"`
@@ -40,6 +41,39 @@ func NewUserResponse(user *domain.User) UserResponse {
 	}
 }
 
+// UserSearchHitResponse is a user found by a search.
+type UserSearchHitResponse struct {
+	User       UserResponse           `json:"user"`
+	Rank       float64                `json:"rank"` // Higher is more relevant; 0 when the database doesn't rank
+	Highlights UserHighlightsResponse `json:"highlights"`
+}
+
+// UserHighlightsResponse holds the matched fields, HTML-escaped, with the matches wrapped in <mark> tags.
+type UserHighlightsResponse struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UserSearchResponse is a page of search hits, most relevant first.
+type UserSearchResponse struct {
+	Items  []UserSearchHitResponse `json:"items"`
+	Total  int64                   `json:"total"`
+	Limit  int                     `json:"limit"`
+	Offset int                     `json:"offset"`
+}
+
+// NewUserSearchHitResponse creates a UserSearchHitResponse DTO from a domain.UserSearchHit.
+func NewUserSearchHitResponse(hit domain.UserSearchHit) UserSearchHitResponse {
+	return UserSearchHitResponse{
+		User: NewUserResponse(hit.User),
+		Rank: hit.Rank,
+		Highlights: UserHighlightsResponse{
+			Name:  hit.NameHighlight,
+			Email: hit.EmailHighlight,
+		},
+	}
+}
+
 // NewUserListResponse creates a slice of UserResponse DTOs from a slice of domain.User objects.
 // func NewUserListResponse(users []*domain.User) []UserResponse {
 //  list := make([]UserResponse, len(users))
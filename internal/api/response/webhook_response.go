@@ -0,0 +1,93 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package response /youGo/internal/api/response/webhook_response.go
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"youGo/internal/domain"
+)
+
+// WebhookEndpointResponse represents a webhook endpoint. The secret is never included.
+type WebhookEndpointResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"eventTypes"`
+	Description string    `json:"description,omitempty"`
+	IsActive    bool      `json:"isActive"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// WebhookEndpointSecretResponse is returned once, on creation, so the caller can store the signing secret.
+type WebhookEndpointSecretResponse struct {
+	WebhookEndpointResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse represents a delivery log entry.
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	EndpointID     string          `json:"endpointId"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"` // Only while pending
+	LastAttemptAt  *time.Time      `json:"lastAttemptAt,omitempty"`
+	LastStatusCode int             `json:"lastStatusCode,omitempty"`
+	LastResponse   string          `json:"lastResponse,omitempty"` // Only shown to admins
+	LastError      string          `json:"lastError,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+// WebhookDeliveryListResponse is a page of the delivery log.
+type WebhookDeliveryListResponse struct {
+	Items  []WebhookDeliveryResponse `json:"items"`
+	Total  int64                     `json:"total"`
+	Limit  int                       `json:"limit"`
+	Offset int                       `json:"offset"`
+}
+
+// NewWebhookEndpointResponse creates a WebhookEndpointResponse DTO from a domain.WebhookEndpoint.
+func NewWebhookEndpointResponse(endpoint *domain.WebhookEndpoint) WebhookEndpointResponse {
+	return WebhookEndpointResponse{
+		ID:          endpoint.ID.String(),
+		URL:         endpoint.URL,
+		EventTypes:  endpoint.EventTypes,
+		Description: endpoint.Description,
+		IsActive:    endpoint.IsActive,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+}
+
+// NewWebhookDeliveryResponse creates a WebhookDeliveryResponse DTO from a domain.WebhookDelivery.
+func NewWebhookDeliveryResponse(delivery *domain.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             delivery.ID.String(),
+		EndpointID:     delivery.EndpointID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastResponse:   delivery.LastResponse,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		next := delivery.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
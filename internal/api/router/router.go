@@ -15,107 +15,99 @@ import (
 	"go.uber.org/zap"
 
 	_ "youGo/docs"
-
-	"youGo/internal/api/handler"
+	"youGo/internal/platform/buildinfo"
 )
 
+// Guards are the access-control middlewares shared by every module's routes.
+type Guards struct {
+	Auth  echo.MiddlewareFunc // Requires a valid access token (JWTAuth)
+	Admin echo.MiddlewareFunc // Requires the admin role (RequireRole); use after Auth
+}
+
+// Idempotency is the middleware replaying the response of a request retried with the same
+// Idempotency-Key (see middleware.Idempotency), for the routes creating resources.
+type Idempotency echo.MiddlewareFunc
+
+// RateLimit is the middleware applying the rate limit policies of the configuration (see
+// middleware.RateLimit) to every request.
+type RateLimit echo.MiddlewareFunc
+
+// Metering is the middleware metering API calls as billable usage and enforcing the quotas of
+// plans (see middleware.Metering).
+type Metering echo.MiddlewareFunc
+
+// Metrics is the middleware counting requests and observing their latency for Prometheus
+// (see middleware.Metrics).
+type Metrics echo.MiddlewareFunc
+
+// Routes are the groups a module attaches its handlers to.
+type Routes struct {
+	Logger *zap.Logger
+	Guards Guards
+	Root   *echo.Group // /, unversioned routes such as the health probes
+	API    *echo.Group // /api/v1, public: add Guards.Auth to the routes or groups that need it
+	Admin  *echo.Group // /api/v1/admin, already behind Guards.Auth and Guards.Admin
+}
+
+// RouteRegistrar is implemented by anything that adds routes, typically a module.
+type RouteRegistrar interface {
+	RegisterRoutes(r *Routes) error
+}
+
+// RouteRegistrarFunc adapts a function to RouteRegistrar.
+type RouteRegistrarFunc func(r *Routes) error
+
+// RegisterRoutes calls f(r).
+func (f RouteRegistrarFunc) RegisterRoutes(r *Routes) error { return f(r) }
+
 // Dependencies holds the required components for setting up routes.
-// This struct is populated in main.go and passed to SetupRoutes.
+// Feature routes are not listed here: each module registers its own (see internal/app).
 type Dependencies struct {
-	Logger         *zap.Logger
-	AuthMiddleware echo.MiddlewareFunc // The JWTAuth middleware instance configured in main.go
-
-	// Handlers
-	AuthHandler *handler.AuthHandler
-	UserHandler *handler.UserHandler
-	// Add other handlers here, e.g.:
-	// ProductHandler *producthandler.ProductHandler
+	Logger     *zap.Logger
+	Guards     Guards
+	Registrars []RouteRegistrar
 }
 
-// SetupRoutes configures all the application routes and applies middleware.
-func SetupRoutes(e *echo.Echo, deps Dependencies) {
+// SetupRoutes configures the shared routes and groups, then lets every registrar add its routes.
+func SetupRoutes(e *echo.Echo, deps Dependencies) error {
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	// --- Health Check / Root ---
-	// A simple endpoint to check if the service is running
+	// --- Root ---
+	// Static: the probes checking the dependencies are /healthz and /readyz (see the health module)
 	e.GET("/", func(c echo.Context) error {
-		// You can return status or version information here
 		return c.JSON(http.StatusOK, map[string]string{
 			"service": "youGo",
 			"status":  "ok",
-			"version": "1.0.0", // Consider adding dynamic versioning
+			"version": buildinfo.Get().Version,
 		})
 	})
+	// The version and commit only: the build in detail is served on the admin port (see the metrics module)
+	e.GET("/version", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, buildinfo.Get().Summary())
+	})
 
 	// --- API Versioning Group ---
 	// Grouping routes under /api/v1 for future versioning
 	api := e.Group("/api/v1")
 
-	// --- Authentication Routes (Public) ---
-	// These routes generally do not require the user to be logged in.
-	authGroup := api.Group("/auth")
-	{ // Braces for visual grouping, optional
-		deps.Logger.Debug("Setting up /auth routes")
-		authGroup.POST("/login", deps.AuthHandler.Login)
-		authGroup.POST("/signup", deps.AuthHandler.Register)
-
-		// Add other public auth routes if implemented:
-		// authGroup.POST("/refresh", deps.AuthHandler.RefreshToken) // Needs careful consideration about auth state
-		// authGroup.POST("/forgot-password", deps.AuthHandler.ForgotPassword)
-		// authGroup.POST("/reset-password", deps.AuthHandler.ResetPassword)
-	}
+	// --- Admin Routes (Protected with Auth + Admin role) ---
+	adminGroup := api.Group("/admin")
+	adminGroup.Use(deps.Guards.Auth, deps.Guards.Admin)
 
-	// --- User Routes (Protected) ---
-	// Routes related to the logged-in user's own data.
-	// Apply the authentication middleware to this group.
-	//userGroup := api.Group("/users")
-	//userGroup.Use(deps.AuthMiddleware) // Apply JWT authentication to all routes below
-	//{
-	//	deps.Logger.Debug("Setting up protected /users routes")
-	//	// Endpoint for the logged-in user to get their own profile
-	//	userGroup.GET("/me", deps.UserHandler.GetMe)
-	//	// Endpoint for the logged-in user to update their own profile
-	//	userGroup.PUT("/me", deps.UserHandler.UpdateMe)
-	//	// Endpoint for the logged-in user to change their password
-	//	userGroup.PUT("/me/password", deps.UserHandler.ChangeMyPassword)
-	//}
-
-	// --- Admin User Routes (Example - Protected with Auth + Admin Middleware) ---
-	// Routes for administrators managing users. Requires additional role checking.
-	// NOTE: This requires an additional Admin middleware not defined yet.
-	/*
-	   adminUserGroup := api.Group("/admin/users")
-	   adminUserGroup.Use(deps.AuthMiddleware) // Must be logged in
-	   // adminUserGroup.Use(apimiddleware.RequireAdmin(deps.Logger)) // Apply admin check middleware <<<< NEEDS IMPLEMENTATION
-	   {
-	       deps.Logger.Debug("Setting up protected /admin/users routes")
-	       adminUserGroup.GET("", deps.UserHandler.ListUsers) // Handler method needs implementation
-	       adminUserGroup.POST("", deps.UserHandler.CreateUser) // Handler method needs implementation
-	       adminUserGroup.GET("/:id", deps.UserHandler.GetUserByID) // Handler method needs implementation
-	       adminUserGroup.PUT("/:id", deps.UserHandler.UpdateUser) // Handler method needs implementation
-	       adminUserGroup.DELETE("/:id", deps.UserHandler.DeleteUser) // Handler method needs implementation
-	   }
-	*/
-
-	// --- Other Resource Routes (Example: Products) ---
-	/*
-	   productGroup := api.Group("/products")
-	   {
-	       deps.Logger.Debug("Setting up /products routes")
-	       // Public endpoints to view products
-	       productGroup.GET("", deps.ProductHandler.ListProducts)
-	       productGroup.GET("/:id", deps.ProductHandler.GetProductByID)
-
-	       // Protected endpoints to manage products
-	       // Apply middleware directly to routes or group specific sub-routes
-	       productGroup.POST("", deps.ProductHandler.CreateProduct, deps.AuthMiddleware)
-	       productGroup.PUT("/:id", deps.ProductHandler.UpdateProduct, deps.AuthMiddleware)
-	       productGroup.DELETE("/:id", deps.ProductHandler.DeleteProduct, deps.AuthMiddleware)
-	       // Example: Adding a review might also require auth
-	       // productGroup.POST("/:id/reviews", deps.ProductHandler.AddReview, deps.AuthMiddleware)
-	   }
-	*/
+	routes := &Routes{
+		Logger: deps.Logger,
+		Guards: deps.Guards,
+		Root:   e.Group(""),
+		API:    api,
+		Admin:  adminGroup,
+	}
+	for _, registrar := range deps.Registrars {
+		if err := registrar.RegisterRoutes(routes); err != nil {
+			return err
+		}
+	}
 
 	deps.Logger.Info("✅ API routes configured successfully")
+	return nil
 }
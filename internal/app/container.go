@@ -0,0 +1,124 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package app /youGo/internal/app/container.go
+// Package app assembles the application from modules. Each module registers constructors for
+// its repositories and services in a Container, and may add routes, migrations and lifecycle hooks.
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// provider builds one component. value is set once it has been built.
+type provider struct {
+	module    string // Module that registered the constructor, for error messages
+	construct func(c *Container) (interface{}, error)
+	built     bool
+	value     interface{}
+	replaces  bool // Registered with Replace: wins over the provider of the type
+}
+
+// Container is a registry of components keyed by type, typically an interface such as
+// domain.UserRepository. Constructors run lazily on first Resolve and their result is shared,
+// so modules may depend on each other's components regardless of the order they are listed in.
+//
+// A Container is meant to be filled and resolved while the application starts; it is not safe
+// for concurrent use.
+type Container struct {
+	providers map[reflect.Type]*provider
+	resolving []reflect.Type // Resolve stack, to report dependency cycles
+	module    string         // Module currently registering providers
+}
+
+// NewContainer returns an empty container.
+func NewContainer() *Container {
+	return &Container{providers: make(map[reflect.Type]*provider)}
+}
+
+// Provide registers the constructor of T. Registering the same type twice is a programming
+// error and panics, like registering an HTTP handler twice does, unless the type is replaced
+// (see Replace).
+func Provide[T any](c *Container, construct func(c *Container) (T, error)) {
+	t := reflect.TypeFor[T]()
+	if existing, ok := c.providers[t]; ok {
+		if existing.replaces {
+			return
+		}
+		panic(fmt.Sprintf("app: %s is provided by both module %q and module %q", t, existing.module, c.module))
+	}
+	c.providers[t] = newProvider(c.module, construct, false)
+}
+
+// Replace registers the constructor of T in place of the one of the module providing it,
+// whether that module registers before or after, e.g. a ratelimit.Store shared between
+// instances. Replacing a type twice, or once it has been built, panics.
+func Replace[T any](c *Container, construct func(c *Container) (T, error)) {
+	t := reflect.TypeFor[T]()
+	if existing, ok := c.providers[t]; ok {
+		switch {
+		case existing.replaces:
+			panic(fmt.Sprintf("app: %s is replaced by both module %q and module %q", t, existing.module, c.module))
+		case existing.built:
+			panic(fmt.Sprintf("app: %s is replaced by module %q after it was built", t, c.module))
+		}
+	}
+	c.providers[t] = newProvider(c.module, construct, true)
+}
+
+func newProvider[T any](module string, construct func(c *Container) (T, error), replaces bool) *provider {
+	return &provider{
+		module: module,
+		construct: func(c *Container) (interface{}, error) {
+			return construct(c)
+		},
+		replaces: replaces,
+	}
+}
+
+// Supply registers an already built value of T.
+func Supply[T any](c *Container, value T) {
+	Provide(c, func(*Container) (T, error) { return value, nil })
+}
+
+// Resolve returns the component registered for T, building it (and its dependencies) on first use.
+func Resolve[T any](c *Container) (T, error) {
+	var zero T
+	t := reflect.TypeFor[T]()
+	p, ok := c.providers[t]
+	if !ok {
+		return zero, fmt.Errorf("app: nothing provides %s", t)
+	}
+	if !p.built {
+		for i, r := range c.resolving {
+			if r == t {
+				return zero, fmt.Errorf("app: dependency cycle: %s", cyclePath(append(c.resolving[i:], t)))
+			}
+		}
+		c.resolving = append(c.resolving, t)
+		value, err := p.construct(c)
+		c.resolving = c.resolving[:len(c.resolving)-1]
+		if err != nil {
+			return zero, fmt.Errorf("app: building %s (module %s): %w", t, p.module, err)
+		}
+		p.value, p.built = value, true
+	}
+	return p.value.(T), nil
+}
+
+// Has reports whether something provides T.
+func Has[T any](c *Container) bool {
+	_, ok := c.providers[reflect.TypeFor[T]()]
+	return ok
+}
+
+func cyclePath(types []reflect.Type) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return strings.Join(names, " -> ")
+}
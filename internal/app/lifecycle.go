@@ -0,0 +1,108 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package app /youGo/internal/app/lifecycle.go
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Hook is a component that needs to be started and stopped with the application,
+// such as the HTTP server or a background worker. Either function may be nil.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle starts hooks in the order they were appended and stops them in reverse order.
+// Components appended last (the HTTP server) therefore stop first, so that nothing they depend
+// on, such as the outbox relay, goes away while requests are still being served.
+type Lifecycle struct {
+	hooks   []Hook
+	started int // Number of hooks started, so a failed Start only stops those
+	logger  *zap.Logger
+}
+
+// NewLifecycle returns an empty lifecycle.
+func NewLifecycle(logger *zap.Logger) *Lifecycle {
+	return &Lifecycle{logger: logger.Named("Lifecycle")}
+}
+
+// Append adds a hook.
+func (l *Lifecycle) Append(h Hook) {
+	l.hooks = append(l.hooks, h)
+}
+
+// Go adds a background worker: run is started in its own goroutine on start, and on stop its
+// context is cancelled and Stop waits for it to return (or for the stop context to expire).
+func (l *Lifecycle) Go(name string, run func(ctx context.Context)) {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+	l.Append(Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			// The start context only bounds starting; the worker lives until stop
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+			go func() {
+				defer close(done)
+				run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return fmt.Errorf("%s did not stop in time: %w", name, ctx.Err())
+			}
+		},
+	})
+}
+
+// Start runs every OnStart in order. If one fails, the hooks already started are stopped
+// and the error is returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	for _, h := range l.hooks {
+		if h.OnStart != nil {
+			if err := h.OnStart(ctx); err != nil {
+				startErr := fmt.Errorf("starting %s: %w", h.Name, err)
+				return errors.Join(startErr, l.Stop(ctx))
+			}
+		}
+		l.started++
+		l.logger.Debug("Started", zap.String("hook", h.Name))
+	}
+	return nil
+}
+
+// Stop runs the OnStop of every started hook in reverse order. All hooks are stopped
+// even if some fail; the errors are joined.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		h := l.hooks[l.started-1]
+		if h.OnStop == nil {
+			continue
+		}
+		if err := h.OnStop(ctx); err != nil {
+			l.logger.Error("Failed to stop", zap.String("hook", h.Name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("stopping %s: %w", h.Name, err))
+			continue
+		}
+		l.logger.Debug("Stopped", zap.String("hook", h.Name))
+	}
+	return errors.Join(errs...)
+}
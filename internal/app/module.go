@@ -0,0 +1,112 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package app /youGo/internal/app/module.go
+package app
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/router"
+)
+
+// Module is a feature of the application. Provide registers the constructors of the module's
+// repositories and services; a module may additionally implement RouteModule, MigrationModule
+// and HookModule.
+type Module interface {
+	Name() string
+	Provide(c *Container)
+}
+
+// RouteModule is a module with HTTP routes. Handlers are built from the container.
+type RouteModule interface {
+	Module
+	RegisterRoutes(c *Container, r *router.Routes) error
+}
+
+// MigrationModule is a module owning database tables. Versions are shared by all modules,
+// so a new migration takes the next free number whichever module it belongs to.
+type MigrationModule interface {
+	Module
+	Migrations() fs.FS
+}
+
+// HookModule is a module with components to start and stop with the server, such as workers.
+type HookModule interface {
+	Module
+	RegisterHooks(c *Container, lc *Lifecycle) error
+}
+
+// App is the application assembled from a list of modules.
+type App struct {
+	Container *Container
+	Lifecycle *Lifecycle
+	modules   []Module
+	logger    *zap.Logger
+}
+
+// New registers the providers of every module. Values shared by all modules (configuration,
+// logger, database...) are supplied to c by the caller beforehand.
+func New(c *Container, logger *zap.Logger, modules ...Module) *App {
+	for _, m := range modules {
+		c.module = m.Name()
+		m.Provide(c)
+	}
+	c.module = ""
+	return &App{Container: c, Lifecycle: NewLifecycle(logger), modules: modules, logger: logger}
+}
+
+// Modules returns the modules, in the order they were given.
+func (a *App) Modules() []Module {
+	return a.modules
+}
+
+// Migrations returns the migrations of every module.
+func (a *App) Migrations() []fs.FS {
+	var sources []fs.FS
+	for _, m := range a.modules {
+		if mm, ok := m.(MigrationModule); ok {
+			sources = append(sources, mm.Migrations())
+		}
+	}
+	return sources
+}
+
+// RegisterHooks adds the hooks of every module to the lifecycle, in module order.
+// Only the server does this: other commands use the container without starting anything.
+func (a *App) RegisterHooks() error {
+	for _, m := range a.modules {
+		if hm, ok := m.(HookModule); ok {
+			if err := hm.RegisterHooks(a.Container, a.Lifecycle); err != nil {
+				return fmt.Errorf("module %s: %w", m.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// SetupRoutes registers the shared routes and those of every module on e.
+// Some module must provide router.Guards.
+func (a *App) SetupRoutes(e *echo.Echo) error {
+	guards, err := Resolve[router.Guards](a.Container)
+	if err != nil {
+		return err
+	}
+	deps := router.Dependencies{Logger: a.logger, Guards: guards}
+	for _, m := range a.modules {
+		if rm, ok := m.(RouteModule); ok {
+			deps.Registrars = append(deps.Registrars, router.RouteRegistrarFunc(func(r *router.Routes) error {
+				if err := rm.RegisterRoutes(a.Container, r); err != nil {
+					return fmt.Errorf("module %s: %w", rm.Name(), err)
+				}
+				return nil
+			}))
+		}
+	}
+	return router.SetupRoutes(e, deps)
+}
@@ -16,6 +16,7 @@ import (
 	// Import request DTO if Login needs it (though better to pass individual fields)
 	"github.com/google/uuid" // Use consistent ID type
 	"youGo/internal/api/request"
+	"youGo/internal/platform/tracing"
 )
 
 var (
@@ -30,6 +31,7 @@ var (
 type Service interface {
 	Login(ctx context.Context, req *request.LoginRequest) (accessToken, refreshToken string, err error) // Accept DTO or email/password
 	ValidateToken(tokenString string) (userID uuid.UUID, err error)                                     // Return uuid.UUID
+	TokenClaims(tokenString string) (*CustomClaims, error)                                              // Claims of a valid token, with the user ID set
 	// RefreshToken(ctx context.Context, refreshTokenString string) (newAccessToken string, err error) // Optional
 }
 
@@ -37,6 +39,8 @@ type Service interface {
 type authService struct {
 	// CORRECT DEPENDENCY: Use the UserRepository interface from the domain package
 	userRepo domain.UserRepository
+	auditor  domain.AuditRecorder    // Records login successes and failures
+	outbox   domain.OutboxRepository // Publishes UserLoggedIn events
 
 	jwtSecret            []byte
 	accessTokenDuration  time.Duration
@@ -45,10 +49,12 @@ type authService struct {
 }
 
 // NewAuthService creates a new instance of the authentication service.
-// It requires the user repository interface and JWT configuration values.
+// It requires the user repository interface, an audit recorder, the event outbox and JWT configuration values.
 func NewAuthService(
 	// CORRECT DEPENDENCY: Accept the interface
 	repo domain.UserRepository,
+	auditor domain.AuditRecorder,
+	outbox domain.OutboxRepository,
 	jwtSecret []byte,
 	accessDuration time.Duration,
 	refreshDuration time.Duration,
@@ -58,6 +64,8 @@ func NewAuthService(
 	}
 	return &authService{
 		userRepo:             repo, // Store the interface implementation
+		auditor:              auditor,
+		outbox:               outbox,
 		jwtSecret:            jwtSecret,
 		accessTokenDuration:  accessDuration,
 		refreshTokenDuration: refreshDuration,
@@ -69,11 +77,15 @@ func NewAuthService(
 
 // Login handles user login attempts.
 func (s *authService) Login(ctx context.Context, req *request.LoginRequest) (accessToken, refreshToken string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer tracing.End(span, &err)
+
 	// 1. Find user by email using the UserRepository interface
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			// Return specific auth error
+			s.recordLogin(ctx, domain.AuditActionAuthLoginFailed, domain.AuditTargetEmail, req.Email, nil)
 			return "", "", ErrInvalidCredentials
 		}
 		// Log underlying error? Need logger dependency if so.
@@ -82,13 +94,14 @@ func (s *authService) Login(ctx context.Context, req *request.LoginRequest) (acc
 
 	// 2. Check password hash using the helper from this package
 	if !CheckPasswordHash(req.Password, user.PasswordHash) {
+		s.recordLogin(ctx, domain.AuditActionAuthLoginFailed, domain.AuditTargetUser, user.ID.String(), nil)
 		return "", "", ErrInvalidCredentials
 	}
 
 	// 3. Generate tokens using helpers from this package
 	// userID := user.ID // ID is uuid.UUID now
 
-	accessToken, err = GenerateAccessToken(user.ID, s.jwtSecret, s.accessTokenDuration) // Pass uuid.UUID directly if generator handles it, or user.ID.String() if it expects string
+	accessToken, err = GenerateAccessToken(user.ID, user.Locale, s.jwtSecret, s.accessTokenDuration) // Pass uuid.UUID directly if generator handles it, or user.ID.String() if it expects string
 	if err != nil {
 		// Log error?
 		return "", "", fmt.Errorf("failed to generate access token: %w", err)
@@ -100,29 +113,57 @@ func (s *authService) Login(ctx context.Context, req *request.LoginRequest) (acc
 		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
-	// 4. Return tokens
+	// 4. Record the successful login (the user is the actor, since the request is unauthenticated)
+	s.recordLogin(ctx, domain.AuditActionAuthLoginSucceeded, domain.AuditTargetUser, user.ID.String(), &user.ID)
+
+	// 5. Announce the login to other services. Like the audit entry, this must not block authentication.
+	if event, err := domain.NewUserEventFromPayload(domain.EventUserLoggedIn,
+		domain.UserEventPayload{ID: user.ID, Email: user.Email, IsActive: user.IsActive}); err == nil {
+		_ = s.outbox.Append(ctx, event)
+	}
+
+	// 6. Return tokens
 	return accessToken, refreshToken, nil
 }
 
+// recordLogin adds a login attempt to the audit trail. Failures are logged by the recorder
+// and must not block authentication.
+func (s *authService) recordLogin(ctx context.Context, action, targetType, targetID string, actorID *uuid.UUID) {
+	_ = s.auditor.Record(ctx, &domain.AuditEvent{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+	})
+}
+
 // ValidateToken is used by middleware to check token validity and get user ID.
 func (s *authService) ValidateToken(tokenString string) (userID uuid.UUID, err error) {
+	claims, err := s.TokenClaims(tokenString)
+	if err != nil {
+		return uuid.Nil, err // Return Nil UUID on error
+	}
+	return claims.UserID, nil
+}
+
+// TokenClaims checks the validity of a token like ValidateToken, and returns all of its claims.
+func (s *authService) TokenClaims(tokenString string) (*CustomClaims, error) {
 	claims, err := ValidateToken(tokenString, s.jwtSecret) // Use helper from this package
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("token validation failed: %w", err) // Return Nil UUID on error
+		return nil, fmt.Errorf("token validation failed: %w", err)
 	}
 
-	// Token is valid, return the UserID stored in the Subject/Custom claim
+	// Token is valid, the UserID is stored in the Subject/Custom claim
 	// Try parsing from custom claim first, then Subject
-	userIDStr := claims.UserID
-	if userIDStr == uuid.Nil {
-		userIDStr = claims.UserID
+	if claims.UserID == uuid.Nil {
+		claims.UserID, _ = uuid.Parse(claims.Subject)
 	}
 
-	if userID == uuid.Nil {
-		return uuid.Nil, errors.New("invalid token: missing user identifier in claims")
+	if claims.UserID == uuid.Nil {
+		return nil, errors.New("invalid token: missing user identifier in claims")
 	}
 
-	return userID, nil
+	return claims, nil
 }
 
 // RefreshToken implementation would go here if needed...
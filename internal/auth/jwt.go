@@ -18,16 +18,19 @@ import (
 // It includes standard registered claims and custom claims like UserID.
 type CustomClaims struct {
 	UserID uuid.UUID `json:"user_id"`
+	Locale string    `json:"locale,omitempty"` // Language the user chose for messages (access tokens only), see domain.User.Locale
 	// Add other custom claims if needed (e.g., role, email)
 	// Role string `json:"role,omitempty"`
 	jwt.RegisteredClaims // Embeds standard claims like ExpiresAt, IssuedAt, Subject etc.
 }
 
-// GenerateAccessToken creates a new JWT access token for the given user ID.
-func GenerateAccessToken(userID uuid.UUID, secret []byte, expiryDuration time.Duration) (string, error) {
+// GenerateAccessToken creates a new JWT access token for the given user ID and locale ("" when
+// the user chose none).
+func GenerateAccessToken(userID uuid.UUID, locale string, secret []byte, expiryDuration time.Duration) (string, error) {
 	// Create the claims
 	claims := CustomClaims{
 		UserID: userID,
+		Locale: locale,
 		// Role: role, // Add role if needed
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID.String(),                                    // Subject identifies the principal that is the subject of the JWT.
@@ -0,0 +1,50 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package auth /youGo/internal/auth/observe.go
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"youGo/internal/api/request"
+)
+
+// Observer is told the outcome of logins and token validations, e.g. to count them
+// (see metrics.Metrics).
+type Observer interface {
+	LoginAttempted(err error)
+	TokenValidated(err error)
+}
+
+// observedService reports the outcomes of the calls of a Service to an Observer.
+type observedService struct {
+	Service
+	observer Observer
+}
+
+// Observe returns svc reporting the outcome of every login and token validation to o.
+func Observe(svc Service, o Observer) Service {
+	return &observedService{Service: svc, observer: o}
+}
+
+func (s *observedService) Login(ctx context.Context, req *request.LoginRequest) (string, string, error) {
+	accessToken, refreshToken, err := s.Service.Login(ctx, req)
+	s.observer.LoginAttempted(err)
+	return accessToken, refreshToken, err
+}
+
+func (s *observedService) ValidateToken(tokenString string) (uuid.UUID, error) {
+	userID, err := s.Service.ValidateToken(tokenString)
+	s.observer.TokenValidated(err)
+	return userID, err
+}
+
+func (s *observedService) TokenClaims(tokenString string) (*CustomClaims, error) {
+	claims, err := s.Service.TokenClaims(tokenString)
+	s.observer.TokenValidated(err)
+	return claims, err
+}
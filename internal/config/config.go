@@ -17,11 +17,19 @@ import (
 // Values are loaded from config files and/or environment variables.
 // Struct tags (`mapstructure`) define mapping from config file keys or env vars.
 type Config struct {
-	App      AppConfig    `mapstructure:"app"`
-	Server   ServerConfig `mapstructure:"server"`
-	Log      LogConfig    `mapstructure:"log"`
-	Auth     AuthConfig   `mapstructure:"auth"`
-	Database Database     `mapstructure:"database"`
+	App         AppConfig         `mapstructure:"app"`
+	Server      ServerConfig      `mapstructure:"server"`
+	Log         LogConfig         `mapstructure:"log"`
+	Auth        AuthConfig        `mapstructure:"auth"`
+	Database    Database          `mapstructure:"database"`
+	Outbox      OutboxConfig      `mapstructure:"outbox"`
+	Webhooks    WebhooksConfig    `mapstructure:"webhooks"`
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
+	Metering    MeteringConfig    `mapstructure:"metering"`
+	Metrics     MetricsConfig     `mapstructure:"metrics"`
+	Tracing     TracingConfig     `mapstructure:"tracing"`
+	Health      HealthConfig      `mapstructure:"health"`
 }
 
 // AppConfig holds application-specific configuration.
@@ -33,21 +41,264 @@ type AppConfig struct {
 type ServerConfig struct {
 	Port               string   `mapstructure:"port"`
 	CORSAllowedOrigins []string `mapstructure:"cors_allowed_origins"` // Note: Corrected spelling from main.go comment example
+	// CIDR ranges of the reverse proxies in front of the server, e.g., ["10.0.0.0/8"]: the client
+	// IP is then taken from their X-Forwarded-For. Empty, it is the address of the connection.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 // Database holds database connection details.
 type Database struct {
+	Driver      string `mapstructure:"driver"` // "postgres" (default) or "sqlite", see DriverName
 	Host        string `mapstructure:"host"`
 	Port        string `mapstructure:"port"`
 	User        string `mapstructure:"user"`
 	Password    string `mapstructure:"password"` // IMPORTANT: Load sensitive data like passwords from ENV VARS in production.
 	DBName      string `mapstructure:"dbname"`
-	SSLMode     string `mapstructure:"sslmode"` // e.g., "disable", "require", "verify-full"
-	AutoMigrate bool   `mapstructure:"auto_migrate"`
-	// You might add connection pool settings here if needed
-	// MaxIdleConns int `mapstructure:"max_idle_conns"`
-	// MaxOpenConns int `mapstructure:"max_open_conns"`
-	// ConnMaxLifetime string `mapstructure:"conn_max_lifetime"` // e.g., "1h"
+	SSLMode     string `mapstructure:"sslmode"`      // e.g., "disable", "require", "verify-full"
+	AutoMigrate bool   `mapstructure:"auto_migrate"` // Apply pending embedded migrations on startup
+	// Read replicas (Postgres only): reads are spread over the healthy ones, writes go to the primary above
+	Replicas             []DatabaseReplica `mapstructure:"replicas"`
+	ReplicaCheckInterval string            `mapstructure:"replica_check_interval"` // How often replicas are pinged, e.g. "5s"
+	// Connection pool, shared settings of the primary and each replica; zero values take the defaults
+	// of database.SettingsFor, durations of "0" mean no limit
+	MaxOpenConns    int    `mapstructure:"max_open_conns"`
+	MaxIdleConns    int    `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime string `mapstructure:"conn_max_lifetime"`  // e.g., "1h"
+	ConnMaxIdleTime string `mapstructure:"conn_max_idle_time"` // e.g., "10m"
+	// Queries running longer than StatementTimeout are cancelled by Postgres, e.g. "30s"
+	StatementTimeout string `mapstructure:"statement_timeout"`
+	// Failed connection attempts on startup are retried ConnectRetries times, waiting
+	// ConnectRetryBackoff, then twice as long each time (up to 30s)
+	ConnectRetries      int    `mapstructure:"connect_retries"`
+	ConnectRetryBackoff string `mapstructure:"connect_retry_backoff"` // e.g., "1s"
+	SlowQueryThreshold  string `mapstructure:"slow_query_threshold"`  // Queries taking longer are logged, e.g. "500ms"
+}
+
+// DatabaseReplica is a read replica of the primary database. Empty fields take the primary's value.
+type DatabaseReplica struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	DBName   string `mapstructure:"dbname"`
+	SSLMode  string `mapstructure:"sslmode"`
+}
+
+// Replica returns the connection settings of the i-th replica, completed with the primary's.
+func (d Database) Replica(i int) Database {
+	r := d.Replicas[i]
+	replica := d
+	replica.Replicas = nil
+	override := func(dst *string, value string) {
+		if value != "" {
+			*dst = value
+		}
+	}
+	override(&replica.Host, r.Host)
+	override(&replica.Port, r.Port)
+	override(&replica.User, r.User)
+	override(&replica.Password, r.Password)
+	override(&replica.DBName, r.DBName)
+	override(&replica.SSLMode, r.SSLMode)
+	return replica
+}
+
+// Database drivers.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite" // For local development: DBName is the database file, or ":memory:"
+)
+
+// DriverName returns the configured driver, lowercased, defaulting to Postgres.
+func (d Database) DriverName() string {
+	if d.Driver == "" {
+		return DriverPostgres
+	}
+	return strings.ToLower(d.Driver)
+}
+
+// OutboxConfig holds settings for the relay that publishes domain events from the outbox table.
+type OutboxConfig struct {
+	Enabled             bool     `mapstructure:"enabled"`
+	PollInterval        string   `mapstructure:"poll_interval"`         // e.g., "1s"
+	BatchSize           int      `mapstructure:"batch_size"`            // Events claimed per relay batch
+	MaxAttempts         int      `mapstructure:"max_attempts"`          // Attempts before an event goes dead; 0 retries forever
+	Sinks               []string `mapstructure:"sinks"`                 // Any of "inprocess", "webhook", "broker", "webhook_subscriptions"
+	WebhookURL          string   `mapstructure:"webhook_url"`           // Target of the "webhook" sink
+	BrokerSubjectPrefix string   `mapstructure:"broker_subject_prefix"` // e.g., "yougo" -> "yougo.user.UserCreated"
+}
+
+// WebhooksConfig holds settings for the dispatcher that sends deliveries to registered webhook endpoints.
+// Deliveries are only queued when the "webhook_subscriptions" outbox sink is enabled.
+type WebhooksConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	PollInterval string `mapstructure:"poll_interval"` // e.g., "5s"
+	BatchSize    int    `mapstructure:"batch_size"`    // Deliveries claimed per poll
+	MaxAttempts  int    `mapstructure:"max_attempts"`  // Attempts before a delivery goes dead
+	Timeout      string `mapstructure:"timeout"`       // Per-attempt HTTP timeout, e.g., "10s"
+}
+
+// IdempotencyConfig holds settings for replaying the responses of requests retried with the
+// same Idempotency-Key (see middleware.Idempotency).
+type IdempotencyConfig struct {
+	Store           string `mapstructure:"store"`            // "database" (default), or "memory" for a single instance
+	TTL             string `mapstructure:"ttl"`              // How long responses are replayed, e.g., "24h"
+	LockTimeout     string `mapstructure:"lock_timeout"`     // How long a request in flight holds its key, e.g., "1m"
+	CleanupInterval string `mapstructure:"cleanup_interval"` // How often expired keys are deleted, e.g., "1h"
+}
+
+// Idempotency stores.
+const (
+	IdempotencyStoreDatabase = "database"
+	IdempotencyStoreMemory   = "memory"
+)
+
+// StoreName returns the configured store, lowercased, defaulting to the database.
+func (c IdempotencyConfig) StoreName() string {
+	if c.Store == "" {
+		return IdempotencyStoreDatabase
+	}
+	return strings.ToLower(c.Store)
+}
+
+// RateLimitConfig holds the request rate limits, declared per route group (path prefix).
+// A request counts against every policy whose prefix it falls under.
+type RateLimitConfig struct {
+	Enabled  bool              `mapstructure:"enabled"`
+	Policies []RateLimitPolicy `mapstructure:"policies"`
+}
+
+// RateLimitPolicy limits the requests of each client to the routes under a path prefix.
+type RateLimitPolicy struct {
+	Name     string `mapstructure:"name"`     // Names the counters and the policy in logs, e.g., "auth"
+	Prefix   string `mapstructure:"prefix"`   // e.g., "/api/v1/auth"
+	Requests int    `mapstructure:"requests"` // Requests allowed per window
+	Window   string `mapstructure:"window"`   // e.g., "1m"
+	By       string `mapstructure:"by"`       // Who is limited: "ip" (default), "user" or "api_key"
+}
+
+// Clients rate limits tell apart.
+const (
+	RateLimitByIP     = "ip"      // Client IP (echo's RealIP)
+	RateLimitByUser   = "user"    // Authenticated user, or else the IP
+	RateLimitByAPIKey = "api_key" // Verified X-API-Key header, or else the IP
+)
+
+// KeyName returns who the policy limits, lowercased, defaulting to the client IP.
+func (p RateLimitPolicy) KeyName() string {
+	if p.By == "" {
+		return RateLimitByIP
+	}
+	return strings.ToLower(p.By)
+}
+
+// MeteringConfig holds the metering of billable usage and the quotas of the plans.
+type MeteringConfig struct {
+	Enabled       bool                  `mapstructure:"enabled"`
+	Period        string                `mapstructure:"period"`         // Billing period, in UTC: "month" (default) or "day"
+	FlushInterval string                `mapstructure:"flush_interval"` // How often recorded usage is written, e.g., "10s"
+	RouteClasses  []RouteClass          `mapstructure:"route_classes"`  // API calls are metered by the class of the longest matching prefix
+	DefaultPlan   string                `mapstructure:"default_plan"`   // Plan of the subjects not listed; none means unlimited
+	Plans         map[string]PlanConfig `mapstructure:"plans"`
+	Subjects      map[string]string     `mapstructure:"subjects"` // Plans of users and organizations, e.g., "org:acme": "pro"
+	// Organization the user accounts are the seats of, e.g., "org:default": creating a user
+	// checks its seats quota. Empty, seats aren't metered.
+	SeatsSubject string `mapstructure:"seats_subject"`
+}
+
+// RouteClass groups routes whose API calls are metered together, as "api_calls:<name>".
+type RouteClass struct {
+	Name   string `mapstructure:"name"`   // e.g., "search"
+	Prefix string `mapstructure:"prefix"` // e.g., "/api/v1/admin/users/search"
+}
+
+// PlanConfig holds the quotas of a plan.
+type PlanConfig struct {
+	Quotas map[string]int64 `mapstructure:"quotas"` // Limits by meter, e.g., "api_calls:search": 1000; other meters are unlimited
+}
+
+// Billing periods of metering.
+const (
+	MeteringPeriodMonth = "month"
+	MeteringPeriodDay   = "day"
+)
+
+// PeriodName returns the configured billing period, lowercased, defaulting to a month.
+func (c MeteringConfig) PeriodName() string {
+	if c.Period == "" {
+		return MeteringPeriodMonth
+	}
+	return strings.ToLower(c.Period)
+}
+
+// MetricsConfig holds the Prometheus metrics endpoint, served on an admin port of its own so
+// that it isn't exposed with the API.
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Port    string `mapstructure:"port"` // Admin port, e.g., "9090"
+	Path    string `mapstructure:"path"` // Defaults to "/metrics"
+}
+
+// DefaultMetricsPath is the path metrics are served at unless configured.
+const DefaultMetricsPath = "/metrics"
+
+// PathOrDefault returns the configured path of the metrics, defaulting to DefaultMetricsPath.
+func (c MetricsConfig) PathOrDefault() string {
+	if c.Path == "" {
+		return DefaultMetricsPath
+	}
+	return c.Path
+}
+
+// TracingConfig holds the OpenTelemetry tracing of requests, services, SQL queries and outbound
+// HTTP calls.
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Exporter    string  `mapstructure:"exporter"`     // "stdout" (default) or "otlp"
+	Endpoint    string  `mapstructure:"endpoint"`     // OTLP/HTTP collector, host:port, e.g. "localhost:4318"
+	Insecure    bool    `mapstructure:"insecure"`     // OTLP over plain HTTP, e.g. to a local collector
+	SampleRatio float64 `mapstructure:"sample_ratio"` // Share of the new traces recorded, from 0 to 1; unset records all
+	ServiceName string  `mapstructure:"service_name"` // Defaults to DefaultServiceName
+}
+
+// Exporters of the spans.
+const (
+	TracingExporterStdout = "stdout" // Pretty-printed JSON on the standard output, for development
+	TracingExporterOTLP   = "otlp"   // OTLP over HTTP to a collector
+)
+
+// DefaultServiceName is the service.name of the spans unless configured.
+const DefaultServiceName = "you-go"
+
+// ExporterName returns the exporter of the spans, lowercased, defaulting to stdout.
+func (c TracingConfig) ExporterName() string {
+	if c.Exporter == "" {
+		return TracingExporterStdout
+	}
+	return strings.ToLower(c.Exporter)
+}
+
+// SampleRatioOrDefault returns the share of the new traces recorded, defaulting to all of them.
+func (c TracingConfig) SampleRatioOrDefault() float64 {
+	if c.SampleRatio == 0 {
+		return 1
+	}
+	return c.SampleRatio
+}
+
+// ServiceNameOrDefault returns the service.name of the spans, defaulting to DefaultServiceName.
+func (c TracingConfig) ServiceNameOrDefault() string {
+	if c.ServiceName == "" {
+		return DefaultServiceName
+	}
+	return c.ServiceName
+}
+
+// HealthConfig holds the liveness (/healthz) and readiness (/readyz) probes.
+type HealthConfig struct {
+	Timeout       string `mapstructure:"timeout"`        // Of each check, e.g. "2s"
+	CacheTTL      string `mapstructure:"cache_ttl"`      // How long check results are reused, e.g. "1s"
+	ShutdownDelay string `mapstructure:"shutdown_delay"` // Readiness fails this long before the server stops, e.g. "5s"
 }
 
 // LogConfig holds logging configuration.
@@ -89,8 +340,6 @@ func Load(path, name string) (*Config, error) {
 		// fmt.Println("Config file not found, relying on environment variables or defaults.")
 	}
 
-	fmt.Println("Viper config after ReadInConfig:", v.AllSettings()) // Log config after file read
-
 	// --- Environment Variable Loading ---
 	v.AutomaticEnv() // Read in environment variables that match
 	// Set a prefix to avoid collisions with other system env vars
@@ -100,8 +349,6 @@ func Load(path, name string) (*Config, error) {
 	// e.g., Database.Host becomes APP_DATABASE_HOST
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
-	fmt.Println("Viper config after AutomaticEnv:", v.AllSettings()) // Log config after env override
-
 	// --- Unmarshalling ---
 	var cfg Config
 	err = v.Unmarshal(&cfg)
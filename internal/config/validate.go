@@ -0,0 +1,203 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package config /youGo/internal/config/validate.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redactedValue replaces secrets in Redacted.
+const redactedValue = "[REDACTED]"
+
+// Validate checks the configuration for values the application can't start with.
+// All problems are reported at once, joined into a single error.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	checkDuration := func(key, value string, required bool) {
+		if value == "" {
+			check(!required, "%s is required", key)
+			return
+		}
+		d, err := time.ParseDuration(value)
+		check(err == nil && d > 0, "%s: invalid duration %q", key, value)
+	}
+	checkLimit := func(key, value string) { // A duration where "0" means no limit
+		if value != "" {
+			d, err := time.ParseDuration(value)
+			check(err == nil && d >= 0, "%s: invalid duration %q", key, value)
+		}
+	}
+
+	port, err := strconv.Atoi(c.Server.Port)
+	check(err == nil && port > 0 && port < 65536, "server.port: invalid port %q", c.Server.Port)
+	for _, cidr := range c.Server.TrustedProxies {
+		_, _, err := net.ParseCIDR(cidr)
+		check(err == nil, "server.trusted_proxies: invalid CIDR range %q", cidr)
+	}
+
+	switch c.Database.DriverName() {
+	case DriverPostgres:
+		check(c.Database.Host != "", "database.host is required")
+		check(c.Database.DBName != "", "database.dbname is required")
+		check(c.Database.User != "", "database.user is required")
+	case DriverSQLite:
+		check(c.Database.DBName != "", "database.dbname is required (the SQLite file, or \":memory:\")")
+		// The relay and the dispatcher claim rows with Postgres locks
+		check(!c.Outbox.Enabled, "outbox.enabled requires the postgres driver")
+		check(!c.Webhooks.Enabled, "webhooks.enabled requires the postgres driver")
+	default:
+		check(false, "database.driver: unknown driver %q", c.Database.Driver)
+	}
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns must not be negative")
+	check(c.Database.ConnectRetries >= 0, "database.connect_retries must not be negative")
+	checkLimit("database.conn_max_lifetime", c.Database.ConnMaxLifetime)
+	checkLimit("database.conn_max_idle_time", c.Database.ConnMaxIdleTime)
+	checkLimit("database.statement_timeout", c.Database.StatementTimeout)
+	checkLimit("database.slow_query_threshold", c.Database.SlowQueryThreshold)
+	checkDuration("database.connect_retry_backoff", c.Database.ConnectRetryBackoff, false)
+	if len(c.Database.Replicas) > 0 {
+		check(c.Database.DriverName() == DriverPostgres, "database.replicas requires the postgres driver")
+		for i, r := range c.Database.Replicas {
+			check(r.Host != "", "database.replicas[%d].host is required", i)
+		}
+		checkDuration("database.replica_check_interval", c.Database.ReplicaCheckInterval, false)
+	}
+
+	check(c.Auth.JWTSecret != "", "auth.jwt_secret is required")
+	checkDuration("auth.access_token_duration", c.Auth.AccessTokenDuration, true)
+	checkDuration("auth.refresh_token_duration", c.Auth.RefreshTokenDuration, true)
+
+	switch strings.ToLower(c.Log.Level) {
+	case "", "debug", "info", "warn", "error", "dpanic", "panic", "fatal":
+	default:
+		check(false, "log.level: unknown level %q", c.Log.Level)
+	}
+
+	if c.Outbox.Enabled {
+		checkDuration("outbox.poll_interval", c.Outbox.PollInterval, false)
+		check(c.Outbox.MaxAttempts >= 0, "outbox.max_attempts must not be negative")
+		for _, sink := range c.Outbox.Sinks {
+			switch strings.ToLower(strings.TrimSpace(sink)) {
+			case "inprocess", "broker", "webhook_subscriptions":
+			case "webhook":
+				check(c.Outbox.WebhookURL != "", "outbox.webhook_url is required by the \"webhook\" sink")
+			default:
+				check(false, "outbox.sinks: unknown sink %q", sink)
+			}
+		}
+	}
+	if c.Webhooks.Enabled {
+		checkDuration("webhooks.poll_interval", c.Webhooks.PollInterval, false)
+		checkDuration("webhooks.timeout", c.Webhooks.Timeout, false)
+		check(c.Webhooks.MaxAttempts >= 0, "webhooks.max_attempts must not be negative")
+	}
+
+	switch c.Idempotency.StoreName() {
+	case IdempotencyStoreDatabase, IdempotencyStoreMemory:
+	default:
+		check(false, "idempotency.store: unknown store %q", c.Idempotency.Store)
+	}
+	checkDuration("idempotency.ttl", c.Idempotency.TTL, false)
+	checkDuration("idempotency.lock_timeout", c.Idempotency.LockTimeout, false)
+	checkDuration("idempotency.cleanup_interval", c.Idempotency.CleanupInterval, false)
+
+	checkDuration("health.timeout", c.Health.Timeout, false)
+	checkDuration("health.cache_ttl", c.Health.CacheTTL, false)
+	checkDuration("health.shutdown_delay", c.Health.ShutdownDelay, false)
+
+	if c.Metrics.Enabled {
+		metricsPort, err := strconv.Atoi(c.Metrics.Port)
+		check(err == nil && metricsPort > 0 && metricsPort < 65536, "metrics.port: invalid port %q", c.Metrics.Port)
+		check(c.Metrics.Port != c.Server.Port, "metrics.port must differ from server.port")
+		check(strings.HasPrefix(c.Metrics.PathOrDefault(), "/"), "metrics.path must start with \"/\"")
+	}
+
+	if c.Tracing.Enabled {
+		switch c.Tracing.ExporterName() {
+		case TracingExporterStdout:
+		case TracingExporterOTLP:
+			check(c.Tracing.Endpoint != "", "tracing.endpoint is required by the otlp exporter")
+		default:
+			check(false, "tracing.exporter: unknown exporter %q", c.Tracing.Exporter)
+		}
+		check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	}
+
+	if c.Metering.Enabled {
+		m := c.Metering
+		check(m.PeriodName() == MeteringPeriodMonth || m.PeriodName() == MeteringPeriodDay,
+			"metering.period: unknown period %q", m.Period)
+		checkDuration("metering.flush_interval", m.FlushInterval, false)
+		meters := map[string]bool{"seats": true}
+		for i, class := range m.RouteClasses {
+			key := fmt.Sprintf("metering.route_classes[%d]", i)
+			check(class.Name != "" && !meters["api_calls:"+class.Name], "%s.name must be set and unique", key)
+			check(strings.HasPrefix(class.Prefix, "/"), "%s.prefix must start with \"/\"", key)
+			meters["api_calls:"+class.Name] = true
+		}
+		_, ok := m.Plans[m.DefaultPlan]
+		check(m.DefaultPlan == "" || ok, "metering.default_plan: unknown plan %q", m.DefaultPlan)
+		for name, plan := range m.Plans {
+			for meter, limit := range plan.Quotas {
+				check(meters[meter], "metering.plans.%s.quotas: unknown meter %q", name, meter)
+				check(limit >= 0, "metering.plans.%s.quotas.%s must not be negative", name, meter)
+			}
+		}
+		for subject, plan := range m.Subjects {
+			_, ok := m.Plans[plan]
+			check(ok, "metering.subjects.%s: unknown plan %q", subject, plan)
+		}
+		org, ok := strings.CutPrefix(m.SeatsSubject, "org:")
+		check(m.SeatsSubject == "" || ok && org != "", "metering.seats_subject must be \"org:<id>\", got %q", m.SeatsSubject)
+	}
+
+	if c.RateLimit.Enabled {
+		names := make(map[string]bool)
+		for i, p := range c.RateLimit.Policies {
+			key := fmt.Sprintf("rate_limit.policies[%d]", i)
+			check(p.Name != "" && !names[p.Name], "%s.name must be set and unique", key)
+			names[p.Name] = true
+			check(strings.HasPrefix(p.Prefix, "/"), "%s.prefix must start with \"/\"", key)
+			check(p.Requests > 0, "%s.requests must be positive", key)
+			checkDuration(key+".window", p.Window, true)
+			switch p.KeyName() {
+			case RateLimitByIP, RateLimitByUser, RateLimitByAPIKey:
+			default:
+				check(false, "%s.by: unknown key %q", key, p.By)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of the configuration with secrets masked, safe to print or log.
+func (c Config) Redacted() Config {
+	redact := func(s *string) {
+		if *s != "" {
+			*s = redactedValue
+		}
+	}
+	redact(&c.Database.Password)
+	c.Database.Replicas = slices.Clone(c.Database.Replicas)
+	for i := range c.Database.Replicas {
+		redact(&c.Database.Replicas[i].Password)
+	}
+	redact(&c.Auth.JWTSecret)
+	return c
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package domain /youGo/internal/domain/audit.go
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions recorded by the service layer.
+const (
+	AuditActionUserCreated        = "user.created"
+	AuditActionUserUpdated        = "user.updated"
+	AuditActionUserDeleted        = "user.deleted"
+	AuditActionAuthLoginSucceeded = "auth.login_succeeded"
+	AuditActionAuthLoginFailed    = "auth.login_failed"
+	AuditActionWebhookCreated     = "webhook_endpoint.created"
+	AuditActionWebhookUpdated     = "webhook_endpoint.updated"
+	AuditActionWebhookDeleted     = "webhook_endpoint.deleted"
+	AuditActionWebhookRedelivered = "webhook_delivery.redelivered"
+)
+
+// Audit target types.
+const (
+	AuditTargetUser  = "user"
+	AuditTargetEmail = "email" // Used for login attempts against an unknown email address
+
+	AuditTargetWebhookEndpoint = "webhook_endpoint"
+	AuditTargetWebhookDelivery = "webhook_delivery"
+)
+
+// AuditEvent is an immutable record of a state-changing operation.
+type AuditEvent struct {
+	ID         uuid.UUID
+	ActorID    *uuid.UUID // nil when no authenticated user performed the action
+	Action     string
+	TargetType string
+	TargetID   string
+	Changes    map[string]FieldChange // Before/after values of the fields that changed
+	RequestID  string
+	IP         string
+	OccurredAt time.Time
+}
+
+// FieldChange holds the before and after value of a single field.
+// Before is nil for creations, After is nil for deletions.
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditFilter narrows down audit event queries. Zero values are ignored.
+type AuditFilter struct {
+	ActorID    *uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time   // Inclusive
+	To         *time.Time   // Exclusive
+	After      *AuditCursor // Keyset paging: only the events listed after this one
+	Limit      int
+	Offset     int
+}
+
+// AuditCursor is the position of an event in the newest-first order of AuditRepository.List.
+// Unlike an offset, it doesn't skip or repeat events when several share a timestamp.
+type AuditCursor struct {
+	OccurredAt time.Time
+	ID         uuid.UUID
+}
+
+// AuditRepository persists audit events. It is append-only by design:
+// there are deliberately no update or delete operations.
+type AuditRepository interface {
+	Append(ctx context.Context, event *AuditEvent) error
+	List(ctx context.Context, filter AuditFilter) ([]*AuditEvent, int64, error)
+}
+
+// AuditRecorder is the hook services call to record a state-changing operation.
+// Implementations fill in the actor, request ID, IP and timestamp from ctx.
+type AuditRecorder interface {
+	Record(ctx context.Context, event *AuditEvent) error
+}
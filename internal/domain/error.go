@@ -6,6 +6,7 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -15,6 +16,8 @@ import (
 // Use errors.Is() to check for these.
 var ErrNotFound = fmt.Errorf("domain: entity not found")
 var ErrDuplicateEntry = fmt.Errorf("domain: duplicate entry")
+var ErrInvalidReference = fmt.Errorf("domain: referenced entity does not exist or is still referenced") // Foreign key violation
+var ErrConstraintViolation = fmt.Errorf("domain: value violates a constraint")                          // Check or NOT NULL violation
 var ErrPermissionDenied = fmt.Errorf("domain: permission denied")
 var ErrInsufficientStock = fmt.Errorf("domain: insufficient stock")                       // Example if needed later
 var ErrOptimisticLock = fmt.Errorf("domain: edit conflict, please refresh and try again") // Example for optimistic locking
@@ -25,16 +28,64 @@ var ErrOptimisticLock = fmt.Errorf("domain: edit conflict, please refresh and tr
 type InvalidArgumentError struct {
 	ArgumentName string
 	Reason       string
+	Code         string // Message code of the reason (one of the Reason constants), for translations
 }
 
+// Codes of the reasons of InvalidArgumentError.
+const (
+	ReasonUUID         = "argument.uuid"
+	ReasonTimestamp    = "argument.timestamp"
+	ReasonExportFormat = "argument.export_format"
+	ReasonSearchTerms  = "argument.search_terms"
+	ReasonUsageSubject = "argument.usage_subject"
+	ReasonPublicURL    = "argument.public_url"
+)
+
 // Error implements the error interface for InvalidArgumentError.
 func (e *InvalidArgumentError) Error() string {
 	return fmt.Sprintf("domain: invalid argument %q: %s", e.ArgumentName, e.Reason)
 }
 
+// ConstraintError reports a write the database rejected because of a constraint.
+// errors.Is matches it against its Kind: ErrDuplicateEntry, ErrInvalidReference or ErrConstraintViolation.
+type ConstraintError struct {
+	Kind       error  // One of the sentinels above
+	Constraint string // Name of the violated constraint, if known, e.g. "users_email_key"
+	Column     string // Column concerned, if known
+}
+
+// Error implements the error interface for ConstraintError.
+func (e *ConstraintError) Error() string {
+	if e.Constraint == "" {
+		return e.Kind.Error()
+	}
+	return fmt.Sprintf("%s (constraint %s)", e.Kind, e.Constraint)
+}
+
+// Unwrap exposes the Kind to errors.Is.
+func (e *ConstraintError) Unwrap() error {
+	return e.Kind
+}
+
+// IsConstraintError reports whether err is a write rejected by a database constraint, of any kind.
+func IsConstraintError(err error) bool {
+	var constraintErr *ConstraintError
+	return errors.As(err, &constraintErr)
+}
+
 // ValidationError holds details about multiple validation failures.
 type ValidationError struct {
-	Failures map[string][]string // Map of field name to list of validation error messages
+	Failures   map[string][]string // Map of field name to list of validation error messages
+	Violations []FieldViolation    // The failed rules, in order, when known (see AddViolation)
+}
+
+// FieldViolation is a validation rule a field failed, described well enough for the API to
+// translate its message.
+type FieldViolation struct {
+	Path    string            // JSON path of the field, e.g. "email" or "items[0].name"
+	Code    string            // Rule that failed, e.g. "required" or "min"
+	Params  map[string]string // Arguments of the rule, e.g. {"limit": "8"}
+	Message string            // Default (English) message
 }
 
 // NewValidationError creates a new ValidationError instance.
@@ -69,6 +120,12 @@ func (e *ValidationError) Add(field, message string) {
 	e.Failures[field] = append(e.Failures[field], message)
 }
 
+// AddViolation records a failed rule; its message is also added to Failures.
+func (e *ValidationError) AddViolation(v FieldViolation) {
+	e.Violations = append(e.Violations, v)
+	e.Add(v.Path, v.Message)
+}
+
 // HasErrors returns true if any validation failures have been recorded.
 func (e *ValidationError) HasErrors() bool {
 	return len(e.Failures) > 0
@@ -0,0 +1,122 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package domain /youGo/internal/domain/event.go
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain event types published to other services.
+const (
+	EventUserCreated  = "UserCreated"
+	EventUserUpdated  = "UserUpdated"
+	EventUserDeleted  = "UserDeleted"
+	EventUserLoggedIn = "UserLoggedIn"
+)
+
+// Aggregate types. Events are delivered in order per (aggregate type, aggregate ID).
+const (
+	AggregateUser = "user"
+)
+
+// DomainEvent is a fact about a change to an aggregate, stored in the outbox
+// and relayed to external sinks at least once.
+type DomainEvent struct {
+	ID            uuid.UUID // Unique per event; consumers use it to de-duplicate redeliveries
+	Sequence      int64     // Assigned by the outbox on insert; defines delivery order
+	AggregateType string
+	AggregateID   string
+	Type          string
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+	Attempts      int    // Number of failed delivery attempts so far
+	Owner         string // Subject the event belongs to, e.g. "user:<id>"; only its webhook endpoints receive it
+}
+
+// NewDomainEvent builds an event with a fresh ID, encoding payload as JSON.
+func NewDomainEvent(aggregateType, aggregateID, eventType string, payload interface{}) (*DomainEvent, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("generating event ID: %w", err)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s payload: %w", eventType, err)
+	}
+	return &DomainEvent{
+		ID:            id,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       data,
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
+
+// UserEventPayload is the public representation of a user carried by user events.
+// It never includes the password hash.
+type UserEventPayload struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	IsActive  bool      `json:"isActive"`
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// NewUserEvent builds a user event whose payload is a snapshot of user.
+func NewUserEvent(eventType string, user *User) (*DomainEvent, error) {
+	return NewUserEventFromPayload(eventType, UserEventPayload{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		IsActive:  user.IsActive,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	})
+}
+
+// NewUserEventFromPayload builds an event of the user payload.ID, owned by that user.
+// An application with organizations makes the user's organization the owner instead.
+func NewUserEventFromPayload(eventType string, payload UserEventPayload) (*DomainEvent, error) {
+	event, err := NewDomainEvent(AggregateUser, payload.ID.String(), eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+	event.Owner = UserSubject(payload.ID)
+	return event, nil
+}
+
+// OutboxHandler delivers a single event. Returning a nil error marks the event as published.
+// Returning an error reschedules the event for retryAt and holds back later events of the same aggregate.
+// An error with a zero retryAt gives up: the event goes dead, and holds them back until it is requeued.
+type OutboxHandler func(ctx context.Context, event *DomainEvent) (retryAt time.Time, err error)
+
+// OutboxRepository stores domain events until they have been relayed.
+type OutboxRepository interface {
+	// Append stores events. Inside TxManager.WithinTransaction they commit or roll back
+	// together with the change that produced them.
+	Append(ctx context.Context, events ...*DomainEvent) error
+	// ProcessPending hands up to limit due events, in order, to handle and records the outcome.
+	// The events are claimed for a while first, so that concurrent callers get other events,
+	// and never two events of the same aggregate at once. handle runs outside any transaction.
+	ProcessPending(ctx context.Context, limit int, handle OutboxHandler) (processed int, err error)
+	// Requeue makes the dead event with ID eventID due again, with its attempts reset. It returns
+	// ErrNotFound when there is no such dead event.
+	Requeue(ctx context.Context, eventID uuid.UUID) error
+}
+
+// EventPublisher delivers a domain event to a sink (in-process bus, webhook, message broker...).
+type EventPublisher interface {
+	Publish(ctx context.Context, event *DomainEvent) error
+}
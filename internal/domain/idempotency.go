@@ -0,0 +1,45 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package domain /youGo/internal/domain/idempotency.go
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord is a request sent with an Idempotency-Key: locked while it is being
+// handled, then holding its response, replayed to the retries of the request until it expires.
+type IdempotencyRecord struct {
+	Key         string              // Idempotency-Key of the client, scoped to the caller and route
+	Fingerprint string              // Hash of the request, which retries must repeat
+	Status      int                 // Status of the response; 0 while the request is in flight
+	Header      map[string][]string // Headers of the response worth replaying
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time // End of the lock while in flight, then of the replays
+}
+
+// Completed reports whether the record holds a response, rather than the lock of a request in flight.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.Status != 0
+}
+
+// IdempotencyStore keeps the IdempotencyRecords. Records are independent of transactions:
+// the lock of a request must be visible to its retries before the request commits.
+type IdempotencyStore interface {
+	// Acquire locks key for a request with the given fingerprint until lockedUntil. If the key
+	// has a record that hasn't expired, it is returned instead and nothing is locked; an
+	// expired record is replaced. It returns nil once the lock is taken.
+	Acquire(ctx context.Context, key, fingerprint string, lockedUntil time.Time) (*IdempotencyRecord, error)
+	// Complete stores the response of the request holding the lock of record.Key, to be
+	// replayed until record.ExpiresAt. It returns ErrNotFound if the lock expired meanwhile.
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+	// Release removes the lock of key without storing a response, so that the request may
+	// be retried. Completed records are kept.
+	Release(ctx context.Context, key string) error
+	// DeleteExpired removes the records expired by now and returns how many there were.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
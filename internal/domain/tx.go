@@ -0,0 +1,20 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package domain /youGo/internal/domain/tx.go
+package domain
+
+import "context"
+
+// TxManager runs a unit of work atomically across repositories.
+//
+// The transaction travels in the context handed to fn: every repository call made with
+// that context joins it, so services never see a transaction handle. Calling
+// WithinTransaction again with that context opens a savepoint, so a failing inner unit
+// only undoes its own writes. The outermost call may run fn more than once when the
+// database reports a serialization failure or deadlock, so fn must not have side effects
+// outside the database.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
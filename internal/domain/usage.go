@@ -0,0 +1,104 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package domain /youGo/internal/domain/usage.go
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Meters of billable usage. API calls are metered per route class, e.g. "api_calls:search"
+// (see APICallsMeter).
+const (
+	MeterAPICalls = "api_calls"
+	MeterSeats    = "seats"
+)
+
+// Kinds of subjects usage is metered for, e.g. "user:<id>" or "org:<id>".
+const (
+	SubjectUser         = "user"
+	SubjectOrganization = "org"
+)
+
+// LevelPeriod is the period of the counters of level meters (see IsLevelMeter): a level holds
+// until it is set again rather than restarting each period.
+var LevelPeriod = time.Unix(0, 0).UTC()
+
+// APICallsMeter returns the meter of the API calls of a route class.
+func APICallsMeter(class string) string {
+	return MeterAPICalls + ":" + class
+}
+
+// IsLevelMeter reports whether meter measures a level (seats), set to its current value,
+// rather than counting events (API calls) within a period.
+func IsLevelMeter(meter string) bool {
+	return meter == MeterSeats
+}
+
+// UserSubject returns the subject usage of a user is metered for.
+func UserSubject(id uuid.UUID) string {
+	return SubjectUser + ":" + id.String()
+}
+
+// OrganizationSubject returns the subject usage of an organization is metered for.
+func OrganizationSubject(id string) string {
+	return SubjectOrganization + ":" + id
+}
+
+// ValidSubject reports whether subject is a user or organization subject.
+func ValidSubject(subject string) bool {
+	kind, id, ok := strings.Cut(subject, ":")
+	switch {
+	case !ok || id == "":
+		return false
+	case kind == SubjectUser:
+		return uuid.Validate(id) == nil
+	default:
+		return kind == SubjectOrganization
+	}
+}
+
+// UsageCounter is the usage of a meter by a subject within a period.
+type UsageCounter struct {
+	Subject     string // e.g. "user:<id>"
+	Meter       string // e.g. "api_calls:standard"
+	PeriodStart time.Time
+	Value       int64
+	UpdatedAt   time.Time
+}
+
+// UsageRepository stores the usage counters.
+type UsageRepository interface {
+	// Add adds the Value of each counter to the stored one, created at 0 if missing.
+	Add(ctx context.Context, counters []UsageCounter) error
+	// Set replaces the value of a counter, e.g. of a level meter.
+	Set(ctx context.Context, counter UsageCounter) error
+	// List returns the counters of subject in the period starting at periodStart.
+	List(ctx context.Context, subject string, periodStart time.Time) ([]UsageCounter, error)
+}
+
+// QuotaExceededError is returned when a subject used up the quota of its plan for a meter.
+type QuotaExceededError struct {
+	Subject string
+	Meter   string
+	Plan    string
+	Limit   int64
+	Reset   time.Time // When the period ends, for meters counting events
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("domain: quota of %s exceeded for %s (plan %s, limit %d)", e.Meter, e.Subject, e.Plan, e.Limit)
+}
+
+// PaymentRequired reports whether only a bigger plan lifts the quota: levels don't go down by
+// waiting for the next period.
+func (e *QuotaExceededError) PaymentRequired() bool {
+	return IsLevelMeter(e.Meter)
+}
@@ -11,6 +11,12 @@ import (
 	"time"
 )
 
+// Roles recognised by the application.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
 // User represents the core user entity within the business domain.
 // Agnostic of database or API implementation details.
 type User struct {
@@ -20,6 +26,7 @@ type User struct {
 	PasswordHash string // The securely hashed password
 	IsActive     bool
 	Role         string // e.g., "admin", "customer"
+	Locale       string // Preferred language of messages, e.g. "id"; empty to follow Accept-Language
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -28,9 +35,14 @@ type User struct {
 // Implementations reside in the infrastructure/repository layer.
 type UserRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*User, error)
+	// FindByIDForUpdate is FindByID locking the user until the end of the transaction carried
+	// by ctx (see TxManager), for changes computed from its current state.
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	Create(ctx context.Context, user *User) error
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Count returns how many users there are, e.g. for the seats meter.
+	Count(ctx context.Context) (int64, error)
 	// List(ctx context.Context /*, filters, pagination */) ([]*User, error) // Optional
 }
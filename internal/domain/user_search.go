@@ -0,0 +1,35 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package domain /youGo/internal/domain/user_search.go
+package domain
+
+import (
+	"context"
+)
+
+// UserSearchQuery is a free-text search over the name and email of users.
+type UserSearchQuery struct {
+	Text   string
+	Limit  int
+	Offset int
+}
+
+// UserSearchHit is a user found by a search. Highlights are HTML-escaped, with the matched
+// terms wrapped in <mark> tags (see textsearch.Highlight).
+type UserSearchHit struct {
+	User           *User
+	Rank           float64 // Higher is more relevant; 0 when the backend doesn't rank
+	NameHighlight  string
+	EmailHighlight string
+}
+
+// UserSearcher finds users by partial or approximate name and email. The Postgres implementation
+// ranks full-text and trigram matches, so it tolerates typos; the fallback of other backends
+// only matches substrings.
+type UserSearcher interface {
+	// Search returns a page of hits, most relevant first, and the total number of hits.
+	// A query without any word (see textsearch.Terms) finds nothing.
+	Search(ctx context.Context, q UserSearchQuery) ([]UserSearchHit, int64, error)
+}
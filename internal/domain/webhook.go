@@ -0,0 +1,97 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package domain /youGo/internal/domain/webhook.go
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookAllEvents subscribes an endpoint to every event type.
+const WebhookAllEvents = "*"
+
+// Webhook delivery statuses.
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for its first attempt or a retry
+	WebhookDeliverySucceeded = "succeeded" // The endpoint answered 2xx
+	WebhookDeliveryDead      = "dead"      // Gave up after the maximum number of attempts
+)
+
+// WebhookEndpoint is a customer URL subscribed to a set of domain event types.
+// It belongs to an owner, which manages it and whose events it receives.
+type WebhookEndpoint struct {
+	ID          uuid.UUID
+	Owner       string // Subject owning the endpoint, e.g. "user:<id>" or "org:<id>"
+	URL         string
+	Secret      string // Shared HMAC secret used to sign deliveries
+	EventTypes  []string
+	Description string
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Subscribes reports whether the endpoint wants events of eventType.
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	for _, t := range e.EventTypes {
+		if t == eventType || t == WebhookAllEvents {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for, or delivered to, one endpoint. It doubles as the delivery log.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	Owner          string // Owner of the endpoint
+	EndpointID     uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        json.RawMessage // Exact body sent to the endpoint
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode int
+	LastResponse   string // Truncated body of the last response
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookDeliveryFilter narrows down delivery log queries. Zero values are ignored, except
+// for Owner: deliveries are always listed for one owner.
+type WebhookDeliveryFilter struct {
+	Owner      string
+	EndpointID *uuid.UUID
+	Status     string
+	Limit      int
+	Offset     int
+}
+
+// WebhookRepository persists webhook endpoints and their deliveries. Lookups are scoped to an
+// owner: an endpoint or delivery of another owner is reported as ErrNotFound.
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	FindEndpointByID(ctx context.Context, owner string, id uuid.UUID) (*WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, owner string) ([]*WebhookEndpoint, error)
+	// ListActiveEndpointsFor returns active endpoints of owner subscribed to eventType (directly or via "*").
+	ListActiveEndpointsFor(ctx context.Context, owner, eventType string) ([]*WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, owner string, id uuid.UUID) error
+
+	CreateDeliveries(ctx context.Context, deliveries ...*WebhookDelivery) error
+	FindDeliveryByID(ctx context.Context, owner string, id uuid.UUID) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*WebhookDelivery, int64, error)
+	// ClaimDueDeliveries leases up to limit due pending deliveries for lease, so that other
+	// workers skip them. A delivery whose worker dies becomes due again once the lease expires.
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+}
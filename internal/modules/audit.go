@@ -0,0 +1,65 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/audit.go
+package modules
+
+import (
+	"io/fs"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/domain"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/service"
+	"youGo/migrations"
+)
+
+// auditModule owns the audit trail. Its service is the domain.AuditRecorder other modules record to.
+type auditModule struct{}
+
+// Audit returns the audit trail module.
+func Audit() app.Module { return auditModule{} }
+
+func (auditModule) Name() string { return "audit" }
+
+func (auditModule) Migrations() fs.FS { return migrations.Audit }
+
+func (auditModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (domain.AuditRepository, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		return repoImpl.NewAuditRepository(k.db), nil
+	})
+	app.Provide(c, func(c *app.Container) (service.AuditService, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := app.Resolve[domain.AuditRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewAuditService(repo, k.logger), nil
+	})
+	app.Provide(c, func(c *app.Container) (domain.AuditRecorder, error) {
+		return app.Resolve[service.AuditService](c)
+	})
+}
+
+func (auditModule) RegisterRoutes(c *app.Container, r *router.Routes) error {
+	auditSvc, err := app.Resolve[service.AuditService](c)
+	if err != nil {
+		return err
+	}
+	auditHandler := handler.NewAuditHandler(auditSvc)
+
+	r.Logger.Debug("Setting up /admin/audit-events routes")
+	r.Admin.GET("/audit-events", auditHandler.ListAuditEvents)
+	r.Admin.GET("/audit-events/export", auditHandler.ExportAuditEvents)
+	return nil
+}
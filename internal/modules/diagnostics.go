@@ -0,0 +1,42 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/diagnostics.go
+package modules
+
+import (
+	"youGo/internal/api/handler"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/platform/database"
+)
+
+// diagnosticsModule serves the admin diagnostics. It reports the *database.ReplicaSet the
+// caller supplies, if any.
+type diagnosticsModule struct{}
+
+// Diagnostics returns the admin diagnostics module.
+func Diagnostics() app.Module { return diagnosticsModule{} }
+
+func (diagnosticsModule) Name() string { return "diagnostics" }
+
+func (diagnosticsModule) Provide(*app.Container) {}
+
+func (diagnosticsModule) RegisterRoutes(c *app.Container, r *router.Routes) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	var replicas *database.ReplicaSet
+	if app.Has[*database.ReplicaSet](c) {
+		if replicas, err = app.Resolve[*database.ReplicaSet](c); err != nil {
+			return err
+		}
+	}
+	diagnosticsHandler := handler.NewDiagnosticsHandler(k.db, database.SettingsFor(k.cfg.Database), replicas)
+
+	r.Logger.Debug("Setting up /admin/diagnostics routes")
+	r.Admin.GET("/diagnostics/database", diagnosticsHandler.GetDatabaseDiagnostics)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/health.go
+package modules
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/platform/health"
+)
+
+// healthModule provides the *health.Registry of the checks behind the probes, with a ping of
+// the database, and serves /healthz and /readyz. Other modules register the checks of their
+// dependencies (cache, broker...) on the registry from their RegisterHooks.
+type healthModule struct{}
+
+// Health returns the liveness and readiness probes module.
+func Health() app.Module { return healthModule{} }
+
+func (healthModule) Name() string { return "health" }
+
+func (healthModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (*health.Registry, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		timeout, _ := time.ParseDuration(k.cfg.Health.Timeout) // Checked by Config.Validate; empty means the default
+		cacheTTL, _ := time.ParseDuration(k.cfg.Health.CacheTTL)
+		registry := health.NewRegistry(health.Options{Timeout: timeout, CacheTTL: cacheTTL, Logger: k.logger.Named("health")})
+		// The pool is looked up when checked, so that wiring doesn't need the database
+		registry.Register("database", func(ctx context.Context) error {
+			sqlDB, err := k.db.DB()
+			if err != nil {
+				return fmt.Errorf("getting the connection pool: %w", err)
+			}
+			return health.PingDB(sqlDB)(ctx)
+		})
+		return registry, nil
+	})
+}
+
+func (healthModule) RegisterRoutes(c *app.Container, r *router.Routes) error {
+	registry, err := app.Resolve[*health.Registry](c)
+	if err != nil {
+		return err
+	}
+	healthHandler := handler.NewHealthHandler(registry)
+
+	r.Logger.Debug("Setting up /healthz and /readyz probes")
+	r.Root.GET("/healthz", healthHandler.Liveness)
+	r.Root.GET("/readyz", healthHandler.Readiness)
+	return nil
+}
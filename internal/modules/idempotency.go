@@ -0,0 +1,97 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/idempotency.go
+package modules
+
+import (
+	"context"
+	"io/fs"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/repository/memory"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/migrations"
+)
+
+// defaultIdempotencyCleanupInterval is how often expired idempotency keys are deleted by default.
+const defaultIdempotencyCleanupInterval = time.Hour
+
+// idempotencyModule owns the idempotency keys. It provides the router.Idempotency middleware.
+type idempotencyModule struct{}
+
+// Idempotency returns the module replaying the responses of requests retried with an Idempotency-Key.
+func Idempotency() app.Module { return idempotencyModule{} }
+
+func (idempotencyModule) Name() string { return "idempotency" }
+
+func (idempotencyModule) Migrations() fs.FS { return migrations.Idempotency }
+
+func (idempotencyModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (domain.IdempotencyStore, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		if k.cfg.Idempotency.StoreName() == config.IdempotencyStoreMemory {
+			return memory.NewIdempotencyStore(), nil
+		}
+		return repoImpl.NewIdempotencyStore(k.db), nil
+	})
+	app.Provide(c, func(c *app.Container) (router.Idempotency, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		store, err := app.Resolve[domain.IdempotencyStore](c)
+		if err != nil {
+			return nil, err
+		}
+		// Checked by Config.Validate; empty values mean the defaults
+		ttl, _ := time.ParseDuration(k.cfg.Idempotency.TTL)
+		lockTimeout, _ := time.ParseDuration(k.cfg.Idempotency.LockTimeout)
+		opts := middleware.IdempotencyOptions{TTL: ttl, LockTimeout: lockTimeout}
+		return router.Idempotency(middleware.Idempotency(store, opts, k.logger)), nil
+	})
+}
+
+func (idempotencyModule) RegisterHooks(c *app.Container, lc *app.Lifecycle) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	store, err := app.Resolve[domain.IdempotencyStore](c)
+	if err != nil {
+		return err
+	}
+	interval, _ := time.ParseDuration(k.cfg.Idempotency.CleanupInterval) // Checked by Config.Validate
+	if interval <= 0 {
+		interval = defaultIdempotencyCleanupInterval
+	}
+	lc.Go("idempotency key cleanup", func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				deleted, err := store.DeleteExpired(ctx, now)
+				if err != nil {
+					k.logger.Error("Failed to delete expired idempotency keys", zap.Error(err))
+					continue
+				}
+				k.logger.Debug("Deleted expired idempotency keys", zap.Int64("deleted", deleted))
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/metering.go
+package modules
+
+import (
+	"io/fs"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/auth"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/service"
+	"youGo/migrations"
+)
+
+// meteringModule owns the usage counters. It provides the router.Metering middleware and serves
+// the usage reports.
+type meteringModule struct{}
+
+// Metering returns the module metering billable usage and enforcing the quotas of plans.
+func Metering() app.Module { return meteringModule{} }
+
+func (meteringModule) Name() string { return "metering" }
+
+func (meteringModule) Migrations() fs.FS { return migrations.Metering }
+
+func (meteringModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (domain.UsageRepository, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		return repoImpl.NewUsageRepository(k.db), nil
+	})
+	app.Provide(c, func(c *app.Container) (service.MeteringService, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := app.Resolve[domain.UsageRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		cfg := k.cfg.Metering
+		flushInterval, _ := time.ParseDuration(cfg.FlushInterval) // Checked by Config.Validate
+		plans := make(map[string]service.Plan, len(cfg.Plans))
+		for name, plan := range cfg.Plans {
+			plans[name] = service.Plan{Name: name, Quotas: plan.Quotas}
+		}
+		return service.NewMeteringService(repo, service.MeteringOptions{
+			Daily:         cfg.PeriodName() == config.MeteringPeriodDay,
+			FlushInterval: flushInterval,
+			DefaultPlan:   cfg.DefaultPlan,
+			Plans:         plans,
+			Subjects:      cfg.Subjects,
+		}, k.logger), nil
+	})
+	app.Provide(c, func(c *app.Container) (router.Metering, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		if !k.cfg.Metering.Enabled {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }, nil
+		}
+		svc, err := app.Resolve[service.MeteringService](c)
+		if err != nil {
+			return nil, err
+		}
+		authSvc, err := app.Resolve[auth.Service](c)
+		if err != nil {
+			return nil, err
+		}
+		// Requests are billed to their user; an application with organizations adds theirs here
+		users := middleware.BearerUser(authSvc)
+		subjects := func(r *http.Request) []string {
+			if userID, ok := users(r); ok {
+				return []string{domain.UserSubject(userID)}
+			}
+			return nil
+		}
+		return router.Metering(middleware.Metering(svc, k.cfg.Metering.RouteClasses, subjects, k.logger)), nil
+	})
+}
+
+func (meteringModule) RegisterRoutes(c *app.Container, r *router.Routes) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	if !k.cfg.Metering.Enabled {
+		return nil
+	}
+	svc, err := app.Resolve[service.MeteringService](c)
+	if err != nil {
+		return err
+	}
+	usageHandler := handler.NewUsageHandler(svc)
+
+	r.Logger.Debug("Setting up /usage and /admin/usage routes")
+	r.API.GET("/usage", usageHandler.GetMyUsage, r.Guards.Auth)
+	r.Admin.GET("/usage/:subject", usageHandler.GetUsage)
+	return nil
+}
+
+func (meteringModule) RegisterHooks(c *app.Container, lc *app.Lifecycle) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	if !k.cfg.Metering.Enabled {
+		return nil
+	}
+	svc, err := app.Resolve[service.MeteringService](c)
+	if err != nil {
+		return err
+	}
+	lc.Go("usage flusher", svc.Run)
+	// Stopped before the flusher, once the HTTP server no longer records usage
+	lc.Append(app.Hook{Name: "usage final flush", OnStop: svc.Flush})
+	return nil
+}
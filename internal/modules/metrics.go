@@ -0,0 +1,118 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/metrics.go
+package modules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/platform/buildinfo"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/metrics"
+)
+
+// metricsModule provides the Prometheus collectors and the router.Metrics middleware, and
+// serves the metrics and the build details (/version) on the admin port of the configuration,
+// apart from the API.
+type metricsModule struct{}
+
+// Metrics returns the module exporting Prometheus metrics.
+func Metrics() app.Module { return metricsModule{} }
+
+func (metricsModule) Name() string { return "metrics" }
+
+func (metricsModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (*metrics.Metrics, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		m := metrics.New()
+		sqlDB, err := k.db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("getting the connection pool: %w", err)
+		}
+		if err := m.RegisterDB(sqlDB, "primary"); err != nil {
+			return nil, fmt.Errorf("registering the connection pool metrics: %w", err)
+		}
+		if app.Has[*database.ReplicaSet](c) {
+			replicas, err := app.Resolve[*database.ReplicaSet](c)
+			if err != nil {
+				return nil, err
+			}
+			for i, pool := range replicas.Pools() {
+				if err := m.RegisterDB(pool, fmt.Sprintf("replica-%d", i+1)); err != nil {
+					return nil, fmt.Errorf("registering the connection pool metrics of replica %d: %w", i+1, err)
+				}
+			}
+		}
+		return m, nil
+	})
+	app.Provide(c, func(c *app.Container) (router.Metrics, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		if !k.cfg.Metrics.Enabled {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }, nil
+		}
+		m, err := app.Resolve[*metrics.Metrics](c)
+		if err != nil {
+			return nil, err
+		}
+		return router.Metrics(middleware.Metrics(m)), nil
+	})
+}
+
+func (metricsModule) RegisterHooks(c *app.Container, lc *app.Lifecycle) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	if !k.cfg.Metrics.Enabled {
+		return nil
+	}
+	m, err := app.Resolve[*metrics.Metrics](c)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(k.cfg.Metrics.PathOrDefault(), m.Handler())
+	mux.Handle("/version", buildinfo.Handler())
+	addr := fmt.Sprintf(":%s", k.cfg.Metrics.Port)
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	lc.Append(app.Hook{
+		Name: "metrics server",
+		OnStart: func(context.Context) error {
+			// Listen synchronously so that a port already in use fails the start
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+			k.logger.Info("📈 Serving metrics", zap.String("address", addr), zap.String("path", k.cfg.Metrics.PathOrDefault()))
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					k.logger.Error("Metrics server failed", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		// Stopped after the HTTP server, so that the last requests are scraped if possible
+		OnStop: server.Shutdown,
+	})
+	return nil
+}
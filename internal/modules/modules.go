@@ -0,0 +1,64 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/modules.go
+// Package modules holds the features the application is assembled from (see internal/app).
+// Modules expect the container to supply *config.Config, *zap.Logger, *gorm.DB and domain.TxManager.
+package modules
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/app"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+)
+
+// Default returns the modules of the application, in the order their hooks start.
+// To add a feature, write a module and append it here (generate resource does so for its modules).
+func Default() []app.Module {
+	return []app.Module{
+		Users(),
+		Audit(),
+		Outbox(),
+		Webhooks(),
+		Idempotency(),
+		RateLimit(),
+		Metering(),
+		Diagnostics(),
+		Metrics(),
+		Health(),
+		// generate resource: new modules are added above this line
+	}
+}
+
+// core holds the shared values supplied by the caller.
+type core struct {
+	cfg       *config.Config
+	logger    *zap.Logger
+	db        *gorm.DB
+	txManager domain.TxManager
+}
+
+// resolveCore resolves the shared values every module depends on.
+func resolveCore(c *app.Container) (core, error) {
+	var (
+		k   core
+		err error
+	)
+	if k.cfg, err = app.Resolve[*config.Config](c); err != nil {
+		return k, err
+	}
+	if k.logger, err = app.Resolve[*zap.Logger](c); err != nil {
+		return k, err
+	}
+	if k.db, err = app.Resolve[*gorm.DB](c); err != nil {
+		return k, err
+	}
+	if k.txManager, err = app.Resolve[domain.TxManager](c); err != nil {
+		return k, err
+	}
+	return k, nil
+}
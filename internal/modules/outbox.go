@@ -0,0 +1,109 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/outbox.go
+package modules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youGo/internal/app"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/eventbus"
+	"youGo/internal/platform/httpclient"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/service"
+	"youGo/migrations"
+)
+
+// outboxModule owns the transactional outbox and the relay publishing it to the configured sinks.
+type outboxModule struct{}
+
+// Outbox returns the transactional outbox module.
+func Outbox() app.Module { return outboxModule{} }
+
+func (outboxModule) Name() string { return "outbox" }
+
+func (outboxModule) Migrations() fs.FS { return migrations.Outbox }
+
+func (outboxModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (domain.OutboxRepository, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		return repoImpl.NewOutboxRepository(k.db), nil
+	})
+}
+
+func (outboxModule) RegisterHooks(c *app.Container, lc *app.Lifecycle) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	if !k.cfg.Outbox.Enabled {
+		return nil
+	}
+	outbox, err := app.Resolve[domain.OutboxRepository](c)
+	if err != nil {
+		return err
+	}
+	publisher, err := newEventPublisher(c, k.cfg.Outbox, k.logger)
+	if err != nil {
+		return fmt.Errorf("invalid outbox configuration: %w", err)
+	}
+	pollInterval, _ := time.ParseDuration(k.cfg.Outbox.PollInterval) // Checked by Config.Validate; empty means the default
+	relay := service.NewOutboxRelay(outbox, publisher, pollInterval, k.cfg.Outbox.BatchSize, k.cfg.Outbox.MaxAttempts, k.logger)
+	lc.Go("outbox relay", relay.Run)
+	return nil
+}
+
+// newEventPublisher builds the sink(s) the outbox relay publishes to from the configured sink names.
+// The "webhook_subscriptions" sink fans events out to the endpoints their owners registered via /webhooks,
+// and needs the webhooks module.
+func newEventPublisher(c *app.Container, cfg config.OutboxConfig, log *zap.Logger) (domain.EventPublisher, error) {
+	sinks := cfg.Sinks
+	if len(sinks) == 0 {
+		sinks = []string{"inprocess"}
+	}
+
+	var fanout eventbus.Fanout
+	for _, sink := range sinks {
+		switch strings.ToLower(strings.TrimSpace(sink)) {
+		case "inprocess":
+			bus := eventbus.NewBus()
+			bus.Subscribe(eventbus.AllEvents, func(_ context.Context, event *domain.DomainEvent) error {
+				log.Debug("Domain event", zap.String("type", event.Type), zap.String("aggregateID", event.AggregateID))
+				return nil
+			})
+			fanout = append(fanout, bus)
+		case "webhook":
+			if cfg.WebhookURL == "" {
+				return nil, errors.New("outbox sink \"webhook\" requires outbox.webhook_url")
+			}
+			fanout = append(fanout, eventbus.NewWebhookPublisher(cfg.WebhookURL, httpclient.NewHTTPClient(httpclient.DefaultTimeout)))
+		case "webhook_subscriptions":
+			webhookSvc, err := app.Resolve[service.WebhookService](c)
+			if err != nil {
+				return nil, fmt.Errorf("outbox sink \"webhook_subscriptions\": %w", err)
+			}
+			fanout = append(fanout, webhookSvc)
+		case "broker":
+			// Only the local stand-in ships with the skeleton; swap in a NATS/Kafka BrokerClient adapter here.
+			log.Warn("Outbox broker sink is using the in-memory LocalBroker")
+			fanout = append(fanout, eventbus.NewBrokerPublisher(eventbus.NewLocalBroker(), cfg.BrokerSubjectPrefix))
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q", sink)
+		}
+	}
+	return fanout, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/ratelimit.go
+package modules
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/auth"
+	"youGo/internal/platform/ratelimit"
+)
+
+// rateLimitModule provides the router.RateLimit middleware applying the policies of the
+// configuration. Counters are kept in memory, per instance; to share them between instances,
+// replace the ratelimit.Store from a module of your own, e.g. with app.Replace and
+// ratelimit.NewRedisStore.
+type rateLimitModule struct{}
+
+// RateLimit returns the module limiting the request rate of clients.
+func RateLimit() app.Module { return rateLimitModule{} }
+
+func (rateLimitModule) Name() string { return "ratelimit" }
+
+func (rateLimitModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (ratelimit.Store, error) {
+		return ratelimit.NewMemoryStore(), nil
+	})
+	app.Provide(c, func(c *app.Container) (router.RateLimit, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		if !k.cfg.RateLimit.Enabled {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }, nil
+		}
+		store, err := app.Resolve[ratelimit.Store](c)
+		if err != nil {
+			return nil, err
+		}
+		// Policies by user need to tell who makes a request before JWTAuth runs; without the
+		// users module, they limit by IP
+		var users func(r *http.Request) (uuid.UUID, bool)
+		if authSvc, err := app.Resolve[auth.Service](c); err == nil {
+			users = middleware.BearerUser(authSvc)
+		}
+		limiter := ratelimit.NewLimiter(store)
+		rules := middleware.RateLimitRules(k.cfg.RateLimit)
+		// No API keys are issued: policies by API key limit by IP
+		return router.RateLimit(middleware.RateLimit(limiter, rules, users, nil, k.logger)), nil
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/users.go
+package modules
+
+import (
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/auth"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/metrics"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+	"youGo/internal/service"
+	"youGo/migrations"
+)
+
+// usersModule owns user accounts and authentication. It provides the route guards.
+type usersModule struct{}
+
+// Users returns the users and authentication module.
+func Users() app.Module { return usersModule{} }
+
+func (usersModule) Name() string { return "users" }
+
+func (usersModule) Migrations() fs.FS { return migrations.Users }
+
+func (usersModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (domain.UserRepository, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		if k.cfg.Database.DriverName() == config.DriverSQLite {
+			return sqlite.NewUserRepository(k.db), nil
+		}
+		return repoImpl.NewUserRepository(k.db), nil
+	})
+	app.Provide(c, func(c *app.Container) (domain.UserSearcher, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		if k.cfg.Database.DriverName() == config.DriverSQLite {
+			return sqlite.NewUserSearchRepository(k.db), nil
+		}
+		return repoImpl.NewUserSearchRepository(k.db), nil
+	})
+	app.Provide(c, func(c *app.Container) (service.UserSearchService, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		searcher, err := app.Resolve[domain.UserSearcher](c)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewUserSearchService(searcher, k.logger), nil
+	})
+	app.Provide(c, func(c *app.Container) (service.UserService, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := app.Resolve[domain.UserRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		outbox, err := app.Resolve[domain.OutboxRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		auditor, err := app.Resolve[domain.AuditRecorder](c)
+		if err != nil {
+			return nil, err
+		}
+		userSvc := service.NewUserService(repo, outbox, auditor, k.txManager, k.logger)
+		m := k.cfg.Metering
+		if !m.Enabled || m.SeatsSubject == "" || !app.Has[service.MeteringService](c) {
+			return userSvc, nil
+		}
+		metering, err := app.Resolve[service.MeteringService](c)
+		if err != nil {
+			return nil, err
+		}
+		return service.MeterSeats(userSvc, repo, metering, m.SeatsSubject, k.logger), nil
+	})
+	app.Provide(c, func(c *app.Container) (auth.Service, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := app.Resolve[domain.UserRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		outbox, err := app.Resolve[domain.OutboxRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		auditor, err := app.Resolve[domain.AuditRecorder](c)
+		if err != nil {
+			return nil, err
+		}
+		accessDuration, err := time.ParseDuration(k.cfg.Auth.AccessTokenDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access token duration %q: %w", k.cfg.Auth.AccessTokenDuration, err)
+		}
+		refreshDuration, err := time.ParseDuration(k.cfg.Auth.RefreshTokenDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid refresh token duration %q: %w", k.cfg.Auth.RefreshTokenDuration, err)
+		}
+		return auth.NewAuthService(repo, auditor, outbox, []byte(k.cfg.Auth.JWTSecret), accessDuration, refreshDuration), nil
+	})
+	app.Provide(c, func(c *app.Container) (router.Guards, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return router.Guards{}, err
+		}
+		authSvc, err := observedAuth(c)
+		if err != nil {
+			return router.Guards{}, err
+		}
+		userSvc, err := app.Resolve[service.UserService](c)
+		if err != nil {
+			return router.Guards{}, err
+		}
+		return router.Guards{
+			Auth:  middleware.JWTAuth(authSvc, k.logger),
+			Admin: middleware.RequireRole(userSvc, k.logger, domain.RoleAdmin),
+		}, nil
+	})
+}
+
+// observedAuth returns the auth.Service counting logins and token validation failures in the
+// metrics, when they are enabled. Only the login route and the Auth guard use it: the
+// middleware peeking at the bearer token of every request (rate limit, metering) would count
+// each rejected token several times.
+func observedAuth(c *app.Container) (auth.Service, error) {
+	k, err := resolveCore(c)
+	if err != nil {
+		return nil, err
+	}
+	authSvc, err := app.Resolve[auth.Service](c)
+	if err != nil {
+		return nil, err
+	}
+	if !k.cfg.Metrics.Enabled || !app.Has[*metrics.Metrics](c) {
+		return authSvc, nil
+	}
+	m, err := app.Resolve[*metrics.Metrics](c)
+	if err != nil {
+		return nil, err
+	}
+	return auth.Observe(authSvc, m), nil
+}
+
+func (usersModule) RegisterRoutes(c *app.Container, r *router.Routes) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	authSvc, err := observedAuth(c)
+	if err != nil {
+		return err
+	}
+	userSvc, err := app.Resolve[service.UserService](c)
+	if err != nil {
+		return err
+	}
+	searchSvc, err := app.Resolve[service.UserSearchService](c)
+	if err != nil {
+		return err
+	}
+	idempotent, err := app.Resolve[router.Idempotency](c)
+	if err != nil {
+		return err
+	}
+	authHandler := handler.NewAuthHandler(authSvc, userSvc, k.logger)
+	userHandler := handler.NewUserHandler(userSvc, k.txManager)
+	searchHandler := handler.NewUserSearchHandler(searchSvc)
+
+	// --- Authentication Routes (Public) ---
+	// Retried signups carrying the same Idempotency-Key get the first response again.
+	authGroup := r.API.Group("/auth")
+	r.Logger.Debug("Setting up /auth routes")
+	authGroup.POST("/login", authHandler.Login)
+	authGroup.POST("/signup", authHandler.Register, echo.MiddlewareFunc(idempotent))
+
+	// --- User Resource Routes (Protected) ---
+	// PATCH accepts application/merge-patch+json and application/json-patch+json.
+	// Which paths may be patched is decided per role inside the handler.
+	usersGroup := r.API.Group("/users", r.Guards.Auth)
+	r.Logger.Debug("Setting up protected /users/:id routes")
+	usersGroup.PATCH("/:id", userHandler.PatchUser)
+
+	// --- User Administration and Search (Admin) ---
+	r.Logger.Debug("Setting up /admin/users routes")
+	r.Admin.POST("/users", userHandler.CreateUser, echo.MiddlewareFunc(idempotent))
+	r.Admin.GET("/users/search", searchHandler.SearchUsers)
+	return nil
+}
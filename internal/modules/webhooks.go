@@ -0,0 +1,102 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package modules /youGo/internal/modules/webhooks.go
+package modules
+
+import (
+	"io/fs"
+	"time"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/router"
+	"youGo/internal/app"
+	"youGo/internal/domain"
+	"youGo/internal/platform/httpclient"
+	"youGo/internal/platform/webhook"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/service"
+	"youGo/migrations"
+)
+
+// webhooksModule owns webhook subscriptions and the dispatcher delivering to them.
+type webhooksModule struct{}
+
+// Webhooks returns the webhook subscriptions module.
+func Webhooks() app.Module { return webhooksModule{} }
+
+func (webhooksModule) Name() string { return "webhooks" }
+
+func (webhooksModule) Migrations() fs.FS { return migrations.Webhooks }
+
+func (webhooksModule) Provide(c *app.Container) {
+	app.Provide(c, func(c *app.Container) (domain.WebhookRepository, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		return repoImpl.NewWebhookRepository(k.db), nil
+	})
+	app.Provide(c, func(c *app.Container) (service.WebhookService, error) {
+		k, err := resolveCore(c)
+		if err != nil {
+			return nil, err
+		}
+		repo, err := app.Resolve[domain.WebhookRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		auditor, err := app.Resolve[domain.AuditRecorder](c)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewWebhookService(repo, auditor, k.txManager, httpclient.CheckPublicURL, k.logger), nil
+	})
+}
+
+func (webhooksModule) RegisterRoutes(c *app.Container, r *router.Routes) error {
+	webhookSvc, err := app.Resolve[service.WebhookService](c)
+	if err != nil {
+		return err
+	}
+	userSvc, err := app.Resolve[service.UserService](c)
+	if err != nil {
+		return err
+	}
+	webhookHandler := handler.NewWebhookHandler(webhookSvc, userSvc)
+
+	// Every user manages the endpoints receiving their own events (see handler.webhookOwner)
+	webhooksGroup := r.API.Group("/webhooks", r.Guards.Auth)
+	r.Logger.Debug("Setting up protected /webhooks routes")
+	webhooksGroup.POST("", webhookHandler.CreateEndpoint)
+	webhooksGroup.GET("", webhookHandler.ListEndpoints)
+	webhooksGroup.GET("/:id", webhookHandler.GetEndpoint)
+	webhooksGroup.PUT("/:id", webhookHandler.UpdateEndpoint)
+	webhooksGroup.DELETE("/:id", webhookHandler.DeleteEndpoint)
+	webhooksGroup.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+	webhooksGroup.POST("/deliveries/:deliveryId/redeliver", webhookHandler.Redeliver)
+	return nil
+}
+
+func (webhooksModule) RegisterHooks(c *app.Container, lc *app.Lifecycle) error {
+	k, err := resolveCore(c)
+	if err != nil {
+		return err
+	}
+	if !k.cfg.Webhooks.Enabled {
+		return nil
+	}
+	repo, err := app.Resolve[domain.WebhookRepository](c)
+	if err != nil {
+		return err
+	}
+	// Checked by Config.Validate; empty values mean the defaults
+	pollInterval, _ := time.ParseDuration(k.cfg.Webhooks.PollInterval)
+	timeout, _ := time.ParseDuration(k.cfg.Webhooks.Timeout)
+	// Endpoints are chosen by users: connect to public addresses only, without following redirects
+	sender := webhook.NewSender(httpclient.NewPublicHTTPClient(timeout))
+	dispatcher := service.NewWebhookDispatcher(repo, sender, k.cfg.Webhooks.MaxAttempts, pollInterval, k.cfg.Webhooks.BatchSize, k.logger)
+	lc.Go("webhook dispatcher", dispatcher.Run)
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package buildinfo /youGo/internal/platform/buildinfo/buildinfo.go
+// Package buildinfo describes the running binary: the version, commit and date injected at
+// link time by scripts/build.sh and the Dockerfile, completed with what the Go toolchain
+// records itself (debug.ReadBuildInfo): Go version, VCS revision, dirty flag and modules.
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// Set at link time, e.g.
+//
+//	go build -ldflags "-X youGo/internal/platform/buildinfo.Version=1.2.0 \
+//	  -X youGo/internal/platform/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X youGo/internal/platform/buildinfo.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Commit and Date default to the revision and commit time recorded by the toolchain.
+var (
+	Version = DevVersion
+	Commit  string
+	Date    string
+)
+
+// DevVersion is the version of binaries built without -ldflags, e.g. by go run.
+const DevVersion = "dev"
+
+// Module is a module the binary was built from.
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+	Replace string `json:"replace,omitempty"` // Path of the replacement, if any
+}
+
+// Info describes the running binary.
+type Info struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit,omitempty"`
+	Date      string   `json:"build_date,omitempty"`
+	Dirty     bool     `json:"dirty"` // Built from a working tree with uncommitted changes
+	GoVersion string   `json:"go_version"`
+	Platform  string   `json:"platform"` // GOOS/GOARCH
+	Module    string   `json:"module,omitempty"`
+	Deps      []Module `json:"dependencies,omitempty"`
+}
+
+// Summary is the part of Info served publicly. The dependencies, Go version and platform
+// are left out: they tell which known vulnerabilities the binary may have.
+type Summary struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the description of the running binary, read once.
+func Get() Info {
+	once.Do(func() {
+		bi, _ := debug.ReadBuildInfo()
+		info = Read(bi)
+	})
+	return info
+}
+
+// Read combines the variables set at link time with bi, which may be nil (binaries built
+// without module support).
+func Read(bi *debug.BuildInfo) Info {
+	i := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if i.Version == "" {
+		i.Version = DevVersion
+	}
+	if bi == nil {
+		return i
+	}
+
+	if bi.GoVersion != "" {
+		i.GoVersion = bi.GoVersion
+	}
+	i.Module = bi.Main.Path
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if i.Commit == "" {
+				i.Commit = s.Value
+			}
+		case "vcs.time":
+			if i.Date == "" {
+				i.Date = s.Value
+			}
+		case "vcs.modified":
+			i.Dirty = s.Value == "true"
+		}
+	}
+	for _, dep := range bi.Deps {
+		m := Module{Path: dep.Path, Version: dep.Version, Sum: dep.Sum}
+		if dep.Replace != nil {
+			m.Replace = dep.Replace.Path
+			m.Version, m.Sum = dep.Replace.Version, dep.Replace.Sum
+		}
+		i.Deps = append(i.Deps, m)
+	}
+	return i
+}
+
+// String is the one line summary printed by --version, e.g.
+// "1.2.0 (commit 4f2a9c1, built 2025-05-01T10:00:00Z, go1.24.2 linux/amd64)".
+func (i Info) String() string {
+	commit := i.Commit
+	if commit == "" {
+		commit = "unknown"
+	}
+	if i.Dirty {
+		commit += "-dirty"
+	}
+	date := i.Date
+	if date == "" {
+		date = "unknown"
+	}
+	return fmt.Sprintf("%s (commit %s, built %s, %s %s)", i.Version, commit, date, i.GoVersion, i.Platform)
+}
+
+// Summary returns the version and commit of i.
+func (i Info) Summary() Summary {
+	return Summary{Version: i.Version, Commit: i.Commit}
+}
+
+// Handler serves Get as JSON, dependencies included: mount it on an admin port only.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Get())
+	})
+}
@@ -7,79 +7,88 @@ package database
 
 import (
 	"fmt"
-	"log"
-	"os"
 	"time"
 
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	gormlogger "gorm.io/gorm/logger"
 
 	"youGo/internal/config"
 )
 
-func NewGORMConnection(cfg config.Database) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
-		cfg.Host,
-		cfg.Port,
-		cfg.User,
-		cfg.Password,
-		cfg.DBName,
-		cfg.SSLMode,
-	)
-	log.Println("Database DSN:", dsn) // Added logging
-
-	gormLogLevel := gormlogger.Silent
-	// Configure GORM logger
-	// Set log level based on environment (e.g., Silent in prod, Info in dev)
-	// Example: Set log level based on an environment variable or config field
-	// if os.Getenv("APP_ENV") == "development" { // Or use cfg.App.Env
-	//  gormLogLevel = gormlogger.Info
-	// }
+// NewGORMConnection opens the database of the configured driver (see config.Database.DriverName),
+// with the pool and timeouts of SettingsFor(cfg). Failed attempts are retried with exponential backoff.
+// SQL is logged to logger, see NewGORMLogger.
+func NewGORMConnection(cfg config.Database, logger *zap.Logger) (*gorm.DB, error) {
+	settings := SettingsFor(cfg)
+	for attempt := 1; ; attempt++ {
+		db, err := open(cfg, settings, logger)
+		if err == nil {
+			return db, nil
+		}
+		if attempt > settings.ConnectRetries {
+			return nil, err
+		}
+		delay := settings.retryDelay(attempt)
+		logger.Warn("Database connection failed, retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("attempts", settings.ConnectRetries+1),
+			zap.Duration("retry_in", delay),
+			zap.Error(err),
+		)
+		time.Sleep(delay)
+	}
+}
 
-	newLogger := gormlogger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer (log to stdout)
-		gormlogger.Config{
-			SlowThreshold:             time.Second * 2, // Slow SQL threshold (adjust as needed)
-			LogLevel:                  gormLogLevel,    // Set log level
-			IgnoreRecordNotFoundError: true,            // Don't log ErrRecordNotFound errors
-			Colorful:                  true,            // Enable color (disable in prod if logging to files)
-		},
-	)
+// open makes one attempt at connecting to the database.
+func open(cfg config.Database, settings Settings, logger *zap.Logger) (*gorm.DB, error) {
+	dialector := postgres.Open(postgresDSN(cfg))
+	if settings.Driver == config.DriverSQLite {
+		// Foreign keys are off by default in SQLite; writers wait for each other instead of failing
+		dialector = sqlite.Open(cfg.DBName + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	}
 
-	// Connect to the database
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: newLogger, // Use configured logger
-		// Add other GORM configs if needed (e.g., naming strategy)
-		// NamingStrategy: schema.NamingStrategy{ ... }
+	// Connect to the database; the pool is sized before the first connection is made
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:               NewGORMLogger(logger, settings.SlowQueryThreshold),
+		DisableAutomaticPing: true,
+		TranslateError:       settings.Driver == config.DriverSQLite, // Constraint violations as gorm.ErrDuplicatedKey etc., see postgres.MapError
 	})
-
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
-
-	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
-		// GORM v2 should generally handle this, but good to check
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
+	settings.applyPool(sqlDB)
 
-	// Set connection pool parameters (load from config if available)
-	// Example values, tune these based on expected load and DB resources
-	sqlDB.SetMaxIdleConns(10)           // cfg.Database.MaxIdleConns
-	sqlDB.SetMaxOpenConns(100)          // cfg.Database.MaxOpenConns
-	sqlDB.SetConnMaxLifetime(time.Hour) // cfg.Database.ConnMaxLifetime (parse duration from config)
-
-	// Optional: Ping the database to verify connection
 	if err = sqlDB.Ping(); err != nil {
+		_ = sqlDB.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
-
-	log.Println("Database connection pool established successfully.") // Use standard log initially
 	return db, nil
 }
 
+// postgresDSN returns the connection string of the Postgres database described by cfg.
+// The password is part of it: never log it.
+func postgresDSN(cfg config.Database) string {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.DBName,
+		cfg.SSLMode,
+	)
+	if timeout := SettingsFor(cfg).StatementTimeout; timeout > 0 {
+		// Sent as a run-time parameter when connecting, so it applies to every session of the pool
+		dsn += fmt.Sprintf(" statement_timeout=%d", timeout.Milliseconds())
+	}
+	return dsn
+}
+
 // Optional: RunMigrations function (if using AutoMigrate)
 // func RunMigrations(db *gorm.DB) error {
 //  log.Println("Running database migrations...")
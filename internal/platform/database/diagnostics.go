@@ -0,0 +1,34 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package database /youGo/internal/platform/database/diagnostics.go
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Diagnostics describe the database connection as the application runs it.
+type Diagnostics struct {
+	Settings Settings
+	Pool     sql.DBStats     // Statistics of the primary's pool
+	Replicas []ReplicaStatus // Empty without read replicas
+}
+
+// Diagnose reports the settings db was opened with, the current state of its pool and the
+// health of its read replicas (replicas may be nil).
+func Diagnose(db *gorm.DB, settings Settings, replicas *ReplicaSet) (Diagnostics, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return Diagnostics{}, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	return Diagnostics{
+		Settings: settings,
+		Pool:     sqlDB.Stats(),
+		Replicas: replicas.Status(),
+	}, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package database /youGo/internal/platform/database/gorm_logger.go
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+
+	"youGo/internal/platform/requestctx"
+	"youGo/internal/platform/tracing"
+)
+
+// gormLogger writes GORM's logs to zap, so that SQL joins the application logs:
+//   - every statement at Debug, so only with log.level "debug";
+//   - statements slower than the threshold at Warn, with their duration and rows;
+//   - failed statements at Warn (not Error: constraint violations and the like are handled by the caller).
+//
+// Bind parameters are never logged: statements show their placeholders. Lines carry the request
+// ID, trace ID and span ID of the statement's context (see requestctx and tracing).
+type gormLogger struct {
+	logger        *zap.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGORMLogger returns a GORM logger writing to logger. Statements taking longer than
+// slowThreshold are logged as slow; zero disables it.
+func NewGORMLogger(logger *zap.Logger, slowThreshold time.Duration) gormlogger.Interface {
+	return &gormLogger{
+		// No caller: it would always be this file, the "source" field has the caller of GORM
+		logger:        logger.Named("gorm").WithOptions(zap.WithCaller(false)),
+		level:         gormlogger.Info, // Filtered by the level of logger
+		slowThreshold: slowThreshold,
+	}
+}
+
+// LogMode returns a copy of the logger limited to level (gorm.DB.Debug asks for gormlogger.Info).
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.logger.Info(fmt.Sprintf(msg, data...), contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.logger.Warn(fmt.Sprintf(msg, data...), contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.logger.Error(fmt.Sprintf(msg, data...), contextFields(ctx)...)
+	}
+}
+
+// Trace logs a statement once it ran. fc returns the SQL and the rows affected (-1 if unknown).
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	var (
+		level   zapcore.Level
+		message string
+		extra   []zap.Field
+	)
+	switch {
+	case err != nil && !errors.Is(err, gormlogger.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		level, message, extra = zap.WarnLevel, "SQL query failed", []zap.Field{zap.Error(err)}
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		level, message, extra = zap.WarnLevel, "Slow SQL query", []zap.Field{zap.Duration("slow_query_threshold", l.slowThreshold)}
+	case l.level >= gormlogger.Info:
+		level, message = zap.DebugLevel, "SQL query"
+	default:
+		return
+	}
+	ce := l.logger.Check(level, message)
+	if ce == nil {
+		return // Disabled at this level: don't render the statement
+	}
+
+	sql, rows := fc()
+	fields := append([]zap.Field{
+		zap.String("sql", sql),
+		zap.Duration("duration", elapsed),
+		zap.String("source", utils.FileWithLineNum()),
+	}, extra...)
+	if rows >= 0 {
+		fields = append(fields, zap.Int64("rows", rows))
+	}
+	ce.Write(append(fields, contextFields(ctx)...)...)
+}
+
+// ParamsFilter drops the bind parameters of the statements before they're logged, as they may
+// hold passwords, tokens or personal data.
+func (l *gormLogger) ParamsFilter(_ context.Context, sql string, _ ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// contextFields returns the request ID, trace ID and span ID carried by ctx.
+func contextFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	meta := requestctx.FromContext(ctx)
+	var fields []zap.Field
+	if meta.RequestID != "" {
+		fields = append(fields, zap.String("request_id", meta.RequestID))
+	}
+	if spanFields := tracing.LogFields(ctx); spanFields != nil {
+		fields = append(fields, spanFields...)
+	} else if meta.TraceID != "" {
+		fields = append(fields, zap.String("trace_id", meta.TraceID))
+	}
+	return fields
+}
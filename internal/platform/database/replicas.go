@@ -0,0 +1,220 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package database /youGo/internal/platform/database/replicas.go
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the "pgx" database/sql driver
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/config"
+)
+
+const defaultReplicaCheckInterval = 5 * time.Second
+
+// Replica is an open connection pool to a read replica of the primary database.
+type Replica struct {
+	Name string // For logs, e.g. "replica-1:5432"
+	DB   *sql.DB
+}
+
+// ReplicaStatus reports whether a replica currently serves reads.
+type ReplicaStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+}
+
+// ReplicaSet routes the reads of a GORM database to read replicas, see UseReplicas.
+// Replicas failing a health check are ejected until a later check succeeds; while none is
+// healthy, reads go to the primary.
+type ReplicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64 // Round robin over the healthy replicas
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+type replica struct {
+	Replica
+	healthy atomic.Bool
+}
+
+// OpenReplicas opens a pool to every replica of cfg. Connections are made lazily, so an
+// unreachable replica doesn't fail the startup: UseReplicas ejects it.
+func OpenReplicas(cfg config.Database) ([]Replica, error) {
+	replicas := make([]Replica, 0, len(cfg.Replicas))
+	for i := range cfg.Replicas {
+		replicaCfg := cfg.Replica(i)
+		db, err := sql.Open("pgx", postgresDSN(replicaCfg))
+		if err != nil {
+			for _, r := range replicas {
+				_ = r.DB.Close()
+			}
+			return nil, fmt.Errorf("failed to open read replica %d: %w", i, err)
+		}
+		SettingsFor(replicaCfg).applyPool(db)
+		replicas = append(replicas, Replica{Name: net.JoinHostPort(replicaCfg.Host, replicaCfg.Port), DB: db})
+	}
+	return replicas, nil
+}
+
+// UseReplicas makes db send its reads to replicas (same SQL dialect as db) and returns the set,
+// whose Run method keeps their health up to date. Replicas are checked once before it returns.
+//
+// A read goes to the primary when it runs in a transaction, locks rows (FOR UPDATE), is raw SQL
+// other than a SELECT, or when its context sticks to the primary (see WithReadYourWrites and
+// UsePrimary). Every other statement is a write and goes to the primary.
+func UseReplicas(db *gorm.DB, replicas []Replica, checkInterval time.Duration, logger *zap.Logger) (*ReplicaSet, error) {
+	if len(replicas) == 0 {
+		return nil, errors.New("database: no replica to use")
+	}
+	if checkInterval <= 0 {
+		checkInterval = defaultReplicaCheckInterval
+	}
+	set := &ReplicaSet{interval: checkInterval, logger: logger.Named("replicas")}
+	for _, r := range replicas {
+		added := &replica{Replica: r}
+		added.healthy.Store(true) // So that the first check reports those it ejects
+		set.replicas = append(set.replicas, added)
+	}
+	set.Check(context.Background())
+
+	route := func(mayBeRaw bool) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) { set.route(tx, mayBeRaw) }
+	}
+	callbacks := db.Callback()
+	if err := errors.Join(
+		callbacks.Query().Before("gorm:query").Register("replicas:route", route(false)),
+		callbacks.Row().Before("gorm:row").Register("replicas:route", route(true)),
+		callbacks.Create().After("gorm:create").Register("replicas:stick", stickAfterWrite),
+		callbacks.Update().After("gorm:update").Register("replicas:stick", stickAfterWrite),
+		callbacks.Delete().After("gorm:delete").Register("replicas:stick", stickAfterWrite),
+		callbacks.Raw().After("gorm:raw").Register("replicas:stick", stickAfterWrite),
+	); err != nil {
+		return nil, fmt.Errorf("database: registering replica routing: %w", err)
+	}
+	return set, nil
+}
+
+// route sends the read tx is about to run to a healthy replica, unless it must see the primary.
+// mayBeRaw tells whether tx may be raw SQL (Row callbacks), which is only routed if it's a plain SELECT.
+func (s *ReplicaSet) route(tx *gorm.DB, mayBeRaw bool) {
+	if _, inTx := tx.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		return
+	}
+	if _, locking := tx.Statement.Clauses["FOR"]; locking {
+		return
+	}
+	if mayBeRaw {
+		if raw := strings.TrimSpace(tx.Statement.SQL.String()); raw != "" && !isPlainSelect(raw) {
+			return
+		}
+	}
+	if sticksToPrimary(tx.Statement.Context) {
+		return
+	}
+	if r := s.pick(); r != nil {
+		tx.Statement.ConnPool = r.DB
+	}
+}
+
+// isPlainSelect reports whether raw is a SELECT that doesn't lock rows.
+func isPlainSelect(raw string) bool {
+	upper := strings.ToUpper(raw)
+	return strings.HasPrefix(upper, "SELECT") && !strings.Contains(upper, " FOR UPDATE") && !strings.Contains(upper, " FOR SHARE")
+}
+
+// stickAfterWrite makes the rest of the request read from the primary once it changed data.
+func stickAfterWrite(tx *gorm.DB) {
+	if tx.Error == nil {
+		stick(tx.Statement.Context)
+	}
+}
+
+// pick returns the next healthy replica, or nil if there is none.
+func (s *ReplicaSet) pick() *replica {
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := s.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// Run checks the replicas periodically until ctx is cancelled.
+func (s *ReplicaSet) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Check(ctx)
+		}
+	}
+}
+
+// Check pings every replica once, ejecting those that fail and readmitting those that recovered.
+func (s *ReplicaSet) Check(ctx context.Context) {
+	for _, r := range s.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, s.interval)
+		err := r.DB.PingContext(pingCtx)
+		cancel()
+		switch wasHealthy := r.healthy.Swap(err == nil); {
+		case err != nil && wasHealthy:
+			s.logger.Warn("Read replica failed its health check, ejected", zap.String("replica", r.Name), zap.Error(err))
+		case err != nil && !wasHealthy:
+			s.logger.Debug("Read replica still unhealthy", zap.String("replica", r.Name), zap.Error(err))
+		case err == nil && !wasHealthy:
+			s.logger.Info("Read replica healthy, serving reads", zap.String("replica", r.Name))
+		}
+	}
+}
+
+// Status returns the health of every replica, in configuration order. A nil set has none.
+func (s *ReplicaSet) Status() []ReplicaStatus {
+	if s == nil {
+		return nil
+	}
+	statuses := make([]ReplicaStatus, 0, len(s.replicas))
+	for _, r := range s.replicas {
+		statuses = append(statuses, ReplicaStatus{Name: r.Name, Healthy: r.healthy.Load()})
+	}
+	return statuses
+}
+
+// Pools returns the connection pools of the replicas, in configuration order. A nil set has none.
+func (s *ReplicaSet) Pools() []*sql.DB {
+	if s == nil {
+		return nil
+	}
+	pools := make([]*sql.DB, 0, len(s.replicas))
+	for _, r := range s.replicas {
+		pools = append(pools, r.DB)
+	}
+	return pools
+}
+
+// Close closes the replicas' pools.
+func (s *ReplicaSet) Close() error {
+	var errs []error
+	for _, r := range s.replicas {
+		errs = append(errs, r.DB.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package database /youGo/internal/platform/database/routing.go
+package database
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// routingKey is the context key of the read-your-writes scope.
+type routingKey struct{}
+
+// routingScope records that a unit of work (typically a request) must read from the primary.
+type routingScope struct {
+	primary atomic.Bool
+}
+
+// WithReadYourWrites returns a context in which reads go to the primary once a write went
+// through it, so that a request sees its own changes despite replication lag.
+// Without replicas it has no effect.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routingKey{}, &routingScope{})
+}
+
+// UsePrimary makes the reads through ctx go to the primary: those of the whole
+// read-your-writes scope if ctx has one, or else those through the returned context.
+func UsePrimary(ctx context.Context) context.Context {
+	if scope, ok := ctx.Value(routingKey{}).(*routingScope); ok {
+		scope.primary.Store(true)
+		return ctx
+	}
+	scope := &routingScope{}
+	scope.primary.Store(true)
+	return context.WithValue(ctx, routingKey{}, scope)
+}
+
+// stick makes the read-your-writes scope of ctx, if any, read from the primary from now on.
+func stick(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+	if scope, ok := ctx.Value(routingKey{}).(*routingScope); ok {
+		scope.primary.Store(true)
+	}
+}
+
+func sticksToPrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	scope, ok := ctx.Value(routingKey{}).(*routingScope)
+	return ok && scope.primary.Load()
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package database /youGo/internal/platform/database/settings.go
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"youGo/internal/config"
+)
+
+// Defaults of the connection settings left unset in the configuration.
+const (
+	defaultMaxOpenConns        = 100
+	defaultMaxIdleConns        = 10
+	defaultConnMaxLifetime     = time.Hour
+	defaultConnectRetryBackoff = time.Second
+	maxConnectRetryBackoff     = 30 * time.Second
+	defaultSlowQueryThreshold  = 2 * time.Second
+)
+
+// Settings are the effective connection settings: the configuration completed with the defaults.
+// Zero durations mean no limit.
+type Settings struct {
+	Driver              string
+	MaxOpenConns        int
+	MaxIdleConns        int
+	ConnMaxLifetime     time.Duration
+	ConnMaxIdleTime     time.Duration
+	StatementTimeout    time.Duration
+	ConnectRetries      int
+	ConnectRetryBackoff time.Duration
+	SlowQueryThreshold  time.Duration
+}
+
+// SettingsFor returns the effective settings of cfg. Invalid durations, which config.Validate
+// reports, are replaced by the defaults.
+func SettingsFor(cfg config.Database) Settings {
+	s := Settings{
+		Driver:              cfg.DriverName(),
+		MaxOpenConns:        cfg.MaxOpenConns,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		ConnMaxLifetime:     parseDuration(cfg.ConnMaxLifetime, defaultConnMaxLifetime),
+		ConnMaxIdleTime:     parseDuration(cfg.ConnMaxIdleTime, 0),
+		StatementTimeout:    parseDuration(cfg.StatementTimeout, 0),
+		ConnectRetries:      cfg.ConnectRetries,
+		ConnectRetryBackoff: parseDuration(cfg.ConnectRetryBackoff, defaultConnectRetryBackoff),
+		SlowQueryThreshold:  parseDuration(cfg.SlowQueryThreshold, defaultSlowQueryThreshold),
+	}
+	if s.MaxOpenConns <= 0 {
+		s.MaxOpenConns = defaultMaxOpenConns
+	}
+	if s.MaxIdleConns <= 0 {
+		s.MaxIdleConns = defaultMaxIdleConns
+	}
+	if s.ConnectRetryBackoff <= 0 {
+		s.ConnectRetryBackoff = defaultConnectRetryBackoff
+	}
+	if s.Driver == config.DriverSQLite {
+		// SQLite has a single writer, and a ":memory:" database lives as long as its connection
+		s.MaxOpenConns, s.MaxIdleConns = 1, 1
+		s.ConnMaxLifetime, s.ConnMaxIdleTime = 0, 0
+		s.StatementTimeout = 0 // Not supported
+	}
+	return s
+}
+
+// applyPool sizes the connection pool of db.
+func (s Settings) applyPool(db *sql.DB) {
+	db.SetMaxOpenConns(s.MaxOpenConns)
+	db.SetMaxIdleConns(s.MaxIdleConns)
+	db.SetConnMaxLifetime(s.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(s.ConnMaxIdleTime)
+}
+
+// retryDelay returns how long to wait before the given connection retry (1 for the first).
+func (s Settings) retryDelay(retry int) time.Duration {
+	delay := s.ConnectRetryBackoff
+	for i := 1; i < retry && delay < maxConnectRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxConnectRetryBackoff)
+}
+
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package eventbus /youGo/internal/platform/eventbus/broker.go
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"youGo/internal/domain"
+)
+
+// Message is what a broker client sends: a subject (NATS) or topic (Kafka),
+// a partition key that keeps one aggregate's messages in order, and the payload.
+type Message struct {
+	Subject string
+	Key     string
+	Headers map[string]string
+	Data    []byte
+}
+
+// BrokerClient is the minimal surface of a NATS/Kafka-style producer.
+// Wrap a real client in an adapter implementing this to plug it in.
+type BrokerClient interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// BrokerPublisher publishes events to a message broker as
+// "<prefix>.<aggregate type>.<event type>", keyed by aggregate ID.
+type BrokerPublisher struct {
+	client BrokerClient
+	prefix string
+}
+
+// NewBrokerPublisher creates a broker sink.
+func NewBrokerPublisher(client BrokerClient, subjectPrefix string) *BrokerPublisher {
+	return &BrokerPublisher{client: client, prefix: subjectPrefix}
+}
+
+// Publish implements domain.EventPublisher.
+func (b *BrokerPublisher) Publish(ctx context.Context, event *domain.DomainEvent) error {
+	data, err := json.Marshal(NewEnvelope(event))
+	if err != nil {
+		return fmt.Errorf("broker: encoding event: %w", err)
+	}
+	subject := strings.Join([]string{b.prefix, event.AggregateType, event.Type}, ".")
+	err = b.client.Send(ctx, Message{
+		Subject: strings.TrimPrefix(subject, "."),
+		Key:     event.AggregateType + ":" + event.AggregateID,
+		Headers: map[string]string{HeaderEventID: event.ID.String(), HeaderEventType: event.Type},
+		Data:    data,
+	})
+	if err != nil {
+		return fmt.Errorf("broker: sending %s: %w", event.Type, err)
+	}
+	return nil
+}
+
+// LocalBrokerLogSize is how many of the latest messages a LocalBroker keeps.
+const LocalBrokerLogSize = 1000
+
+// LocalBroker is an in-memory stand-in for a real broker, for local development and tests.
+// It keeps the latest LocalBrokerLogSize messages and fans out to subscribers synchronously.
+type LocalBroker struct {
+	mu          sync.Mutex
+	log         []Message
+	subscribers map[string][]func(Message)
+}
+
+// NewLocalBroker creates an empty local broker.
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{subscribers: make(map[string][]func(Message))}
+}
+
+// Send implements BrokerClient.
+func (l *LocalBroker) Send(_ context.Context, msg Message) error {
+	l.mu.Lock()
+	l.log = append(l.log, msg)
+	if len(l.log) > LocalBrokerLogSize {
+		// The oldest messages go once append moves the log to a new array
+		l.log = l.log[1:]
+	}
+	subs := append([]func(Message){}, l.subscribers[msg.Subject]...)
+	subs = append(subs, l.subscribers[AllEvents]...)
+	l.mu.Unlock()
+
+	for _, sub := range subs {
+		sub(msg)
+	}
+	return nil
+}
+
+// Subscribe registers fn for an exact subject, or for all subjects with AllEvents.
+func (l *LocalBroker) Subscribe(subject string, fn func(Message)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.subscribers[subject] = append(l.subscribers[subject], fn)
+}
+
+// Messages returns a copy of the latest messages, oldest first.
+func (l *LocalBroker) Messages() []Message {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Message(nil), l.log...)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package eventbus /youGo/internal/platform/eventbus/bus.go
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"youGo/internal/domain"
+)
+
+// AllEvents subscribes a handler to every event type.
+const AllEvents = "*"
+
+// Handler consumes a domain event delivered by the in-process Bus.
+type Handler func(ctx context.Context, event *domain.DomainEvent) error
+
+// Bus is an in-process publish/subscribe sink. Handlers run synchronously
+// in subscription order, so a failing handler makes the relay retry the event.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates an empty in-process bus.
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for eventType, or for every event when eventType is AllEvents.
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish implements domain.EventPublisher.
+func (b *Bus) Publish(ctx context.Context, event *domain.DomainEvent) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler{}, b.handlers[event.Type]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("eventbus: %d handler(s) failed for %s: %w", len(errs), event.Type, errors.Join(errs...))
+	}
+	return nil
+}
+
+// Fanout publishes every event to all of its sinks. It fails if any sink fails,
+// which makes the relay redeliver to all of them (consumers de-duplicate by event ID).
+type Fanout []domain.EventPublisher
+
+// Publish implements domain.EventPublisher.
+func (f Fanout) Publish(ctx context.Context, event *domain.DomainEvent) error {
+	var errs []error
+	for _, sink := range f {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
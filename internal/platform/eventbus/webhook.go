@@ -0,0 +1,87 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package eventbus /youGo/internal/platform/eventbus/webhook.go
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"youGo/internal/domain"
+)
+
+// Headers sent with every webhook delivery.
+const (
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+)
+
+// Envelope is the JSON body sent to webhook and broker sinks.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	Sequence      int64           `json:"sequence"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// NewEnvelope wraps a domain event for transport.
+func NewEnvelope(event *domain.DomainEvent) Envelope {
+	return Envelope{
+		ID:            event.ID.String(),
+		Type:          event.Type,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Sequence:      event.Sequence,
+		OccurredAt:    event.OccurredAt,
+		Data:          event.Payload,
+	}
+}
+
+// WebhookPublisher POSTs every event as JSON to a single fixed URL.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookPublisher creates a webhook sink. Pass a client from httpclient.NewHTTPClient.
+func NewWebhookPublisher(url string, client *http.Client) *WebhookPublisher {
+	return &WebhookPublisher{url: url, client: client}
+}
+
+// Publish implements domain.EventPublisher. Any non-2xx response counts as a failure.
+func (w *WebhookPublisher) Publish(ctx context.Context, event *domain.DomainEvent) error {
+	body, err := json.Marshal(NewEnvelope(event))
+	if err != nil {
+		return fmt.Errorf("webhook: encoding event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, event.ID.String())
+	req.Header.Set(HeaderEventType, event.Type)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: delivering %s: %w", event.Type, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body) // Drain so the connection can be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: %s rejected with status %d", event.Type, resp.StatusCode)
+	}
+	return nil
+}
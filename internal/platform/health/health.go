@@ -0,0 +1,251 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package health /youGo/internal/platform/health/health.go
+// Package health keeps the named checks behind the liveness (/healthz) and readiness (/readyz)
+// probes. Results are cached for a while, so that many probes, load balancers or dashboards
+// polling at once cost one check per period rather than one per poll.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Defaults of Options.
+const (
+	DefaultTimeout  = 2 * time.Second
+	DefaultCacheTTL = time.Second
+)
+
+// Statuses of a check and of a report.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ShutdownCheck is the check failing readiness once the shutdown started.
+const ShutdownCheck = "shutdown"
+
+// ErrShuttingDown is the error of ShutdownCheck.
+var ErrShuttingDown = errors.New("shutting down")
+
+// CheckFunc checks a dependency, returning why it is unusable. It should honour the deadline of ctx.
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of a check.
+type Result struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Duration  float64   `json:"duration_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the outcome of the checks of a probe, failed if any of them failed.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// OK tells whether every check passed.
+func (r Report) OK() bool { return r.Status == StatusOK }
+
+// Summary is the part of a Report served publicly: the status of each check. The errors are
+// left out, since they may name internal hosts, versions or paths; the registry logs them.
+type Summary struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Summary returns the status of r and of each of its checks.
+func (r Report) Summary() Summary {
+	s := Summary{Status: r.Status, Checks: make(map[string]string, len(r.Checks))}
+	for name, result := range r.Checks {
+		s.Checks[name] = result.Status
+	}
+	return s
+}
+
+// Options configure NewRegistry.
+type Options struct {
+	Timeout  time.Duration // Of each check; defaults to DefaultTimeout
+	CacheTTL time.Duration // How long a result is reused; defaults to DefaultCacheTTL
+	Logger   *zap.Logger   // Logs why checks fail; defaults to none
+}
+
+// check is a registered check and its last result.
+type check struct {
+	name     string
+	fn       CheckFunc
+	liveness bool
+
+	mu     sync.Mutex // Held while the check runs, so that concurrent probes wait for its result
+	result Result
+}
+
+// Registry holds the named checks of the probes.
+type Registry struct {
+	opts Options
+	now  func() time.Time
+
+	mu           sync.RWMutex
+	checks       []*check
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry(opts Options) *Registry {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = DefaultCacheTTL
+	}
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	return &Registry{opts: opts, now: time.Now}
+}
+
+// Register adds a readiness check: while it fails, the instance shouldn't get traffic, e.g.
+// its database is unreachable. Names are unique; registering one again replaces its check.
+func (r *Registry) Register(name string, fn CheckFunc) {
+	r.add(&check{name: name, fn: fn})
+}
+
+// RegisterLiveness adds a check failing liveness as well as readiness. Only register checks a
+// restart of the process would fix, e.g. a deadlocked worker: a liveness failure gets the
+// instance killed, which wouldn't bring a database back.
+func (r *Registry) RegisterLiveness(name string, fn CheckFunc) {
+	r.add(&check{name: name, fn: fn, liveness: true})
+}
+
+func (r *Registry) add(c *check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.checks {
+		if existing.name == c.name {
+			r.checks[i] = c
+			return
+		}
+	}
+	r.checks = append(r.checks, c)
+}
+
+// Names returns the names of the readiness checks, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for _, c := range r.checks {
+		names = append(names, c.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StartShutdown makes readiness fail from now on, so that load balancers stop sending requests
+// while the ones in flight finish.
+func (r *Registry) StartShutdown() {
+	r.shuttingDown.Store(true)
+}
+
+// ShuttingDown tells whether StartShutdown was called.
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Liveness runs the liveness checks. Without any, the process answering is enough.
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.run(ctx, func(c *check) bool { return c.liveness })
+}
+
+// Readiness runs every check, and fails as soon as the shutdown started.
+func (r *Registry) Readiness(ctx context.Context) Report {
+	if r.ShuttingDown() {
+		return Report{Status: StatusFail, Checks: map[string]Result{
+			ShutdownCheck: {Status: StatusFail, Error: ErrShuttingDown.Error(), CheckedAt: r.now().UTC()},
+		}}
+	}
+	return r.run(ctx, func(*check) bool { return true })
+}
+
+// run runs the checks selected by include concurrently.
+func (r *Registry) run(ctx context.Context, include func(c *check) bool) Report {
+	r.mu.RLock()
+	var selected []*check
+	for _, c := range r.checks {
+		if include(c) {
+			selected = append(selected, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	results := make([]Result, len(selected))
+	var wg sync.WaitGroup
+	for i, c := range selected {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.result(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(selected))}
+	for i, c := range selected {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// result returns the cached result of c, running it if the result expired.
+func (r *Registry) result(ctx context.Context, c *check) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.result.CheckedAt.IsZero() && r.now().Sub(c.result.CheckedAt) < r.opts.CacheTTL {
+		return c.result
+	}
+
+	// The probe's own deadline doesn't apply: the result is shared with the other probes
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.opts.Timeout)
+	defer cancel()
+	start := r.now()
+	err := runCheck(checkCtx, c.fn)
+	if err == nil && checkCtx.Err() != nil {
+		err = checkCtx.Err() // Passed too late to count
+	}
+	c.result = Result{Status: StatusOK, Duration: float64(r.now().Sub(start).Microseconds()) / 1000, CheckedAt: start.UTC()}
+	if err != nil {
+		c.result.Status, c.result.Error = StatusFail, err.Error()
+		r.opts.Logger.Warn("Health check failed", zap.String("check", c.name), zap.Float64("durationMs", c.result.Duration), zap.Error(err))
+	}
+	return c.result
+}
+
+// runCheck runs fn, turning a panic into a failure.
+func runCheck(ctx context.Context, fn CheckFunc) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("check panicked: %v", p)
+		}
+	}()
+	return fn(ctx)
+}
+
+// PingDB returns a check pinging db.
+func PingDB(db *sql.DB) CheckFunc {
+	return db.PingContext
+}
@@ -8,28 +8,45 @@ package httpclient
 import (
 	"net"
 	"net/http"
+	"net/url"
+	"syscall"
 	"time"
 	// Import config if timeout values are stored there
 	// "youGo/internal/config"
+
+	"youGo/internal/platform/tracing"
 )
 
 // DefaultTimeout is a reasonable default timeout for external HTTP calls.
 const DefaultTimeout = 15 * time.Second
 
 // NewHTTPClient creates a new *http.Client with sensible defaults.
+// Every request is traced as a client span carrying the traceparent of the caller's context.
 // Customize by passing configuration options if needed.
 func NewHTTPClient( /* cfg config.HTTPClientConfig */ timeout time.Duration) *http.Client {
 	if timeout <= 0 {
 		timeout = DefaultTimeout
 	}
 
+	client := &http.Client{
+		Timeout:   timeout,                                                         // Total timeout for the entire request-response cycle
+		Transport: tracing.Transport(newTransport(http.ProxyFromEnvironment, nil)), // Use the configured transport, traced
+	}
+
+	return client
+}
+
+// newTransport returns the transport of the clients. control, if not nil, vets the address of
+// every connection before it is made.
+func newTransport(proxy func(*http.Request) (*url.URL, error), control func(network, address string, c syscall.RawConn) error) *http.Transport {
 	// Configure the transport with timeouts for connection establishment, etc.
 	// These are lower-level timeouts compared to the client's total timeout.
-	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment, // Respect environment proxy settings
+	return &http.Transport{
+		Proxy: proxy, // http.ProxyFromEnvironment respects environment proxy settings; nil connects directly
 		DialContext: (&net.Dialer{
 			Timeout:   5 * time.Second, // Timeout for establishing the connection
 			KeepAlive: 30 * time.Second,
+			Control:   control,
 		}).DialContext,
 		ForceAttemptHTTP2:     true,             // Prefer HTTP/2
 		MaxIdleConns:          100,              // Max idle connections overall
@@ -38,13 +55,6 @@ func NewHTTPClient( /* cfg config.HTTPClientConfig */ timeout time.Duration) *ht
 		TLSHandshakeTimeout:   5 * time.Second,  // Timeout for TLS handshake
 		ExpectContinueTimeout: 1 * time.Second,
 	}
-
-	client := &http.Client{
-		Timeout:   timeout,   // Total timeout for the entire request-response cycle
-		Transport: transport, // Use the configured transport
-	}
-
-	return client
 }
 
 // You can add more specific clients here if needed, e.g., a client
@@ -0,0 +1,110 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package httpclient /youGo/internal/platform/httpclient/public.go
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"youGo/internal/platform/tracing"
+)
+
+// ErrNonPublicAddress is the error of a connection to, or a URL of, an address that isn't
+// public: loopback, private, link-local (cloud metadata services included) and the like.
+var ErrNonPublicAddress = errors.New("httpclient: address is not public")
+
+// nonPublicPrefixes are the special purpose ranges netip.Addr has no predicate for.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "This network"
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT, e.g. 100.100.100.200 (Alibaba Cloud metadata)
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reserved, broadcast included
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which may map to any IPv4 address
+	netip.MustParsePrefix("2001:db8::/32"), // Documentation
+}
+
+// PublicAddr reports whether addr is a public unicast address. IPv4-mapped IPv6 addresses are
+// judged by their IPv4 address.
+func PublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false // Loopback, link-local, multicast and unspecified addresses aren't global unicast
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPublicURL returns an error wrapping ErrNonPublicAddress unless rawURL is an http or
+// https URL whose host resolves to public addresses only. It is meant for URLs users choose,
+// e.g. webhook endpoints, when they are registered; NewPublicHTTPClient checks the address
+// again when connecting, since what a name resolves to may change.
+func CheckPublicURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("httpclient: parsing URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("httpclient: unsupported scheme %q", u.Scheme)
+	}
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !PublicAddr(addr) {
+			return fmt.Errorf("%w: %s", ErrNonPublicAddress, addr)
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("httpclient: resolving %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !PublicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrNonPublicAddress, host, addr)
+		}
+	}
+	return nil
+}
+
+// NewPublicHTTPClient creates a client like NewHTTPClient for URLs chosen by users: it only
+// connects to public addresses (see PublicAddr), whatever their name resolves to when the
+// request is made, ignores the environment's proxy and doesn't follow redirects, which are
+// returned as the response instead.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: tracing.Transport(newTransport(nil, controlPublic)),
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// controlPublic refuses connections to addresses that aren't public.
+func controlPublic(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("httpclient: parsing address %q: %w", address, err)
+	}
+	if !PublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, addrPort.Addr())
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package i18n /youGo/internal/platform/i18n/i18n.go
+// Package i18n translates user-facing messages. A Catalogue holds the messages of each
+// locale by key (a stable code such as "user.not_found"); the ones shipped with the
+// application are embedded from locales/*.json.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of the messages used when no requested locale is available.
+const DefaultLocale = "en"
+
+// CountParam is the parameter choosing the plural form of a message.
+const CountParam = "count"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Message is the text of a key in one locale. A message counting something has a form per
+// plural category of the locale instead ("one", "other", ...), chosen by the CountParam
+// parameter. In JSON, it is a string or an object of forms.
+type Message struct {
+	Text   string
+	Plural map[string]string
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.Text); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &m.Plural); err != nil {
+		return errors.New("a message is a string or an object of plural forms")
+	}
+	if _, ok := m.Plural["other"]; !ok {
+		return errors.New(`plural forms need an "other" form`)
+	}
+	return nil
+}
+
+// Catalogue holds the messages of a set of locales. It is safe for concurrent use.
+type Catalogue struct {
+	fallback string
+	messages map[string]map[string]Message // locale -> key -> message
+}
+
+// NewCatalogue creates a catalogue of the given messages by locale. Messages missing in a
+// locale are taken from the fallback locale.
+func NewCatalogue(fallback string, messages map[string]map[string]Message) *Catalogue {
+	normalized := make(map[string]map[string]Message, len(messages))
+	for locale, m := range messages {
+		normalized[normalize(locale)] = m
+	}
+	return &Catalogue{fallback: normalize(fallback), messages: normalized}
+}
+
+// Load reads a catalogue from the <locale>.json files of fsys, each a JSON object of
+// messages by key.
+func Load(fsys fs.FS, fallback string) (*Catalogue, error) {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	messages := make(map[string]map[string]Message, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[string]Message)
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("i18n: invalid catalogue %s: %w", file, err)
+		}
+		messages[strings.TrimSuffix(path.Base(file), ".json")] = m
+	}
+	if _, ok := messages[fallback]; !ok {
+		return nil, fmt.Errorf("i18n: no catalogue for the fallback locale %q", fallback)
+	}
+	return NewCatalogue(fallback, messages), nil
+}
+
+var defaultCatalogue = sync.OnceValue(func() *Catalogue {
+	sub, _ := fs.Sub(locales, "locales") // Fails only for an invalid directory name
+	c, err := Load(sub, DefaultLocale)
+	if err != nil {
+		panic(err) // The embedded files are part of the build
+	}
+	return c
+})
+
+// Default returns the catalogue of the locales shipped with the application.
+func Default() *Catalogue {
+	return defaultCatalogue()
+}
+
+// Locales returns the locales of the catalogue, sorted.
+func (c *Catalogue) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// Fallback returns the locale used when no requested locale is available.
+func (c *Catalogue) Fallback() string {
+	return c.fallback
+}
+
+// Message returns the message of key in locale (or else in the fallback locale), with its
+// {placeholders} replaced by params. ok is false if no locale has the key.
+func (c *Catalogue) Message(locale, key string, params map[string]string) (message string, ok bool) {
+	locale = normalize(locale)
+	m, ok := c.messages[locale][key]
+	if !ok {
+		locale = c.fallback
+		m, ok = c.messages[locale][key]
+	}
+	if !ok {
+		return "", false
+	}
+	message = m.Text
+	if m.Plural != nil {
+		count, err := strconv.Atoi(params[CountParam])
+		if message, ok = m.Plural[pluralRulesOf(locale).category(count)]; !ok || err != nil {
+			message = m.Plural["other"]
+		}
+	}
+	if len(params) > 0 && strings.Contains(message, "{") {
+		pairs := make([]string, 0, 2*len(params))
+		for name, value := range params {
+			pairs = append(pairs, "{"+name+"}", value)
+		}
+		message = strings.NewReplacer(pairs...).Replace(message)
+	}
+	return message, true
+}
+
+// Match returns the locale of the catalogue for a language tag: the tag itself, or its
+// language for a tag with a region ("id-ID" is "id").
+func (c *Catalogue) Match(tag string) (string, bool) {
+	tag = normalize(tag)
+	if _, ok := c.messages[tag]; ok {
+		return tag, true
+	}
+	if language, _, found := strings.Cut(tag, "-"); found {
+		if _, ok := c.messages[language]; ok {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate returns the locale of the catalogue best matching an Accept-Language header,
+// e.g. "id-ID,id;q=0.9,en;q=0.8": the ones with the highest weight first (see Match).
+// Without a match it is the fallback locale.
+func (c *Catalogue) Negotiate(acceptLanguage string) string {
+	type weighted struct {
+		tag    string
+		weight float64
+	}
+	var ranges []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			w, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = w
+		}
+		if tag = normalize(tag); tag != "" && weight > 0 {
+			ranges = append(ranges, weighted{tag, weight})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].weight > ranges[j].weight })
+
+	for _, r := range ranges {
+		if r.tag == "*" {
+			return c.fallback
+		}
+		if locale, ok := c.Match(r.tag); ok {
+			return locale
+		}
+	}
+	return c.fallback
+}
+
+// Check reports the translations missing from the catalogue: each key of keys, or of any
+// locale, that a locale lacks, and the plural forms a locale needs but lacks.
+func (c *Catalogue) Check(keys ...string) error {
+	all := make(map[string]bool)
+	for _, key := range keys {
+		all[key] = true
+	}
+	for _, messages := range c.messages {
+		for key := range messages {
+			all[key] = true
+		}
+	}
+	sorted := make([]string, 0, len(all))
+	for key := range all {
+		sorted = append(sorted, key)
+	}
+	slices.Sort(sorted)
+
+	var errs []error
+	for _, locale := range c.Locales() {
+		categories := pluralRulesOf(locale).categories
+		for _, key := range sorted {
+			m, ok := c.messages[locale][key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("i18n: %s: missing %q", locale, key))
+				continue
+			}
+			plural := m.Plural != nil || c.messages[c.fallback][key].Plural != nil
+			if !plural || (m.Plural == nil && len(categories) == 1) {
+				continue // A language without plural forms may use a plain message
+			}
+			for _, category := range categories {
+				if _, ok := m.Plural[category]; !ok {
+					errs = append(errs, fmt.Errorf("i18n: %s: %q misses the plural form %q", locale, key, category))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// normalize lowercases a language tag and uses "-" as separator: "pt_BR" is "pt-br".
+func normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package i18n /youGo/internal/platform/i18n/plural.go
+package i18n
+
+import "strings"
+
+// pluralRules are the plural categories of a language (CLDR), for whole counts.
+type pluralRules struct {
+	categories []string
+	category   func(n int) string
+}
+
+var (
+	// oneOther: "1 item", "0 items", "2 items"
+	oneOther = pluralRules{[]string{"one", "other"}, func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}}
+	// zeroOneOther: "0 item", "1 item", "2 items"
+	zeroOneOther = pluralRules{[]string{"one", "other"}, func(n int) string {
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	}}
+	// otherOnly: languages without plural forms
+	otherOnly = pluralRules{[]string{"other"}, func(int) string { return "other" }}
+)
+
+// pluralRulesByLanguage lists the languages not following oneOther.
+var pluralRulesByLanguage = map[string]pluralRules{
+	"fr": zeroOneOther,
+	"pt": zeroOneOther,
+	"id": otherOnly,
+	"ms": otherOnly,
+	"ja": otherOnly,
+	"ko": otherOnly,
+	"th": otherOnly,
+	"vi": otherOnly,
+	"zh": otherOnly,
+}
+
+func pluralRulesOf(locale string) pluralRules {
+	language, _, _ := strings.Cut(locale, "-")
+	if rules, ok := pluralRulesByLanguage[language]; ok {
+		return rules
+	}
+	return oneOther
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package metrics /youGo/internal/platform/metrics/metrics.go
+// Package metrics holds the Prometheus collectors of the application: HTTP requests, the
+// database connection pools, authentication, and the build of the binary. They are registered on a registry of their
+// own, served by Handler, rather than on the global one.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"youGo/internal/platform/buildinfo"
+)
+
+// Results of logins, the values of the "result" label of auth_logins_total.
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// Metrics are the collectors of the application.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	HTTPRequests *prometheus.CounterVec   // By route template, method and status
+	HTTPDuration *prometheus.HistogramVec // By route template, method and status
+	HTTPInFlight prometheus.Gauge
+
+	Logins                  *prometheus.CounterVec // By result
+	TokenValidationFailures prometheus.Counter
+
+	BuildInfo prometheus.Gauge // Always 1, the build is in the labels
+}
+
+// New creates the collectors, registered with those of the Go runtime and the process.
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests handled, by route template, method and status.",
+		}, []string{"route", "method", "status"}),
+		HTTPDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of the HTTP requests handled, by route template, method and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		HTTPInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "HTTP requests being handled.",
+		}),
+		Logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_logins_total",
+			Help: "Login attempts, by result (success or failure).",
+		}, []string{"result"}),
+		TokenValidationFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "auth_token_validation_failures_total",
+			Help: "Access tokens rejected as invalid or expired.",
+		}),
+		BuildInfo: newBuildInfo(buildinfo.Get()),
+	}
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests, m.HTTPDuration, m.HTTPInFlight,
+		m.Logins, m.TokenValidationFailures,
+		m.BuildInfo,
+	)
+	// Both results are exported from the start, so that rates don't miss the first failure
+	m.Logins.WithLabelValues(LoginSuccess)
+	m.Logins.WithLabelValues(LoginFailure)
+	return m
+}
+
+// newBuildInfo returns the build_info gauge of info, set to 1: dashboards join on it, or count
+// it by version to follow a rollout.
+func newBuildInfo(info buildinfo.Info) prometheus.Gauge {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "build_info",
+		Help: "Always 1, labelled with the version, commit and Go version of the running binary.",
+		ConstLabels: prometheus.Labels{
+			"version":    info.Version,
+			"commit":     info.Commit,
+			"go_version": info.GoVersion,
+			"dirty":      strconv.FormatBool(info.Dirty),
+		},
+	})
+	g.Set(1)
+	return g
+}
+
+// RegisterDB exports the statistics of a connection pool (sql.DB.Stats) as the go_sql_*
+// metrics, labelled with db_name, e.g. "primary".
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+// LoginAttempted counts a login by its outcome (see auth.Observe).
+func (m *Metrics) LoginAttempted(err error) {
+	if err != nil {
+		m.Logins.WithLabelValues(LoginFailure).Inc()
+		return
+	}
+	m.Logins.WithLabelValues(LoginSuccess).Inc()
+}
+
+// TokenValidated counts the access tokens rejected (see auth.Observe).
+func (m *Metrics) TokenValidated(err error) {
+	if err != nil {
+		m.TokenValidationFailures.Inc()
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package migrate /youGo/internal/platform/migrate/migrate.go
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// lockKey is the session-level advisory lock held while migrating,
+// so that several instances starting at once don't apply the same migration twice.
+const lockKey = 7_311_402_031
+
+// versionTable has the same layout as golang-migrate's, so databases migrated
+// with the external CLI carry on where they left off.
+const versionTable = "schema_migrations"
+
+// pgUndefinedTable is the SQLSTATE of a query on a missing table.
+const pgUndefinedTable = "42P01"
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+var (
+	// ErrDirty is returned when a previous migration failed half-way. Inspect the schema,
+	// fix it by hand and then Force the version it is actually at.
+	ErrDirty = errors.New("migrate: database is dirty, fix it manually and force a version")
+	// ErrUnknownVersion is returned for a version with no migration files, including a
+	// database migrated by a newer build.
+	ErrUnknownVersion = errors.New("migrate: unknown version")
+	// ErrPending is returned by CheckUpToDate when migrations of this build aren't applied yet.
+	ErrPending = errors.New("migrate: pending migrations")
+)
+
+// Migration is one version with its up and down SQL.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus tells whether a migration is applied.
+type MigrationStatus struct {
+	Version uint64
+	Name    string
+	Applied bool
+}
+
+// Status describes the state of the database schema.
+type Status struct {
+	Version    uint64 // 0 when nothing is applied
+	Dirty      bool
+	Migrations []MigrationStatus
+}
+
+// Load reads and pairs the migration files of every source, sorted by version.
+// Every version needs both an up and a down file, and may only be defined once across sources.
+func Load(sources ...fs.FS) ([]Migration, error) {
+	byVersion := make(map[uint64]*Migration)
+	for _, fsys := range sources {
+		if err := loadSource(fsys, byVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migrate: version %d (%s) needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// loadSource adds the migration files of fsys to byVersion.
+func loadSource(fsys fs.FS, byVersion map[uint64]*Migration) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return fmt.Errorf("migrate: reading migrations: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m := fileNamePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue // Not a migration (e.g. the Go file embedding them)
+		}
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil || version == 0 {
+			return fmt.Errorf("migrate: invalid version in %s", entry.Name())
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return fmt.Errorf("migrate: reading %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		} else if migration.Name != m[2] {
+			return fmt.Errorf("migrate: version %d has two names: %s and %s", version, migration.Name, m[2])
+		}
+		target := &migration.Down
+		if m[3] == "up" {
+			target = &migration.Up
+		}
+		if *target != "" {
+			return fmt.Errorf("migrate: %s is defined twice", entry.Name())
+		}
+		*target = string(body)
+	}
+	return nil
+}
+
+// Runner applies migrations to a PostgreSQL database.
+// Each migration runs in its own transaction together with the version bump.
+type Runner struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     *zap.Logger
+}
+
+// NewRunner loads the migrations of sources (see Load).
+func NewRunner(db *sql.DB, sources []fs.FS, logger *zap.Logger) (*Runner, error) {
+	migrations, err := Load(sources...)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{db: db, migrations: migrations, logger: logger.Named("Migrate")}, nil
+}
+
+// Up applies every pending migration and returns how many were applied.
+func (r *Runner) Up(ctx context.Context) (int, error) {
+	latest := uint64(0)
+	if len(r.migrations) > 0 {
+		latest = r.migrations[len(r.migrations)-1].Version
+	}
+	return r.migrateTo(ctx, latest, false)
+}
+
+// CheckUpToDate returns ErrPending if migrations of this build aren't applied, including when
+// the database was never migrated, or ErrDirty. A database migrated by a newer build passes:
+// during a rolling deployment, the instances of the previous build keep running against it.
+// Unlike the other methods, it doesn't wait for the migration lock, so that it can be polled
+// while migrations run.
+func (r *Runner) CheckUpToDate(ctx context.Context) error {
+	current, dirty, err := readVersion(ctx, r.db)
+	if isUndefinedTable(err) {
+		current, dirty, err = 0, false, nil // No migration was ever applied
+	}
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return ErrDirty
+	}
+	if len(r.migrations) > 0 {
+		if latest := r.migrations[len(r.migrations)-1].Version; current < latest {
+			return fmt.Errorf("%w: database is at %d, this build at %d", ErrPending, current, latest)
+		}
+	}
+	return nil
+}
+
+// Down reverts the last steps applied migrations and returns how many were reverted.
+func (r *Runner) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, nil
+	}
+	var reverted int
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		current, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := r.checkCurrent(current, dirty); err != nil {
+			return err
+		}
+		target := uint64(0)
+		idx := r.index(current)
+		if idx-steps >= 0 {
+			target = r.migrations[idx-steps].Version
+		}
+		reverted, err = r.apply(ctx, conn, current, target)
+		return err
+	})
+	return reverted, err
+}
+
+// Goto migrates up or down to exactly version (0 reverts everything).
+func (r *Runner) Goto(ctx context.Context, version uint64) (int, error) {
+	if version != 0 && r.index(version) < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return r.migrateTo(ctx, version, true)
+}
+
+// Force records version as the current, clean version without running any SQL.
+// It is the way out of a dirty state after the schema has been repaired by hand.
+func (r *Runner) Force(ctx context.Context, version uint64) error {
+	if version != 0 && r.index(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return r.withLock(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("migrate: beginning transaction: %w", err)
+		}
+		defer func() { _ = tx.Rollback() }()
+		if err := writeVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// Status reports the current version and which migrations are applied.
+func (r *Runner) Status(ctx context.Context) (Status, error) {
+	var status Status
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		current, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		status.Version, status.Dirty = current, dirty
+		for _, m := range r.migrations {
+			status.Migrations = append(status.Migrations, MigrationStatus{Version: m.Version, Name: m.Name, Applied: m.Version <= current})
+		}
+		return nil
+	})
+	return status, err
+}
+
+// migrateTo moves to target. Without allowDown, a target below the current version is a no-op.
+func (r *Runner) migrateTo(ctx context.Context, target uint64, allowDown bool) (int, error) {
+	var applied int
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		current, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if target < current && !allowDown && !dirty {
+			// A newer build already migrated further; leave its schema alone.
+			r.logger.Warn("Database is ahead of this build's migrations", zap.Uint64("version", current), zap.Uint64("latest", target))
+			return nil
+		}
+		if err := r.checkCurrent(current, dirty); err != nil {
+			return err
+		}
+		applied, err = r.apply(ctx, conn, current, target)
+		return err
+	})
+	return applied, err
+}
+
+// apply runs the up migrations in (current, target] or the down migrations in (target, current].
+func (r *Runner) apply(ctx context.Context, conn *sql.Conn, current, target uint64) (int, error) {
+	count := 0
+	if target >= current {
+		for _, m := range r.migrations {
+			if m.Version <= current || m.Version > target {
+				continue
+			}
+			if err := r.step(ctx, conn, m.Up, m.Version); err != nil {
+				return count, fmt.Errorf("migrate: applying %d_%s: %w", m.Version, m.Name, err)
+			}
+			r.logger.Info("Applied migration", zap.Uint64("version", m.Version), zap.String("name", m.Name))
+			count++
+		}
+		return count, nil
+	}
+
+	for i := len(r.migrations) - 1; i >= 0; i-- {
+		m := r.migrations[i]
+		if m.Version > current || m.Version <= target {
+			continue
+		}
+		previous := uint64(0)
+		if i > 0 {
+			previous = r.migrations[i-1].Version
+		}
+		if err := r.step(ctx, conn, m.Down, previous); err != nil {
+			return count, fmt.Errorf("migrate: reverting %d_%s: %w", m.Version, m.Name, err)
+		}
+		r.logger.Info("Reverted migration", zap.Uint64("version", m.Version), zap.String("name", m.Name))
+		count++
+	}
+	return count, nil
+}
+
+// step runs one migration's SQL and records newVersion in the same transaction,
+// so a failure leaves both the schema and the version untouched.
+func (r *Runner) step(ctx context.Context, conn *sql.Conn, body string, newVersion uint64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+	if err := writeVersion(ctx, tx, newVersion); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock.
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: acquiring connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("migrate: acquiring lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context: the lock must be released even if ctx was cancelled.
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			r.logger.Warn("Failed to release migration lock", zap.Error(err))
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+versionTable+` (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("migrate: creating version table: %w", err)
+	}
+	return fn(conn)
+}
+
+// checkCurrent refuses to touch a dirty database or one at a version this build doesn't know,
+// which would otherwise be treated as "nothing applied".
+func (r *Runner) checkCurrent(current uint64, dirty bool) error {
+	if dirty {
+		return ErrDirty
+	}
+	if current != 0 && r.index(current) < 0 {
+		return fmt.Errorf("%w: database is at %d", ErrUnknownVersion, current)
+	}
+	return nil
+}
+
+// index returns the position of version in r.migrations, or -1.
+func (r *Runner) index(version uint64) int {
+	for i, m := range r.migrations {
+		if m.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func readVersion(ctx context.Context, conn queryer) (version uint64, dirty bool, err error) {
+	err = conn.QueryRowContext(ctx, `SELECT version, dirty FROM `+versionTable+` LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("migrate: reading version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// isUndefinedTable reports whether err is about a missing table: SQLSTATE 42P01 in Postgres,
+// "no such table" in SQLite.
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUndefinedTable
+	}
+	return err != nil && strings.Contains(err.Error(), "no such table")
+}
+
+// queryer is a *sql.Conn or a *sql.DB.
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// writeVersion replaces the single version row. Version 0 is stored as no row, like golang-migrate does.
+func writeVersion(ctx context.Context, tx *sql.Tx, version uint64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+versionTable); err != nil {
+		return fmt.Errorf("migrate: clearing version: %w", err)
+	}
+	if version == 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO `+versionTable+` (version, dirty) VALUES ($1, false)`, int64(version)); err != nil {
+		return fmt.Errorf("migrate: writing version: %w", err)
+	}
+	return nil
+}
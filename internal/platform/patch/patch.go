@@ -0,0 +1,163 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package patch /youGo/internal/platform/patch/patch.go
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Supported patch media types.
+const (
+	MediaTypeMergePatch = "application/merge-patch+json" // RFC 7396
+	MediaTypeJSONPatch  = "application/json-patch+json"  // RFC 6902
+)
+
+var (
+	// ErrUnsupportedMediaType is returned when the Content-Type is not a known patch format.
+	ErrUnsupportedMediaType = errors.New("patch: unsupported media type")
+	// ErrInvalidPatch is returned when the patch document is malformed or cannot be applied.
+	ErrInvalidPatch = errors.New("patch: invalid patch document")
+	// ErrTestFailed is returned when a JSON Patch "test" operation does not match.
+	ErrTestFailed = errors.New("patch: test operation failed")
+)
+
+// PathNotAllowedError indicates the patch touches a path outside the allowed whitelist.
+type PathNotAllowedError struct {
+	Path string
+}
+
+// Error implements the error interface for PathNotAllowedError.
+func (e *PathNotAllowedError) Error() string {
+	return fmt.Sprintf("patch: path %q is not patchable", e.Path)
+}
+
+// MediaType extracts the bare media type from a Content-Type header value
+// and checks it is one of the supported patch formats.
+func MediaType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrUnsupportedMediaType
+	}
+	switch mediaType {
+	case MediaTypeMergePatch, MediaTypeJSONPatch:
+		return mediaType, nil
+	default:
+		return "", ErrUnsupportedMediaType
+	}
+}
+
+// Apply applies patchDoc (encoded as mediaType) to doc and returns the patched document.
+// Every path touched by the patch must equal, or be nested below, one of the allowed
+// JSON Pointers (e.g. "/name"); otherwise a *PathNotAllowedError is returned and doc is left untouched.
+func Apply(mediaType string, doc, patchDoc []byte, allowed []string) ([]byte, error) {
+	switch mediaType {
+	case MediaTypeMergePatch:
+		return applyMergePatch(doc, patchDoc, allowed)
+	case MediaTypeJSONPatch:
+		return applyJSONPatch(doc, patchDoc, allowed)
+	default:
+		return nil, ErrUnsupportedMediaType
+	}
+}
+
+func applyMergePatch(doc, patchDoc []byte, allowed []string) ([]byte, error) {
+	var raw interface{}
+	if err := json.Unmarshal(patchDoc, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	// A non-object patch would replace the whole document: it can't be a partial update.
+	if _, ok := raw.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("%w: a merge patch must be a JSON object", ErrInvalidPatch)
+	}
+	// Check every leaf path before touching the document.
+	for _, p := range mergePatchPaths("", raw) {
+		if !isAllowed(p, allowed) {
+			return nil, &PathNotAllowedError{Path: p}
+		}
+	}
+
+	patched, err := jsonpatch.MergePatch(doc, patchDoc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return patched, nil
+}
+
+func applyJSONPatch(doc, patchDoc []byte, allowed []string) ([]byte, error) {
+	ops, err := jsonpatch.DecodePatch(patchDoc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	for _, op := range ops {
+		p, err := op.Path()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		if !isAllowed(p, allowed) {
+			return nil, &PathNotAllowedError{Path: p}
+		}
+		// move and copy read from a second location; it must be patchable too.
+		if kind := op.Kind(); kind == "move" || kind == "copy" {
+			from, err := op.From()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+			}
+			if !isAllowed(from, allowed) {
+				return nil, &PathNotAllowedError{Path: from}
+			}
+		}
+	}
+
+	patched, err := ops.Apply(doc)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, ErrTestFailed
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return patched, nil
+}
+
+// mergePatchPaths lists the JSON Pointers of every leaf member set by a merge patch.
+func mergePatchPaths(prefix string, v interface{}) []string {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return []string{prefix}
+	}
+	if len(obj) == 0 && prefix != "" {
+		return []string{prefix}
+	}
+	var paths []string
+	for key, child := range obj {
+		p := prefix + "/" + escapePointerToken(key)
+		if _, nested := child.(map[string]interface{}); nested {
+			paths = append(paths, mergePatchPaths(p, child)...)
+			continue
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// escapePointerToken escapes a member name per RFC 6901.
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func isAllowed(path string, allowed []string) bool {
+	for _, a := range allowed {
+		if path == a || strings.HasPrefix(path, a+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package ratelimit /youGo/internal/platform/ratelimit/ratelimit.go
+// Package ratelimit limits how many requests a client makes per window of time, with a
+// sliding window: the requests of the current fixed window, plus those of the previous one
+// weighted by how much of it the sliding window still covers. Counters live in a Store,
+// in memory or in Redis, so that instances can share them.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Policy is the limit of requests a client may make per window.
+type Policy struct {
+	Name     string        // e.g. "auth"; counters are per policy
+	Requests int           // Requests allowed per window
+	Window   time.Duration // e.g. time.Minute
+}
+
+// String formats p as the RateLimit-Policy header does, e.g. "10;w=60".
+func (p Policy) String() string {
+	return fmt.Sprintf("%d;w=%d", p.Requests, int(math.Ceil(p.Window.Seconds())))
+}
+
+// Result is the outcome of a request against a policy.
+type Result struct {
+	Policy     Policy
+	Allowed    bool
+	Remaining  int           // Requests left in the window
+	Reset      time.Duration // Until the current fixed window ends
+	RetryAfter time.Duration // Until a request is allowed again, if it isn't
+}
+
+// Store counts the requests of each key in fixed windows. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Increment counts a request of key in the window of the given length starting at start,
+	// and returns the count of that window and of the previous one.
+	Increment(ctx context.Context, key string, window time.Duration, start time.Time) (current, previous int64, err error)
+}
+
+// Limiter applies policies to the requests of clients.
+type Limiter struct {
+	store Store
+	Now   func() time.Time // The clock, time.Now unless replaced (e.g. in tests)
+}
+
+// NewLimiter creates a limiter keeping its counters in store.
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, Now: time.Now}
+}
+
+// Allow counts a request of the client identified by key against policy. Rejected requests
+// are counted too, so that a client retrying without pause stays limited.
+func (l *Limiter) Allow(ctx context.Context, policy Policy, key string) (Result, error) {
+	now := l.Now()
+	start := now.Truncate(policy.Window)
+	current, previous, err := l.store.Increment(ctx, policy.Name+":"+key, policy.Window, start)
+	if err != nil {
+		return Result{Policy: policy, Allowed: true, Remaining: policy.Requests}, err
+	}
+
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(policy.Window) // Share of the previous window still covered
+	count := float64(previous)*weight + float64(current)
+	limit := float64(policy.Requests)
+	result := Result{
+		Policy:    policy,
+		Allowed:   count <= limit,
+		Remaining: max(0, policy.Requests-int(math.Ceil(count))),
+		Reset:     policy.Window - elapsed,
+	}
+	if !result.Allowed {
+		result.RetryAfter = retryAfter(count+1-limit, float64(previous), float64(current), limit, policy.Window, elapsed)
+	}
+	return result, nil
+}
+
+// retryAfter returns how long it takes for excess requests to leave the sliding window, making
+// room for one more: the previous window slides out at a constant rate; if that isn't enough,
+// the current window has to end, and then slide out in turn.
+func retryAfter(excess, previous, current, limit float64, window, elapsed time.Duration) time.Duration {
+	if previous > 0 {
+		if wait := time.Duration(excess / previous * float64(window)); elapsed+wait < window {
+			return wait
+		}
+	}
+	// In the next window, current becomes the previous count: wait until current*weight+1 <= limit
+	wait := window - elapsed
+	if current > limit-1 {
+		wait += time.Duration((1 - (limit-1)/current) * float64(window))
+	}
+	return wait
+}
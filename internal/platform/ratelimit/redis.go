@@ -0,0 +1,123 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package ratelimit /youGo/internal/platform/ratelimit/redis.go
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IncrExpireScript is the Lua script of RedisClient.IncrExpire, for EVAL or EVALSHA with the
+// key as KEYS[1] and the TTL in milliseconds as ARGV[1].
+const IncrExpireScript = `local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n`
+
+// RedisClient is the subset of Redis commands RedisStore needs. Adapt the client of your
+// choice to it, e.g. go-redis's *redis.Client, mapping a missing key (redis.Nil) to 0.
+type RedisClient interface {
+	// IncrExpire is INCR, adding one to key, created at 0 if missing, and returning the new
+	// value, followed when the key is created by PEXPIRE, for key to be deleted once ttl
+	// elapsed. Both must run at once, e.g. as IncrExpireScript: a key INCR created that
+	// PEXPIRE then failed to expire would never be deleted, limiting its client for good.
+	IncrExpire(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// GetInt is GET of a key holding an integer; a missing key is 0.
+	GetInt(ctx context.Context, key string) (int64, error)
+}
+
+// RedisStore keeps the counters in Redis, shared by every instance of the application.
+type RedisStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisStore creates a store of counters named prefix+key, e.g. "ratelimit:".
+func NewRedisStore(client RedisClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Increment implements Store.
+func (s *RedisStore) Increment(ctx context.Context, key string, window time.Duration, start time.Time) (int64, int64, error) {
+	currentKey := s.prefix + windowKey(key, start)
+	// The counter is still needed during the next window
+	current, err := s.client.IncrExpire(ctx, currentKey, 2*window)
+	if err != nil {
+		return 0, 0, fmt.Errorf("ratelimit: INCR %s: %w", currentKey, err)
+	}
+	previousKey := s.prefix + windowKey(key, start.Add(-window))
+	previous, err := s.client.GetInt(ctx, previousKey)
+	if err != nil {
+		return 0, 0, fmt.Errorf("ratelimit: GET %s: %w", previousKey, err)
+	}
+	return current, previous, nil
+}
+
+// FakeRedis is an in-process RedisClient, for tests and local development. Err, when set,
+// fails every command, like an unreachable server.
+type FakeRedis struct {
+	mu     sync.Mutex
+	values map[string]int64
+	expiry map[string]time.Time
+	Now    func() time.Time
+	Err    error
+}
+
+// NewFakeRedis creates an empty FakeRedis on the system clock.
+func NewFakeRedis() *FakeRedis {
+	return &FakeRedis{values: make(map[string]int64), expiry: make(map[string]time.Time), Now: time.Now}
+}
+
+// IncrExpire implements RedisClient.
+func (r *FakeRedis) IncrExpire(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return 0, r.Err
+	}
+	r.expire(key)
+	r.values[key]++
+	if r.values[key] == 1 {
+		r.expiry[key] = r.Now().Add(ttl)
+	}
+	return r.values[key], nil
+}
+
+// GetInt implements RedisClient.
+func (r *FakeRedis) GetInt(_ context.Context, key string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return 0, r.Err
+	}
+	r.expire(key)
+	return r.values[key], nil
+}
+
+// Keys returns the keys that haven't expired, as KEYS * does.
+func (r *FakeRedis) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]string, 0, len(r.values))
+	for key := range r.values {
+		r.expire(key)
+		if _, ok := r.values[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// expire deletes key if its time to live elapsed.
+func (r *FakeRedis) expire(key string) {
+	if expiresAt, ok := r.expiry[key]; ok && !r.Now().Before(expiresAt) {
+		delete(r.values, key)
+		delete(r.expiry, key)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package ratelimit /youGo/internal/platform/ratelimit/store.go
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the counters in process memory: each instance limits on its own.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]int64 // "<key>@<window start>" -> requests
+	expiry    map[string]time.Time
+	nextSweep time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]int64), expiry: make(map[string]time.Time)}
+}
+
+// Increment implements Store. Counters are dropped once their window no longer counts, by a
+// sweep at most once a minute (of the windows' clock).
+func (s *MemoryStore) Increment(_ context.Context, key string, window time.Duration, start time.Time) (int64, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if start.After(s.nextSweep) {
+		for k, expiresAt := range s.expiry {
+			if start.After(expiresAt) {
+				delete(s.counters, k)
+				delete(s.expiry, k)
+			}
+		}
+		s.nextSweep = start.Add(time.Minute)
+	}
+
+	currentKey := windowKey(key, start)
+	s.counters[currentKey]++
+	s.expiry[currentKey] = start.Add(2 * window) // Still the previous window during the next one
+	return s.counters[currentKey], s.counters[windowKey(key, start.Add(-window))], nil
+}
+
+// windowKey names the counter of key in the window starting at start.
+func windowKey(key string, start time.Time) string {
+	return key + "@" + strconv.FormatInt(start.UnixMilli(), 10)
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package requestctx /youGo/internal/platform/requestctx/requestctx.go
+package requestctx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Metadata carries request-scoped details that layers below the HTTP handlers
+// (services, repositories, loggers) need without depending on echo.Context.
+type Metadata struct {
+	RequestID string
+	TraceID   string // W3C trace ID of the request's span, or of its traceparent header; "" without either
+	IP        string
+	UserAgent string
+	ActorID   uuid.UUID // uuid.Nil when the request is unauthenticated
+	Locale    string    // Language the authenticated caller chose for messages; "" without a choice
+}
+
+// ctxKey is unexported so no other package can collide with it.
+type ctxKey struct{}
+
+// WithMetadata returns a copy of ctx carrying m.
+func WithMetadata(ctx context.Context, m Metadata) context.Context {
+	return context.WithValue(ctx, ctxKey{}, m)
+}
+
+// FromContext returns the Metadata stored in ctx, or the zero value if none was set.
+func FromContext(ctx context.Context) Metadata {
+	m, _ := ctx.Value(ctxKey{}).(Metadata)
+	return m
+}
+
+// WithActor returns a copy of ctx whose Metadata records actorID as the authenticated caller.
+func WithActor(ctx context.Context, actorID uuid.UUID) context.Context {
+	m := FromContext(ctx)
+	m.ActorID = actorID
+	return WithMetadata(ctx, m)
+}
+
+// WithLocale returns a copy of ctx whose Metadata records locale as the language the
+// authenticated caller chose.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	m := FromContext(ctx)
+	m.Locale = locale
+	return WithMetadata(ctx, m)
+}
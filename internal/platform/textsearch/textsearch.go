@@ -0,0 +1,81 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package textsearch /youGo/internal/platform/textsearch/textsearch.go
+// Package textsearch splits search input into terms and highlights them in results, the same
+// way for every search backend.
+package textsearch
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// MaxTerms is the number of terms of a search; further words are ignored.
+const MaxTerms = 8
+
+// Highlight markers around the matches of a highlighted text.
+const (
+	MarkStart = "<mark>"
+	MarkEnd   = "</mark>"
+)
+
+// Terms returns the distinct words of text, lowercased. Words are runs of letters and digits,
+// so "Jane.Doe@Example.com" gives jane, doe, example and com.
+func Terms(text string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		term := strings.Map(unicode.ToLower, word)
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+		if len(terms) == MaxTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// Highlight wraps the occurrences of terms in text (ignoring case) in MarkStart and MarkEnd.
+// The rest of the text is HTML-escaped, so the result can be rendered as HTML.
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	marked := make([]bool, len(runes))
+	for _, term := range terms {
+		t := []rune(strings.Map(unicode.ToLower, term))
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == string(t) {
+				for j := i; j < i+len(t); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			segment = MarkStart + segment + MarkEnd
+		}
+		b.WriteString(segment)
+		i = j
+	}
+	return b.String()
+}
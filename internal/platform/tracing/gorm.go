@@ -0,0 +1,96 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package tracing /youGo/internal/platform/tracing/gorm.go
+package tracing
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey is the instance key the span of a statement is kept under between its callbacks.
+const gormSpanKey = "tracing:span"
+
+// InstrumentGORM makes every statement of db a client span, child of the span of the
+// statement's context, named after the operation ("gorm.query", "gorm.create", ...) and carrying
+// the table, the SQL and the rows affected. Bind parameters aren't recorded: the SQL shows their
+// placeholders, as in the logs.
+func InstrumentGORM(db *gorm.DB) error {
+	system := dbSystem(db.Dialector.Name())
+	callbacks := db.Callback()
+	if err := errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:start", startStatement("create", system)),
+		callbacks.Create().After("gorm:create").Register("tracing:end", endStatement),
+		callbacks.Query().Before("gorm:query").Register("tracing:start", startStatement("query", system)),
+		callbacks.Query().After("gorm:query").Register("tracing:end", endStatement),
+		callbacks.Update().Before("gorm:update").Register("tracing:start", startStatement("update", system)),
+		callbacks.Update().After("gorm:update").Register("tracing:end", endStatement),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:start", startStatement("delete", system)),
+		callbacks.Delete().After("gorm:delete").Register("tracing:end", endStatement),
+		callbacks.Row().Before("gorm:row").Register("tracing:start", startStatement("row", system)),
+		callbacks.Row().After("gorm:row").Register("tracing:end", endStatement),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:start", startStatement("raw", system)),
+		callbacks.Raw().After("gorm:raw").Register("tracing:end", endStatement),
+	); err != nil {
+		return fmt.Errorf("tracing: registering the GORM callbacks: %w", err)
+	}
+	return nil
+}
+
+// startStatement starts the span of a statement about to run. The span is kept on the
+// statement rather than in its context, which transactions share between their statements.
+func startStatement(op string, system attribute.KeyValue) func(tx *gorm.DB) {
+	return func(tx *gorm.DB) {
+		ctx := tx.Statement.Context
+		if ctx == nil {
+			return
+		}
+		_, span := tracer().Start(ctx, "gorm."+op, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+			system,
+			semconv.DBOperationName(op),
+		))
+		tx.InstanceSet(gormSpanKey, span)
+	}
+}
+
+// endStatement ends the span of a statement that ran, with its outcome.
+func endStatement(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	if span.IsRecording() {
+		if tx.Statement.Table != "" {
+			span.SetAttributes(semconv.DBCollectionName(tx.Statement.Table))
+		}
+		span.SetAttributes(
+			semconv.DBQueryText(tx.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+		)
+	}
+	err := tx.Statement.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil // An outcome, not a failure of the statement
+	}
+	End(span, &err)
+}
+
+// dbSystem returns the db.system attribute of a GORM dialect.
+func dbSystem(dialect string) attribute.KeyValue {
+	switch dialect {
+	case "postgres":
+		return semconv.DBSystemPostgreSQL
+	case "sqlite":
+		return semconv.DBSystemSqlite
+	default:
+		return semconv.DBSystemKey.String(dialect)
+	}
+}
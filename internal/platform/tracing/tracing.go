@@ -0,0 +1,116 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package tracing /youGo/internal/platform/tracing/tracing.go
+// Package tracing sets up OpenTelemetry tracing: the tracer provider and its exporter, the W3C
+// propagation of traceparent, and the spans of GORM statements (InstrumentGORM) and outbound
+// HTTP calls (Transport). The server spans come from middleware.Tracing.
+//
+// Until Setup runs, as when tracing is disabled, spans are no-ops and cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"youGo/internal/config"
+	"youGo/internal/platform/buildinfo"
+)
+
+// instrumentationName names the tracer of the application's spans.
+const instrumentationName = "youGo"
+
+// tracer returns the tracer of the global provider, the one installed by Setup if it ran.
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Stdout is where the stdout exporter writes; tests replace it.
+var Stdout io.Writer = os.Stdout
+
+// Setup installs the tracer provider of cfg, exporting spans in batches, and the W3C
+// TraceContext and Baggage propagators as the global ones. env is recorded as the
+// deployment.environment of the spans. The returned shutdown flushes the pending spans.
+func Setup(ctx context.Context, cfg config.TracingConfig, env string) (shutdown func(context.Context) error, err error) {
+	var exporter sdktrace.SpanExporter
+	switch cfg.ExporterName() {
+	case config.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(Stdout), stdouttrace.WithPrettyPrint())
+	case config.TracingExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: creating the %s exporter: %w", cfg.ExporterName(), err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceNameOrDefault()),
+			semconv.ServiceVersion(buildinfo.Get().Version),
+			semconv.DeploymentEnvironment(env),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: describing the service: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Requests carrying a traceparent follow the sampling decision of their caller
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatioOrDefault()))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name, child of the span of ctx if any, and returns ctx carrying it.
+// Services name their spans "<Service>.<Method>", e.g. "UserService.Create".
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, opts...)
+}
+
+// End records the error *err points to, if any, on span and ends it. Deferred by functions
+// with a named error result:
+//
+//	ctx, span := tracing.Start(ctx, "UserService.Create")
+//	defer tracing.End(span, &err)
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// LogFields returns the trace ID and span ID of the span of ctx as zap fields, none if ctx has
+// no span, so that log lines can be matched with traces.
+func LogFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String())}
+}
@@ -6,30 +6,215 @@
 package validator
 
 import (
-	"net/http"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/labstack/echo/v4"
+
+	"youGo/internal/domain"
+	"youGo/internal/platform/i18n"
+)
+
+// nameTags are the struct tags naming a field in requests, by priority.
+var nameTags = []string{"json", "query", "param", "form"}
+
+var (
+	// sizeRules compare a length, a number of items or a number, see kindOf.
+	sizeRules = []string{"min", "max", "len"}
+	// fieldRules compare the field to another one, {other}.
+	fieldRules = []string{"eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield"}
+	// rulesWithMessages are the other rules whose message tells what they expect.
+	rulesWithMessages = []string{"required", "email", "url", "uuid", "datetime", "oneof", "locale", "eqfield", "nefield"}
 )
 
 // CustomValidator wraps the validator library
 type CustomValidator struct {
 	validator *validator.Validate
+	messages  *i18n.Catalogue
 }
 
-// NewValidator creates a new instance of CustomValidator
+// NewValidator creates a new instance of CustomValidator. Fields are reported under the names
+// clients use (json, query, param or form tag), with default messages in i18n.DefaultLocale.
+// Besides the built-in rules, "locale" accepts the locales of the i18n catalogue.
 func NewValidator() *CustomValidator {
-	return &CustomValidator{validator: validator.New()}
+	messages := i18n.Default()
+	v := validator.New()
+	v.RegisterTagNameFunc(fieldName)
+	_ = v.RegisterValidation("locale", func(fl validator.FieldLevel) bool {
+		return slices.Contains(messages.Locales(), fl.Field().String())
+	}) // Fails only for an invalid tag name
+	return &CustomValidator{validator: v, messages: messages}
+}
+
+// MessageKeys returns the keys of the messages of the rules that have one, for checking that
+// each locale translates them.
+func MessageKeys() []string {
+	keys := []string{"validation.invalid"}
+	for _, rule := range rulesWithMessages {
+		keys = append(keys, "validation."+rule)
+	}
+	for _, rule := range sizeRules {
+		for _, kind := range []string{"string", "items", "number"} {
+			keys = append(keys, "validation."+rule+"."+kind)
+		}
+	}
+	return keys
 }
 
-// Validate implements the echo.Validator interface
+// Validate implements the echo.Validator interface. Failed rules are reported as a
+// *domain.ValidationError holding one violation per rule, which the error handler turns into a 422.
 func (cv *CustomValidator) Validate(i interface{}) error {
-	if err := cv.validator.Struct(i); err != nil {
-		// Optionally, you can return echo.NewHTTPError to provide specific HTTP errors
-		// Here, we return a generic validation error message, or the specific error
-		// You might want to customize error formatting here later
-		return echo.NewHTTPError(http.StatusUnprocessableEntity, "Input validation failed: "+err.Error())
-		// return err // Alternatively, return the raw validator error
+	err := cv.validator.Struct(i)
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err // nil, or a misuse such as validating a non-struct
+	}
+
+	failures := domain.NewValidationError()
+	root := reflect.TypeOf(i)
+	for _, fieldErr := range validationErrors {
+		v := violation(root, fieldErr)
+		v.Message = Message(cv.messages, cv.messages.Fallback(), v)
+		failures.AddViolation(v)
+	}
+	return failures
+}
+
+// Message returns the message of v in locale. Violations of rules without a message of their
+// own get a generic one, and those recorded without a code keep their message.
+func Message(messages *i18n.Catalogue, locale string, v domain.FieldViolation) string {
+	if v.Code == "" {
+		return v.Message
+	}
+	params := map[string]string{"field": v.Path, "code": v.Code}
+	for name, value := range v.Params {
+		params[name] = value
+	}
+	if limit, ok := v.Params["limit"]; ok {
+		params[i18n.CountParam] = limit // "at least 1 character", "at least 8 characters"
+	}
+	keys := []string{"validation." + v.Code, "validation.invalid"}
+	if kind := v.Params["kind"]; kind != "" {
+		keys = append([]string{"validation." + v.Code + "." + kind}, keys...)
+	}
+	for _, key := range keys {
+		if message, ok := messages.Message(locale, key, params); ok {
+			return message
+		}
+	}
+	return v.Path + " is invalid (" + v.Code + ")"
+}
+
+// violation describes fieldErr, a failure in a value of type root.
+func violation(root reflect.Type, fieldErr validator.FieldError) domain.FieldViolation {
+	path, parent := fieldPath(root, fieldErr)
+	v := domain.FieldViolation{Path: path, Code: fieldErr.Tag()}
+	param := fieldErr.Param()
+
+	switch tag := fieldErr.Tag(); {
+	case slices.Contains(sizeRules, tag):
+		v.Params = map[string]string{"limit": param, "kind": kindOf(fieldErr.Kind())}
+	case tag == "locale":
+		v.Params = map[string]string{"values": strings.Join(i18n.Default().Locales(), ", ")}
+	case tag == "oneof":
+		v.Params = map[string]string{"values": strings.Join(strings.Fields(param), ", ")}
+	case tag == "datetime":
+		v.Params = map[string]string{"layout": param}
+	case slices.Contains(fieldRules, tag):
+		v.Params = map[string]string{"other": siblingName(parent, param)}
+	default:
+		if param != "" {
+			v.Params = map[string]string{"param": param}
+		}
+	}
+	return v
+}
+
+// fieldPath returns the JSON path of the field of fieldErr, e.g. "items[0].name", and the
+// struct type holding it. Embedded structs are flattened, as in JSON.
+func fieldPath(root reflect.Type, fieldErr validator.FieldError) (string, reflect.Type) {
+	// Both namespaces start with the name of the root type and have one segment per field,
+	// e.g. "CreateOrderRequest.items[0].name" and "CreateOrderRequest.Items[0].Name".
+	names := strings.Split(fieldErr.Namespace(), ".")[1:]
+	goNames := strings.Split(fieldErr.StructNamespace(), ".")[1:]
+	if len(names) != len(goNames) { // A map key holding a dot: give up on flattening
+		return strings.Join(names, "."), nil
+	}
+
+	var path []string
+	t := root
+	for i, goName := range goNames {
+		t = elem(t)
+		if t == nil || t.Kind() != reflect.Struct {
+			path = append(path, names[i:]...)
+			return strings.Join(path, "."), nil
+		}
+		field, ok := t.FieldByName(strings.SplitN(goName, "[", 2)[0])
+		if !ok {
+			path = append(path, names[i:]...)
+			return strings.Join(path, "."), nil
+		}
+		if i == len(goNames)-1 {
+			return strings.Join(append(path, names[i]), "."), t
+		}
+		if !field.Anonymous {
+			path = append(path, names[i])
+		}
+		t = field.Type
+	}
+	return strings.Join(path, "."), nil
+}
+
+// elem returns the type a path goes through in t: the element of pointers, slices and maps.
+func elem(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
 	}
 	return nil
 }
+
+// siblingName returns the request name of the Go field goName of parent, for the rules
+// comparing two fields.
+func siblingName(parent reflect.Type, goName string) string {
+	if parent != nil {
+		if field, ok := parent.FieldByName(goName); ok {
+			if name := fieldName(field); name != "" {
+				return name
+			}
+		}
+	}
+	return strings.ToLower(goName)
+}
+
+// fieldName is the name of field in requests: the name given by its first naming tag.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range nameTags {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return "" // The validator falls back on the Go name
+}
+
+// kindOf groups the kinds of values whose size rules read alike.
+func kindOf(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	default:
+		return "number"
+	}
+}
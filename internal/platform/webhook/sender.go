@@ -0,0 +1,76 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package webhook /youGo/internal/platform/webhook/sender.go
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxResponseBody bounds how much of a receiver's response is read for the delivery log.
+const maxResponseBody = 4 << 10
+
+// Request is a single signed delivery attempt.
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventID    string
+	EventType  string
+	Body       []byte
+}
+
+// Result describes the receiver's answer. StatusCode is 0 if no response was received.
+type Result struct {
+	StatusCode int
+	Body       string // Truncated response body, for the delivery log
+}
+
+// Sender signs and POSTs webhook deliveries.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender creates a sender. Pass a client from httpclient.NewPublicHTTPClient, or from
+// httpclient.NewHTTPClient for receivers the operator trusts.
+func NewSender(client *http.Client) *Sender {
+	return &Sender{client: client, now: time.Now}
+}
+
+// Send performs one delivery attempt. A non-2xx answer is returned as an error alongside the Result.
+func (s *Sender) Send(ctx context.Context, r Request) (Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return Result{}, fmt.Errorf("webhook: building request: %w", err)
+	}
+	timestamp := s.now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "youGo-Webhooks/1.0")
+	req.Header.Set(HeaderTimestamp, fmt.Sprintf("%d", timestamp.Unix()))
+	req.Header.Set(HeaderSignature, Sign(r.Secret, timestamp, r.Body))
+	req.Header.Set(HeaderEventID, r.EventID)
+	req.Header.Set(HeaderEventType, r.EventType)
+	req.Header.Set(HeaderDelivery, r.DeliveryID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("webhook: delivering to %s: %w", r.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	_, _ = io.Copy(io.Discard, resp.Body) // Drain the rest so the connection can be reused
+	result := Result{StatusCode: resp.StatusCode, Body: string(body)}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return result, fmt.Errorf("webhook: %s answered with status %d", r.URL, resp.StatusCode)
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package webhook /youGo/internal/platform/webhook/signature.go
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every signed delivery.
+const (
+	HeaderTimestamp = "X-Webhook-Timestamp" // Unix seconds at signing time
+	HeaderSignature = "X-Webhook-Signature" // "v1=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+	HeaderEventID   = "X-Webhook-Event-ID"
+	HeaderEventType = "X-Webhook-Event-Type"
+	HeaderDelivery  = "X-Webhook-Delivery-ID"
+)
+
+const signatureVersion = "v1"
+
+// DefaultTolerance is how far a receiver should accept a timestamp from its own clock.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	// ErrInvalidSignature is returned by Verify when the signature does not match.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	// ErrTimestampOutOfRange is returned by Verify when the timestamp is too old or in the future.
+	ErrTimestampOutOfRange = errors.New("webhook: timestamp outside tolerance")
+)
+
+// Sign returns the signature header value for body signed at timestamp.
+// Including the timestamp in the MAC stops replays of old deliveries.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return signatureVersion + "=" + hex.EncodeToString(mac(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// Verify checks a delivery the way a receiver should: the timestamp must be within tolerance
+// of now and the signature must match. It is used in tests and documents the scheme for customers.
+func Verify(secret, timestampHeader, signatureHeader string, body []byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrTimestampOutOfRange
+	}
+	if delta := now.Sub(time.Unix(ts, 0)); delta > tolerance || delta < -tolerance {
+		return ErrTimestampOutOfRange
+	}
+
+	sig, ok := strings.CutPrefix(signatureHeader, signatureVersion+"=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, mac(secret, timestampHeader, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
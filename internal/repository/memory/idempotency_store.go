@@ -0,0 +1,87 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package memory /youGo/internal/repository/memory/idempotency_store.go
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"youGo/internal/domain"
+)
+
+// memoryIdempotencyStore implements domain.IdempotencyStore with a map guarded by a mutex.
+// Its locks only hold within the process: use it for a single instance.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]domain.IdempotencyRecord
+	now     func() time.Time
+}
+
+// NewIdempotencyStore creates an empty in-memory idempotency store.
+func NewIdempotencyStore() domain.IdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]domain.IdempotencyRecord), now: time.Now}
+}
+
+func (s *memoryIdempotencyStore) Acquire(_ context.Context, key, fingerprint string, lockedUntil time.Time) (*domain.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if record, ok := s.records[key]; ok && record.ExpiresAt.After(now) {
+		return copyIdempotencyRecord(record), nil
+	}
+	s.records[key] = domain.IdempotencyRecord{Key: key, Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: lockedUntil}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, record *domain.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lock, ok := s.records[record.Key]
+	if !ok || lock.Completed() || lock.Fingerprint != record.Fingerprint || !lock.ExpiresAt.After(s.now()) {
+		return domain.ErrNotFound
+	}
+	completed := *copyIdempotencyRecord(*record)
+	completed.CreatedAt = lock.CreatedAt
+	s.records[record.Key] = completed
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.records[key]; ok && !record.Completed() {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for key, record := range s.records {
+		if !record.ExpiresAt.After(now) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// copyIdempotencyRecord copies record, so that callers never share the stored headers and body.
+func copyIdempotencyRecord(record domain.IdempotencyRecord) *domain.IdempotencyRecord {
+	if record.Header != nil {
+		header := make(map[string][]string, len(record.Header))
+		for name, values := range record.Header {
+			header[name] = slices.Clone(values)
+		}
+		record.Header = header
+	}
+	record.Body = slices.Clone(record.Body)
+	return &record
+}
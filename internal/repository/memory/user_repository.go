@@ -0,0 +1,132 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package memory /youGo/internal/repository/memory/user_repository.go
+// Package memory holds repositories keeping their data in process memory, for tests and local
+// development. They behave like the database ones, constraints included, but ignore transactions:
+// a change is visible at once and isn't undone when the surrounding unit of work fails.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"youGo/internal/domain"
+)
+
+// memoryUserRepository implements domain.UserRepository with maps guarded by a mutex.
+// Users are copied in and out, so callers never share the stored values.
+type memoryUserRepository struct {
+	mu      sync.RWMutex
+	byID    map[uuid.UUID]domain.User
+	byEmail map[string]uuid.UUID // Emails are unique and case-sensitive, like the users_email_key constraint
+	now     func() time.Time
+}
+
+// NewUserRepository creates an empty in-memory user repository.
+func NewUserRepository() domain.UserRepository {
+	return &memoryUserRepository{
+		byID:    make(map[uuid.UUID]domain.User),
+		byEmail: make(map[string]uuid.UUID),
+		now:     time.Now,
+	}
+}
+
+func (r *memoryUserRepository) FindByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user, ok := r.byID[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &user, nil
+}
+
+// FindByIDForUpdate is FindByID: without transactions, there is nothing to lock until.
+func (r *memoryUserRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return r.FindByID(ctx, id)
+}
+
+func (r *memoryUserRepository) FindByEmail(_ context.Context, email string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.byEmail[email]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	user := r.byID[id]
+	return &user, nil
+}
+
+// Create stores the user, generating its ID and timestamps when they are zero, and copies them back.
+// A taken email is reported as domain.ErrDuplicateEntry.
+func (r *memoryUserRepository) Create(_ context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	if _, ok := r.byID[user.ID]; ok {
+		return &domain.ConstraintError{Kind: domain.ErrDuplicateEntry, Constraint: "users_pkey", Column: "id"}
+	}
+	if _, ok := r.byEmail[user.Email]; ok {
+		return emailTaken()
+	}
+	now := r.now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = now
+	}
+	r.byID[user.ID] = *user
+	r.byEmail[user.Email] = user.ID
+	return nil
+}
+
+// Update writes the mutable fields of user; its creation time is kept. It returns
+// domain.ErrNotFound if the user doesn't exist, domain.ErrDuplicateEntry if the new email is taken.
+func (r *memoryUserRepository) Update(_ context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.byID[user.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if owner, ok := r.byEmail[user.Email]; ok && owner != user.ID {
+		return emailTaken()
+	}
+	updated := *user
+	updated.CreatedAt = stored.CreatedAt
+	updated.UpdatedAt = r.now()
+	delete(r.byEmail, stored.Email)
+	r.byID[user.ID] = updated
+	r.byEmail[updated.Email] = user.ID
+	return nil
+}
+
+func (r *memoryUserRepository) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.byID[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.byID, id)
+	delete(r.byEmail, user.Email)
+	return nil
+}
+
+func (r *memoryUserRepository) Count(_ context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.byID)), nil
+}
+
+func emailTaken() error {
+	return &domain.ConstraintError{Kind: domain.ErrDuplicateEntry, Constraint: "users_email_key", Column: "email"}
+}
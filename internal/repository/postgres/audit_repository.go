@@ -0,0 +1,151 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/audit_repository.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+	"youGo/internal/repository/query"
+)
+
+// AuditEventModel defines the GORM database model for a row of the append-only audit_events table.
+type AuditEventModel struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActorID    *uuid.UUID `gorm:"type:uuid;index"`
+	Action     string     `gorm:"size:100;not null;index"`
+	TargetType string     `gorm:"size:50;not null"`
+	TargetID   string     `gorm:"size:255;not null"`
+	Changes    []byte     `gorm:"type:jsonb"` // JSON object of field -> {before, after}
+	RequestID  string     `gorm:"size:100"`
+	IP         string     `gorm:"size:64"`
+	OccurredAt time.Time  `gorm:"not null;index"`
+}
+
+// TableName explicitly sets the table name for the AuditEventModel struct.
+func (AuditEventModel) TableName() string {
+	return "audit_events"
+}
+
+// Columns of the audit_events table.
+var (
+	auditID         = query.NewColumn[uuid.UUID]("id")
+	auditActorID    = query.NewColumn[uuid.UUID]("actor_id")
+	auditAction     = query.NewText("action")
+	auditTargetType = query.NewText("target_type")
+	auditTargetID   = query.NewText("target_id")
+	auditOccurredAt = query.NewColumn[time.Time]("occurred_at")
+)
+
+// postgresAuditRepository implements domain.AuditRepository using GORM/Postgres.
+type postgresAuditRepository struct {
+	db   *gorm.DB
+	base *Repository[domain.AuditEvent, AuditEventModel]
+}
+
+// NewAuditRepository creates a new GORM/Postgres audit repository instance.
+func NewAuditRepository(db *gorm.DB) domain.AuditRepository {
+	return &postgresAuditRepository{
+		db:   db,
+		base: NewRepository(db, "audit events", Mapper[domain.AuditEvent, AuditEventModel]{ToDomain: toDomainAuditEvent, FromDomain: fromDomainAuditEvent}),
+	}
+}
+
+// --- Mapping Functions ---
+
+func toDomainAuditEvent(model *AuditEventModel) (*domain.AuditEvent, error) {
+	event := &domain.AuditEvent{
+		ID:         model.ID,
+		ActorID:    model.ActorID,
+		Action:     model.Action,
+		TargetType: model.TargetType,
+		TargetID:   model.TargetID,
+		RequestID:  model.RequestID,
+		IP:         model.IP,
+		OccurredAt: model.OccurredAt,
+	}
+	if len(model.Changes) > 0 {
+		if err := json.Unmarshal(model.Changes, &event.Changes); err != nil {
+			return nil, fmt.Errorf("decoding audit event changes [%s]: %w", model.ID, err)
+		}
+	}
+	return event, nil
+}
+
+func fromDomainAuditEvent(event *domain.AuditEvent) (*AuditEventModel, error) {
+	model := &AuditEventModel{
+		ID:         event.ID,
+		ActorID:    event.ActorID,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		RequestID:  event.RequestID,
+		IP:         event.IP,
+		OccurredAt: event.OccurredAt,
+	}
+	if len(event.Changes) > 0 {
+		changes, err := json.Marshal(event.Changes)
+		if err != nil {
+			return nil, fmt.Errorf("encoding audit event changes: %w", err)
+		}
+		model.Changes = changes
+	}
+	return model, nil
+}
+
+// --- Interface Implementation ---
+
+func (r *postgresAuditRepository) Append(ctx context.Context, event *domain.AuditEvent) error {
+	model, err := fromDomainAuditEvent(event)
+	if err != nil {
+		return err
+	}
+	if err := conn(ctx, r.db).Create(model).Error; err != nil {
+		return dbError(err, "appending audit event")
+	}
+	event.ID = model.ID
+	return nil
+}
+
+func (r *postgresAuditRepository) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, int64, error) {
+	var specs []query.Spec
+	if filter.ActorID != nil {
+		specs = append(specs, auditActorID.Eq(*filter.ActorID))
+	}
+	if filter.Action != "" {
+		specs = append(specs, auditAction.Eq(filter.Action))
+	}
+	if filter.TargetType != "" {
+		specs = append(specs, auditTargetType.Eq(filter.TargetType))
+	}
+	if filter.TargetID != "" {
+		specs = append(specs, auditTargetID.Eq(filter.TargetID))
+	}
+	if filter.From != nil {
+		specs = append(specs, auditOccurredAt.Gte(*filter.From))
+	}
+	if filter.To != nil {
+		specs = append(specs, auditOccurredAt.Lt(*filter.To))
+	}
+	if after := filter.After; after != nil {
+		// (occurred_at, id) < (after.OccurredAt, after.ID), in the order of the listing
+		specs = append(specs, query.Or(
+			auditOccurredAt.Lt(after.OccurredAt),
+			query.And(auditOccurredAt.Eq(after.OccurredAt), auditID.Lt(after.ID)),
+		))
+	}
+
+	return r.base.FindPage(ctx, query.Where(specs...).
+		OrderBy(auditOccurredAt.Desc(), auditID.Desc()).
+		Limit(filter.Limit).
+		Offset(filter.Offset))
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/errors.go
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+)
+
+// Postgres error codes (SQLSTATE) of constraint violations.
+const (
+	pgNotNullViolation    = "23502"
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+	pgExclusionViolation  = "23P01"
+)
+
+// MapError translates a database error into the domain error it stands for:
+// a missing row into domain.ErrNotFound and a constraint violation into a *domain.ConstraintError
+// of the matching kind. Other errors are returned unchanged.
+func MapError(err error) error {
+	mapped, _ := mapError(err)
+	return mapped
+}
+
+// mapError is MapError, also reporting whether err was translated.
+func mapError(err error) (error, bool) {
+	if err == nil {
+		return nil, false
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrNotFound, true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		constraintErr := &domain.ConstraintError{Constraint: pgErr.ConstraintName, Column: pgErr.ColumnName}
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			constraintErr.Kind = domain.ErrDuplicateEntry
+		case pgForeignKeyViolation:
+			constraintErr.Kind = domain.ErrInvalidReference
+		case pgCheckViolation, pgNotNullViolation, pgExclusionViolation:
+			constraintErr.Kind = domain.ErrConstraintViolation
+		default:
+			return err, false
+		}
+		return constraintErr, true
+	}
+
+	// Errors of other drivers, translated by GORM when gorm.Config.TranslateError is set
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return &domain.ConstraintError{Kind: domain.ErrDuplicateEntry}, true
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return &domain.ConstraintError{Kind: domain.ErrInvalidReference}, true
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return &domain.ConstraintError{Kind: domain.ErrConstraintViolation}, true
+	}
+	return err, false
+}
+
+// dbError maps err to a domain error, or wraps it with what was being done.
+func dbError(err error, format string, args ...any) error {
+	if mapped, ok := mapError(err); ok {
+		return mapped
+	}
+	return fmt.Errorf("db error "+format+": %w", append(args, err)...)
+}
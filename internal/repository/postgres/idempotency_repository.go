@@ -0,0 +1,144 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/idempotency_repository.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"youGo/internal/domain"
+)
+
+// IdempotencyKeyModel defines the GORM database model for a row of the idempotency_keys table.
+type IdempotencyKeyModel struct {
+	Key         string    `gorm:"primaryKey;size:64"`
+	Fingerprint string    `gorm:"size:64;not null"`
+	Status      int       `gorm:"not null;default:0"` // 0 while the request is in flight
+	Header      []byte    `gorm:"type:jsonb"`
+	Body        []byte    `gorm:"type:bytea"`
+	CreatedAt   time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+// TableName explicitly sets the table name for the IdempotencyKeyModel struct.
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+// postgresIdempotencyStore implements domain.IdempotencyStore using GORM. The statements are
+// portable, so it also runs on SQLite.
+type postgresIdempotencyStore struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewIdempotencyStore creates a new GORM idempotency store instance.
+func NewIdempotencyStore(db *gorm.DB) domain.IdempotencyStore {
+	return &postgresIdempotencyStore{db: db, now: time.Now}
+}
+
+// --- Mapping Functions ---
+
+func toDomainIdempotencyRecord(model *IdempotencyKeyModel) (*domain.IdempotencyRecord, error) {
+	record := &domain.IdempotencyRecord{
+		Key:         model.Key,
+		Fingerprint: model.Fingerprint,
+		Status:      model.Status,
+		Body:        model.Body,
+		CreatedAt:   model.CreatedAt,
+		ExpiresAt:   model.ExpiresAt,
+	}
+	if len(model.Header) > 0 {
+		if err := json.Unmarshal(model.Header, &record.Header); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// --- Interface Implementation ---
+// Records never join the transaction of ctx (db rather than conn): a lock must be seen by
+// the retries of the request holding it at once.
+
+// Acquire inserts the lock unless the key has a live record; the unique key settles races
+// between concurrent requests. Expired records are deleted first.
+func (r *postgresIdempotencyStore) Acquire(ctx context.Context, key, fingerprint string, lockedUntil time.Time) (*domain.IdempotencyRecord, error) {
+	db := r.db.WithContext(ctx)
+	now := r.now().UTC()
+	// The live record may expire between the insert and the select: try again then
+	for attempt := 0; attempt < 3; attempt++ {
+		if err := db.Where("key = ? AND expires_at <= ?", key, now).Delete(&IdempotencyKeyModel{}).Error; err != nil {
+			return nil, dbError(err, "deleting expired idempotency key")
+		}
+		lock := &IdempotencyKeyModel{Key: key, Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: lockedUntil.UTC()}
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(lock)
+		if result.Error != nil {
+			return nil, dbError(result.Error, "locking idempotency key")
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		var model IdempotencyKeyModel
+		err := db.Where("key = ? AND expires_at > ?", key, now).First(&model).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, dbError(err, "loading idempotency key")
+		}
+		record, err := toDomainIdempotencyRecord(&model)
+		if err != nil {
+			return nil, dbError(err, "decoding idempotency key headers")
+		}
+		return record, nil
+	}
+	return nil, errors.New("db error locking idempotency key: the key keeps expiring")
+}
+
+// Complete replaces the lock with the response, if the request still holds it.
+func (r *postgresIdempotencyStore) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	header, err := json.Marshal(record.Header)
+	if err != nil {
+		return dbError(err, "encoding idempotency key headers")
+	}
+	result := r.db.WithContext(ctx).Model(&IdempotencyKeyModel{}).
+		Where("key = ? AND fingerprint = ? AND status = 0 AND expires_at > ?", record.Key, record.Fingerprint, r.now().UTC()).
+		Updates(map[string]any{
+			"status":     record.Status,
+			"header":     header,
+			"body":       record.Body,
+			"expires_at": record.ExpiresAt.UTC(),
+		})
+	if result.Error != nil {
+		return dbError(result.Error, "storing idempotent response")
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresIdempotencyStore) Release(ctx context.Context, key string) error {
+	err := r.db.WithContext(ctx).Where("key = ? AND status = 0", key).Delete(&IdempotencyKeyModel{}).Error
+	if err != nil {
+		return dbError(err, "releasing idempotency key")
+	}
+	return nil
+}
+
+func (r *postgresIdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at <= ?", now.UTC()).Delete(&IdempotencyKeyModel{})
+	if result.Error != nil {
+		return 0, dbError(result.Error, "deleting expired idempotency keys")
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/outbox_repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+)
+
+// outboxRelayLockKey is the transaction-level advisory lock taken while claiming events,
+// so that concurrent relays can't claim events of the same aggregate at once.
+const outboxRelayLockKey = 7_311_402_028
+
+// outboxClaimLease is how long claimed events are reserved for the relay that claimed them.
+// Past it, the relay stops handing them over, and they may be claimed again.
+const outboxClaimLease = 2 * time.Minute
+
+// OutboxEventModel defines the GORM database model for a row of the outbox table.
+type OutboxEventModel struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement"` // Delivery order
+	EventID       uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null"`
+	AggregateType string     `gorm:"size:50;not null"`
+	AggregateID   string     `gorm:"size:255;not null"`
+	EventType     string     `gorm:"size:100;not null"`
+	Payload       []byte     `gorm:"type:jsonb;not null"`
+	OccurredAt    time.Time  `gorm:"not null"`
+	Attempts      int        `gorm:"not null;default:0"`
+	LastError     string     `gorm:"type:text"`
+	NextAttemptAt time.Time  `gorm:"not null"`
+	PublishedAt   *time.Time // NULL until relayed
+	DeadAt        *time.Time // Set when the relay gave up on the event
+	Owner         string     `gorm:"size:100;not null;default:''"`
+}
+
+// TableName explicitly sets the table name for the OutboxEventModel struct.
+func (OutboxEventModel) TableName() string {
+	return "outbox"
+}
+
+// postgresOutboxRepository implements domain.OutboxRepository using GORM/Postgres.
+type postgresOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new GORM/Postgres outbox repository instance.
+func NewOutboxRepository(db *gorm.DB) domain.OutboxRepository {
+	return &postgresOutboxRepository{db: db}
+}
+
+// --- Mapping Functions ---
+
+func toDomainEvent(model *OutboxEventModel) *domain.DomainEvent {
+	return &domain.DomainEvent{
+		ID:            model.EventID,
+		Sequence:      model.ID,
+		AggregateType: model.AggregateType,
+		AggregateID:   model.AggregateID,
+		Type:          model.EventType,
+		Payload:       model.Payload,
+		OccurredAt:    model.OccurredAt,
+		Attempts:      model.Attempts,
+		Owner:         model.Owner,
+	}
+}
+
+func fromDomainEvent(event *domain.DomainEvent) *OutboxEventModel {
+	return &OutboxEventModel{
+		EventID:       event.ID,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     event.Type,
+		Payload:       event.Payload,
+		OccurredAt:    event.OccurredAt,
+		NextAttemptAt: event.OccurredAt,
+		Owner:         event.Owner,
+	}
+}
+
+// --- Interface Implementation ---
+
+// Append writes events. Called within a TxManager unit of work, it shares the transaction with
+// the change that produced the events; that is what makes the outbox transactional.
+func (r *postgresOutboxRepository) Append(ctx context.Context, events ...*domain.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	models := make([]*OutboxEventModel, 0, len(events))
+	for _, event := range events {
+		models = append(models, fromDomainEvent(event))
+	}
+	if err := conn(ctx, r.db).Create(models).Error; err != nil {
+		return dbError(err, "appending outbox events")
+	}
+	for i, model := range models {
+		events[i].Sequence = model.ID
+	}
+	return nil
+}
+
+// ProcessPending claims a batch of due events, then hands them to handle one by one. No
+// transaction or connection is held while handle runs, since sinks may be slow: the claim
+// reserves the events for outboxClaimLease instead, and handle gets a context ending with it.
+func (r *postgresOutboxRepository) ProcessPending(ctx context.Context, limit int, handle domain.OutboxHandler) (int, error) {
+	models, leaseEnd, err := r.claim(ctx, limit)
+	if err != nil || len(models) == 0 {
+		return 0, err
+	}
+	leaseCtx, cancel := context.WithDeadline(ctx, leaseEnd)
+	defer cancel()
+
+	processed := 0
+	blocked := make(map[string]bool)
+	var released []int64
+	for i := range models {
+		model := &models[i]
+		aggregateKey := model.AggregateType + "/" + model.AggregateID
+		if blocked[aggregateKey] || leaseCtx.Err() != nil {
+			// An earlier event of this aggregate just failed, and must be delivered first; or the
+			// lease ran out. The event goes back to the outbox, where the failed one holds it back.
+			released = append(released, model.ID)
+			continue
+		}
+
+		// Each outcome is saved at once, so that a failure later in the batch doesn't lose it
+		retryAt, handleErr := handle(leaseCtx, toDomainEvent(model))
+		if handleErr != nil {
+			blocked[aggregateKey] = true
+			failure := map[string]interface{}{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"last_error":      handleErr.Error(),
+				"next_attempt_at": retryAt,
+			}
+			if retryAt.IsZero() {
+				now := time.Now().UTC()
+				failure["next_attempt_at"], failure["dead_at"] = now, now
+			}
+			err := conn(ctx, r.db).Model(model).Updates(failure).Error
+			if err != nil {
+				return processed, fmt.Errorf("db error recording outbox failure [%d]: %w", model.ID, err)
+			}
+			continue
+		}
+		if err := conn(ctx, r.db).Model(model).Update("published_at", time.Now().UTC()).Error; err != nil {
+			return processed, fmt.Errorf("db error marking outbox event published [%d]: %w", model.ID, err)
+		}
+		processed++
+	}
+
+	if len(released) > 0 {
+		err := conn(ctx, r.db).Model(&OutboxEventModel{}).Where("id IN ?", released).
+			Update("next_attempt_at", time.Now().UTC()).Error
+		if err != nil {
+			return processed, fmt.Errorf("db error releasing outbox events: %w", err)
+		}
+	}
+	return processed, nil
+}
+
+// Requeue clears the dead mark and the attempts of an event, which the relay then picks up on
+// its next poll; the last error is kept until the next attempt.
+func (r *postgresOutboxRepository) Requeue(ctx context.Context, eventID uuid.UUID) error {
+	result := conn(ctx, r.db).Model(&OutboxEventModel{}).
+		Where("event_id = ? AND dead_at IS NOT NULL AND published_at IS NULL", eventID).
+		Updates(map[string]interface{}{"dead_at": nil, "attempts": 0, "next_attempt_at": time.Now().UTC()})
+	if result.Error != nil {
+		return dbError(result.Error, "requeuing outbox event")
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// claim selects up to limit due events in insert order, and leases them: they aren't due
+// again until the returned lease end. An event whose aggregate has an earlier event waiting
+// for a retry, leased or dead is skipped, which keeps per-aggregate ordering across relays.
+func (r *postgresOutboxRepository) claim(ctx context.Context, limit int) ([]OutboxEventModel, time.Time, error) {
+	now := time.Now().UTC()
+	leaseEnd := now.Add(outboxClaimLease)
+	var models []OutboxEventModel
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// SQLite runs its write transactions one at a time already
+		if tx.Dialector.Name() != "sqlite" {
+			var locked bool
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockKey).Scan(&locked).Error; err != nil {
+				return fmt.Errorf("db error acquiring outbox relay lock: %w", err)
+			}
+			if !locked {
+				return nil // Another relay is claiming; try again on the next tick
+			}
+		}
+
+		err := tx.Raw(`
+			SELECT o.* FROM outbox o
+			WHERE o.published_at IS NULL
+			  AND o.dead_at IS NULL
+			  AND o.next_attempt_at <= ?
+			  AND NOT EXISTS (
+			      SELECT 1 FROM outbox p
+			      WHERE p.published_at IS NULL
+			        AND p.aggregate_type = o.aggregate_type
+			        AND p.aggregate_id = o.aggregate_id
+			        AND p.id < o.id
+			        AND (p.next_attempt_at > ? OR p.dead_at IS NOT NULL))
+			ORDER BY o.id
+			LIMIT ?`, now, now, limit).Scan(&models).Error
+		if err != nil {
+			return fmt.Errorf("db error loading pending outbox events: %w", err)
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		ids := make([]int64, 0, len(models))
+		for _, model := range models {
+			ids = append(ids, model.ID)
+		}
+		if err := tx.Model(&OutboxEventModel{}).Where("id IN ?", ids).Update("next_attempt_at", leaseEnd).Error; err != nil {
+			return fmt.Errorf("db error claiming outbox events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return models, leaseEnd, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/repository.go
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+	"youGo/internal/repository/query"
+)
+
+// Mapper converts between a domain entity and its GORM model.
+type Mapper[TDomain, TModel any] struct {
+	ToDomain   func(model *TModel) (*TDomain, error)
+	FromDomain func(entity *TDomain) (*TModel, error)
+}
+
+// Mapping builds a Mapper from conversions that can't fail.
+func Mapping[TDomain, TModel any](toDomain func(*TModel) *TDomain, fromDomain func(*TDomain) *TModel) Mapper[TDomain, TModel] {
+	return Mapper[TDomain, TModel]{
+		ToDomain:   func(model *TModel) (*TDomain, error) { return toDomain(model), nil },
+		FromDomain: func(entity *TDomain) (*TModel, error) { return fromDomain(entity), nil },
+	}
+}
+
+// Repository implements the operations every entity repository needs on top of GORM: reads
+// described with the query DSL, writes, mapping between entity and model, and the translation of
+// database errors to domain errors (see MapError). Entity repositories hold one and implement
+// their domain interface with it, adding their own SQL where the DSL doesn't reach.
+// Like the repositories, it joins the transaction carried by ctx, if any (see TxManager).
+type Repository[TDomain, TModel any] struct {
+	db     *gorm.DB
+	entity string // What the rows are, for error messages, e.g. "user"
+	mapper Mapper[TDomain, TModel]
+}
+
+// NewRepository creates the repository of the entities stored as TModel rows.
+func NewRepository[TDomain, TModel any](db *gorm.DB, entity string, mapper Mapper[TDomain, TModel]) *Repository[TDomain, TModel] {
+	return &Repository[TDomain, TModel]{db: db, entity: entity, mapper: mapper}
+}
+
+// Conn returns the connection for ctx scoped to the model's table, for queries the DSL can't express.
+// Errors should go through MapError.
+func (r *Repository[TDomain, TModel]) Conn(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).Model(new(TModel))
+}
+
+// First returns the first entity matching q, or domain.ErrNotFound.
+func (r *Repository[TDomain, TModel]) First(ctx context.Context, q query.Query) (*TDomain, error) {
+	var model TModel
+	if err := q.Apply(conn(ctx, r.db)).Take(&model).Error; err != nil {
+		return nil, dbError(err, "finding %s", r.entity)
+	}
+	return r.mapper.ToDomain(&model)
+}
+
+// Find returns the entities matching q.
+func (r *Repository[TDomain, TModel]) Find(ctx context.Context, q query.Query) ([]*TDomain, error) {
+	var models []TModel
+	if err := q.Apply(conn(ctx, r.db)).Find(&models).Error; err != nil {
+		return nil, dbError(err, "listing %s", r.entity)
+	}
+	return r.toDomainList(models)
+}
+
+// FindPage returns the page of entities selected by q, and how many match its conditions in total.
+func (r *Repository[TDomain, TModel]) FindPage(ctx context.Context, q query.Query) ([]*TDomain, int64, error) {
+	total, err := r.Count(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	entities, err := r.Find(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entities, total, nil
+}
+
+// Count returns how many rows match the conditions of q.
+func (r *Repository[TDomain, TModel]) Count(ctx context.Context, q query.Query) (int64, error) {
+	var total int64
+	if err := q.Filter().Apply(r.Conn(ctx)).Count(&total).Error; err != nil {
+		return 0, dbError(err, "counting %s", r.entity)
+	}
+	return total, nil
+}
+
+// Exists reports whether any row satisfies specs.
+func (r *Repository[TDomain, TModel]) Exists(ctx context.Context, specs ...query.Spec) (bool, error) {
+	var found int
+	err := query.Where(specs...).Apply(r.Conn(ctx)).Select("1").Limit(1).Scan(&found).Error
+	if err != nil {
+		return false, dbError(err, "checking for %s", r.entity)
+	}
+	return found == 1, nil
+}
+
+// Create inserts entity, then copies back the values the database generated (ID, timestamps).
+func (r *Repository[TDomain, TModel]) Create(ctx context.Context, entity *TDomain) error {
+	model, err := r.mapper.FromDomain(entity)
+	if err != nil {
+		return err
+	}
+	if err := conn(ctx, r.db).Create(model).Error; err != nil {
+		return dbError(err, "creating %s", r.entity)
+	}
+	created, err := r.mapper.ToDomain(model)
+	if err != nil {
+		return err
+	}
+	*entity = *created
+	return nil
+}
+
+// Update writes the given columns of entity to its row, found by primary key.
+// Columns must be listed, since GORM would otherwise skip zero values; include updated_at.
+// It returns domain.ErrNotFound if the row doesn't exist.
+func (r *Repository[TDomain, TModel]) Update(ctx context.Context, entity *TDomain, columns ...query.Field) error {
+	if len(columns) == 0 {
+		return errors.New("postgres: Update needs the columns to write")
+	}
+	model, err := r.mapper.FromDomain(entity)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Name())
+	}
+	result := conn(ctx, r.db).Model(model).Select(names).Updates(model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrMissingWhereClause) {
+			return domain.ErrNotFound // No primary key: the entity was never stored
+		}
+		return dbError(result.Error, "updating %s", r.entity)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateWhere sets columns of every row satisfying specs, and returns how many rows changed.
+func (r *Repository[TDomain, TModel]) UpdateWhere(ctx context.Context, values map[string]any, specs ...query.Spec) (int64, error) {
+	if len(specs) == 0 {
+		return 0, errors.New("postgres: UpdateWhere needs a condition")
+	}
+	result := query.Where(specs...).Apply(r.Conn(ctx)).Updates(values)
+	if result.Error != nil {
+		return 0, dbError(result.Error, "updating %s", r.entity)
+	}
+	return result.RowsAffected, nil
+}
+
+// Delete removes the rows satisfying specs. It returns domain.ErrNotFound if there is none.
+func (r *Repository[TDomain, TModel]) Delete(ctx context.Context, specs ...query.Spec) error {
+	if len(specs) == 0 {
+		return errors.New("postgres: Delete needs a condition")
+	}
+	result := query.Where(specs...).Apply(conn(ctx, r.db)).Delete(new(TModel))
+	if result.Error != nil {
+		return dbError(result.Error, "deleting %s", r.entity)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repository[TDomain, TModel]) toDomainList(models []TModel) ([]*TDomain, error) {
+	entities := make([]*TDomain, 0, len(models))
+	for i := range models {
+		entity, err := r.mapper.ToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
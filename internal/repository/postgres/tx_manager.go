@@ -0,0 +1,92 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/tx_manager.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+)
+
+const (
+	defaultTxRetries = 3
+	txRetryBaseDelay = 20 * time.Millisecond
+)
+
+// txKey is the context key under which the current transaction is stored.
+type txKey struct{}
+
+// postgresTxManager implements domain.TxManager using GORM transactions.
+type postgresTxManager struct {
+	db         *gorm.DB
+	maxRetries int
+}
+
+// NewTxManager creates a transaction manager. Serialization failures (40001) and
+// deadlocks (40P01) are retried up to maxRetries times; a non-positive value uses the default.
+func NewTxManager(db *gorm.DB, maxRetries int) domain.TxManager {
+	if maxRetries <= 0 {
+		maxRetries = defaultTxRetries
+	}
+	return &postgresTxManager{db: db, maxRetries: maxRetries}
+}
+
+func (m *postgresTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		// Already in a transaction: GORM runs a nested Transaction as SAVEPOINT / ROLLBACK TO SAVEPOINT.
+		// Retrying is left to the outermost call, since a serialization failure aborts the whole transaction.
+		return tx.Transaction(func(sp *gorm.DB) error {
+			return fn(withTx(ctx, sp))
+		})
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(withTx(ctx, tx))
+		})
+		if err == nil || attempt >= m.maxRetries || !isRetryableTxError(err) {
+			return err
+		}
+
+		// Jittered exponential backoff so that the conflicting transactions don't collide again.
+		delay := txRetryBaseDelay << attempt
+		delay += time.Duration(rand.Int64N(int64(delay)))
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// conn returns the transaction carried by ctx, or db when there is none, bound to ctx.
+// Repositories use it instead of db.WithContext so that they join a unit of work transparently.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// withTx returns a context carrying tx, for code that opened a transaction itself.
+func withTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// isRetryableTxError reports whether the whole transaction may succeed if run again.
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01" // serialization_failure, deadlock_detected
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/usage_repository.go
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"youGo/internal/domain"
+)
+
+// UsageCounterModel defines the GORM database model for a row of the usage_counters table.
+type UsageCounterModel struct {
+	Subject     string    `gorm:"primaryKey;size:100"`
+	Meter       string    `gorm:"primaryKey;size:100"`
+	PeriodStart time.Time `gorm:"primaryKey"`
+	Value       int64     `gorm:"not null;default:0"`
+	UpdatedAt   time.Time `gorm:"not null"`
+}
+
+// TableName explicitly sets the table name for the UsageCounterModel struct.
+func (UsageCounterModel) TableName() string {
+	return "usage_counters"
+}
+
+// postgresUsageRepository implements domain.UsageRepository using GORM. The upserts are
+// portable, so it also runs on SQLite.
+type postgresUsageRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new GORM usage repository instance.
+func NewUsageRepository(db *gorm.DB) domain.UsageRepository {
+	return &postgresUsageRepository{db: db}
+}
+
+// --- Mapping Functions ---
+
+func fromDomainUsageCounter(counter domain.UsageCounter) UsageCounterModel {
+	return UsageCounterModel{
+		Subject:     counter.Subject,
+		Meter:       counter.Meter,
+		PeriodStart: counter.PeriodStart.UTC(),
+		Value:       counter.Value,
+		UpdatedAt:   time.Now().UTC(),
+	}
+}
+
+// --- Interface Implementation ---
+
+// Add upserts the counters, adding to the stored values in the same statement so that
+// concurrent instances don't lose counts.
+func (r *postgresUsageRepository) Add(ctx context.Context, counters []domain.UsageCounter) error {
+	if len(counters) == 0 {
+		return nil
+	}
+	models := make([]UsageCounterModel, len(counters))
+	for i, counter := range counters {
+		models[i] = fromDomainUsageCounter(counter)
+	}
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "subject"}, {Name: "meter"}, {Name: "period_start"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"value":      gorm.Expr("usage_counters.value + excluded.value"),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(&models).Error
+	if err != nil {
+		return dbError(err, "adding usage")
+	}
+	return nil
+}
+
+// Set upserts the counter with its value.
+func (r *postgresUsageRepository) Set(ctx context.Context, counter domain.UsageCounter) error {
+	model := fromDomainUsageCounter(counter)
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subject"}, {Name: "meter"}, {Name: "period_start"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&model).Error
+	if err != nil {
+		return dbError(err, "setting usage")
+	}
+	return nil
+}
+
+// List returns the counters of subject in the period, by meter.
+func (r *postgresUsageRepository) List(ctx context.Context, subject string, periodStart time.Time) ([]domain.UsageCounter, error) {
+	var models []UsageCounterModel
+	err := conn(ctx, r.db).
+		Where("subject = ? AND period_start = ?", subject, periodStart.UTC()).
+		Order("meter").
+		Find(&models).Error
+	if err != nil {
+		return nil, dbError(err, "listing usage")
+	}
+	counters := make([]domain.UsageCounter, len(models))
+	for i, model := range models {
+		counters[i] = domain.UsageCounter{
+			Subject:     model.Subject,
+			Meter:       model.Meter,
+			PeriodStart: model.PeriodStart,
+			Value:       model.Value,
+			UpdatedAt:   model.UpdatedAt,
+		}
+	}
+	return counters, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/user_search_repository.go
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+	"youGo/internal/platform/textsearch"
+	"youGo/internal/repository/query"
+)
+
+// A user matches a full-text search when its search_vector (name and email words, see the
+// migration adding it) contains every term as a prefix, or when the whole text is similar to
+// its name or email by pg_trgm, which catches typos. Full-text rank and similarity add up.
+const (
+	userSearchMatch = `search_vector @@ to_tsquery('simple', ?) OR name % ? OR email % ?`
+	userSearchRank  = `ts_rank(search_vector, to_tsquery('simple', ?)) + GREATEST(similarity(name, ?), similarity(email, ?))`
+)
+
+// userSearchRow is a user with the rank of a full-text search.
+type userSearchRow struct {
+	UserModel  `gorm:"embedded"`
+	SearchRank float64
+}
+
+// postgresUserSearchRepository implements domain.UserSearcher with Postgres full-text search and pg_trgm.
+type postgresUserSearchRepository struct {
+	base *Repository[domain.User, UserModel]
+}
+
+// NewUserSearchRepository creates a ranked user search. It needs the users.search_vector column
+// and the pg_trgm extension, both added by the users migrations.
+func NewUserSearchRepository(db *gorm.DB) domain.UserSearcher {
+	return &postgresUserSearchRepository{
+		base: NewRepository(db, "user", Mapping(toDomainUser, fromDomainUser)),
+	}
+}
+
+func (r *postgresUserSearchRepository) Search(ctx context.Context, q domain.UserSearchQuery) ([]domain.UserSearchHit, int64, error) {
+	terms := textsearch.Terms(q.Text)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+	// Terms are letters and digits only, so they need no quoting in the tsquery
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
+	}
+	tsquery := strings.Join(prefixes, " & ")
+	text := strings.ToLower(strings.TrimSpace(q.Text))
+
+	match := query.Where(query.Raw(userSearchMatch, tsquery, text, text))
+	total, err := r.base.Count(ctx, match)
+	if err != nil {
+		return nil, 0, err
+	}
+	var rows []userSearchRow
+	err = match.Limit(q.Limit).Offset(q.Offset).Apply(r.base.Conn(ctx)).
+		Select("users.*, "+userSearchRank+" AS search_rank", tsquery, text, text).
+		Order("search_rank DESC, created_at DESC, id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, dbError(err, "searching users")
+	}
+
+	hits := make([]domain.UserSearchHit, 0, len(rows))
+	for i := range rows {
+		hits = append(hits, newUserSearchHit(toDomainUser(&rows[i].UserModel), rows[i].SearchRank, terms))
+	}
+	return hits, total, nil
+}
+
+// likeUserSearchRepository implements domain.UserSearcher with case-insensitive LIKE, on any database.
+type likeUserSearchRepository struct {
+	base *Repository[domain.User, UserModel]
+}
+
+// NewLikeUserSearchRepository creates the fallback user search for databases without full-text
+// search: a user matches when every term is part of its name or email. Hits are sorted by name
+// and not ranked.
+func NewLikeUserSearchRepository(db *gorm.DB) domain.UserSearcher {
+	return &likeUserSearchRepository{
+		base: NewRepository(db, "user", Mapping(toDomainUser, fromDomainUser)),
+	}
+}
+
+func (r *likeUserSearchRepository) Search(ctx context.Context, q domain.UserSearchQuery) ([]domain.UserSearchHit, int64, error) {
+	terms := textsearch.Terms(q.Text)
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+	specs := make([]query.Spec, 0, len(terms))
+	for _, term := range terms {
+		specs = append(specs, query.Or(userName.ContainsFold(term), userEmail.ContainsFold(term)))
+	}
+
+	users, total, err := r.base.FindPage(ctx, query.Where(specs...).
+		OrderBy(userName.Asc(), userID.Asc()).
+		Limit(q.Limit).
+		Offset(q.Offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]domain.UserSearchHit, 0, len(users))
+	for _, user := range users {
+		hits = append(hits, newUserSearchHit(user, 0, terms))
+	}
+	return hits, total, nil
+}
+
+func newUserSearchHit(user *domain.User, rank float64, terms []string) domain.UserSearchHit {
+	return domain.UserSearchHit{
+		User:           user,
+		Rank:           rank,
+		NameHighlight:  textsearch.Highlight(user.Name, terms),
+		EmailHighlight: textsearch.Highlight(user.Email, terms),
+	}
+}
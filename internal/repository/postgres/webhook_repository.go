@@ -0,0 +1,307 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package postgres /youGo/internal/repository/postgres/webhook_repository.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"youGo/internal/domain"
+	"youGo/internal/repository/query"
+)
+
+// WebhookEndpointModel defines the GORM database model for a registered webhook endpoint.
+type WebhookEndpointModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Owner       string    `gorm:"size:100;not null"`
+	URL         string    `gorm:"type:text;not null"`
+	Secret      string    `gorm:"type:text;not null"`
+	EventTypes  []byte    `gorm:"type:jsonb;not null"` // JSON array of event types
+	Description string    `gorm:"size:255"`
+	IsActive    bool      `gorm:"default:true;not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName explicitly sets the table name for the WebhookEndpointModel struct.
+func (WebhookEndpointModel) TableName() string {
+	return "webhook_endpoints"
+}
+
+// WebhookDeliveryModel defines the GORM database model for a delivery (and its log entry).
+type WebhookDeliveryModel struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Owner          string    `gorm:"size:100;not null"`
+	EndpointID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID `gorm:"type:uuid;not null"`
+	EventType      string    `gorm:"size:100;not null"`
+	Payload        []byte    `gorm:"type:jsonb;not null"`
+	Status         string    `gorm:"size:20;not null"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"not null"`
+	LastAttemptAt  *time.Time
+	LastStatusCode int
+	LastResponse   string `gorm:"type:text"`
+	LastError      string `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName explicitly sets the table name for the WebhookDeliveryModel struct.
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Columns of the webhook_endpoints and webhook_deliveries tables.
+var (
+	webhookEndpointID          = query.NewColumn[uuid.UUID]("id")
+	webhookEndpointOwner       = query.NewText("owner")
+	webhookEndpointURL         = query.NewText("url")
+	webhookEndpointEventTypes  = query.NewColumn[[]byte]("event_types")
+	webhookEndpointDescription = query.NewText("description")
+	webhookEndpointIsActive    = query.NewColumn[bool]("is_active")
+	webhookEndpointCreatedAt   = query.NewColumn[time.Time]("created_at")
+	webhookEndpointUpdatedAt   = query.NewColumn[time.Time]("updated_at")
+
+	webhookDeliveryID             = query.NewColumn[uuid.UUID]("id")
+	webhookDeliveryOwner          = query.NewText("owner")
+	webhookDeliveryEndpointID     = query.NewColumn[uuid.UUID]("endpoint_id")
+	webhookDeliveryStatus         = query.NewText("status")
+	webhookDeliveryAttempts       = query.NewColumn[int]("attempts")
+	webhookDeliveryNextAttemptAt  = query.NewColumn[time.Time]("next_attempt_at")
+	webhookDeliveryLastAttemptAt  = query.NewColumn[*time.Time]("last_attempt_at")
+	webhookDeliveryLastStatusCode = query.NewColumn[int]("last_status_code")
+	webhookDeliveryLastResponse   = query.NewText("last_response")
+	webhookDeliveryLastError      = query.NewText("last_error")
+	webhookDeliveryCreatedAt      = query.NewColumn[time.Time]("created_at")
+	webhookDeliveryUpdatedAt      = query.NewColumn[time.Time]("updated_at")
+)
+
+// postgresWebhookRepository implements domain.WebhookRepository using GORM/Postgres.
+type postgresWebhookRepository struct {
+	db         *gorm.DB
+	endpoints  *Repository[domain.WebhookEndpoint, WebhookEndpointModel]
+	deliveries *Repository[domain.WebhookDelivery, WebhookDeliveryModel]
+}
+
+// NewWebhookRepository creates a new GORM/Postgres webhook repository instance.
+func NewWebhookRepository(db *gorm.DB) domain.WebhookRepository {
+	return &postgresWebhookRepository{
+		db: db,
+		endpoints: NewRepository(db, "webhook endpoint",
+			Mapper[domain.WebhookEndpoint, WebhookEndpointModel]{ToDomain: toDomainWebhookEndpoint, FromDomain: fromDomainWebhookEndpoint}),
+		deliveries: NewRepository(db, "webhook delivery", Mapping(toDomainWebhookDelivery, fromDomainWebhookDelivery)),
+	}
+}
+
+// --- Mapping Functions ---
+
+func toDomainWebhookEndpoint(model *WebhookEndpointModel) (*domain.WebhookEndpoint, error) {
+	endpoint := &domain.WebhookEndpoint{
+		ID:          model.ID,
+		Owner:       model.Owner,
+		URL:         model.URL,
+		Secret:      model.Secret,
+		Description: model.Description,
+		IsActive:    model.IsActive,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+	if err := json.Unmarshal(model.EventTypes, &endpoint.EventTypes); err != nil {
+		return nil, fmt.Errorf("decoding webhook endpoint event types [%s]: %w", model.ID, err)
+	}
+	return endpoint, nil
+}
+
+func fromDomainWebhookEndpoint(endpoint *domain.WebhookEndpoint) (*WebhookEndpointModel, error) {
+	eventTypes, err := json.Marshal(endpoint.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("encoding webhook endpoint event types: %w", err)
+	}
+	return &WebhookEndpointModel{
+		ID:          endpoint.ID,
+		Owner:       endpoint.Owner,
+		URL:         endpoint.URL,
+		Secret:      endpoint.Secret,
+		EventTypes:  eventTypes,
+		Description: endpoint.Description,
+		IsActive:    endpoint.IsActive,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}, nil
+}
+
+func toDomainWebhookDelivery(model *WebhookDeliveryModel) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             model.ID,
+		Owner:          model.Owner,
+		EndpointID:     model.EndpointID,
+		EventID:        model.EventID,
+		EventType:      model.EventType,
+		Payload:        model.Payload,
+		Status:         model.Status,
+		Attempts:       model.Attempts,
+		NextAttemptAt:  model.NextAttemptAt,
+		LastAttemptAt:  model.LastAttemptAt,
+		LastStatusCode: model.LastStatusCode,
+		LastResponse:   model.LastResponse,
+		LastError:      model.LastError,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func fromDomainWebhookDelivery(delivery *domain.WebhookDelivery) *WebhookDeliveryModel {
+	return &WebhookDeliveryModel{
+		ID:             delivery.ID,
+		Owner:          delivery.Owner,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastResponse:   delivery.LastResponse,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+// --- Interface Implementation: Endpoints ---
+
+func (r *postgresWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	return r.endpoints.Create(ctx, endpoint)
+}
+
+func (r *postgresWebhookRepository) FindEndpointByID(ctx context.Context, owner string, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	return r.endpoints.First(ctx, query.Where(webhookEndpointOwner.Eq(owner), webhookEndpointID.Eq(id)))
+}
+
+func (r *postgresWebhookRepository) ListEndpoints(ctx context.Context, owner string) ([]*domain.WebhookEndpoint, error) {
+	return r.endpoints.Find(ctx, query.Where(webhookEndpointOwner.Eq(owner)).OrderBy(webhookEndpointCreatedAt.Asc()))
+}
+
+func (r *postgresWebhookRepository) ListActiveEndpointsFor(ctx context.Context, owner, eventType string) ([]*domain.WebhookEndpoint, error) {
+	return r.endpoints.Find(ctx, query.Where(
+		webhookEndpointOwner.Eq(owner),
+		webhookEndpointIsActive.Eq(true),
+		query.Raw("(event_types @> CAST(? AS jsonb) OR event_types @> CAST(? AS jsonb))",
+			jsonArray(eventType), jsonArray(domain.WebhookAllEvents)),
+	))
+}
+
+func (r *postgresWebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	return r.endpoints.Update(ctx, endpoint, webhookEndpointURL, webhookEndpointEventTypes, webhookEndpointDescription,
+		webhookEndpointIsActive, webhookEndpointUpdatedAt)
+}
+
+func (r *postgresWebhookRepository) DeleteEndpoint(ctx context.Context, owner string, id uuid.UUID) error {
+	// Deliveries are removed by the ON DELETE CASCADE foreign key.
+	return r.endpoints.Delete(ctx, webhookEndpointOwner.Eq(owner), webhookEndpointID.Eq(id))
+}
+
+// --- Interface Implementation: Deliveries ---
+
+func (r *postgresWebhookRepository) CreateDeliveries(ctx context.Context, deliveries ...*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	models := make([]*WebhookDeliveryModel, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		if delivery.ID == uuid.Nil {
+			delivery.ID = uuid.New() // Assigned here so skipped duplicates don't shift IDs
+		}
+		models = append(models, fromDomainWebhookDelivery(delivery))
+	}
+	// The outbox relay is at-least-once, so the same event may be fanned out twice.
+	// The (endpoint_id, event_id) unique index turns the repeat into a no-op.
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(models).Error
+	if err != nil {
+		return dbError(err, "creating webhook deliveries")
+	}
+	for i, model := range models {
+		deliveries[i].CreatedAt = model.CreatedAt
+		deliveries[i].UpdatedAt = model.UpdatedAt
+	}
+	return nil
+}
+
+func (r *postgresWebhookRepository) FindDeliveryByID(ctx context.Context, owner string, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	return r.deliveries.First(ctx, query.Where(webhookDeliveryOwner.Eq(owner), webhookDeliveryID.Eq(id)))
+}
+
+func (r *postgresWebhookRepository) ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, int64, error) {
+	specs := []query.Spec{webhookDeliveryOwner.Eq(filter.Owner)}
+	if filter.EndpointID != nil {
+		specs = append(specs, webhookDeliveryEndpointID.Eq(*filter.EndpointID))
+	}
+	if filter.Status != "" {
+		specs = append(specs, webhookDeliveryStatus.Eq(filter.Status))
+	}
+	return r.deliveries.FindPage(ctx, query.Where(specs...).
+		OrderBy(webhookDeliveryCreatedAt.Desc(), webhookDeliveryID.Desc()).
+		Limit(filter.Limit).
+		Offset(filter.Offset))
+}
+
+func (r *postgresWebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	// Pushing next_attempt_at forward is the lease: concurrent workers skip locked rows now
+	// and won't see these rows as due until the lease runs out.
+	var models []WebhookDeliveryModel
+	err := conn(ctx, r.db).Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED)
+		RETURNING *`, time.Now().UTC().Add(lease), domain.WebhookDeliveryPending, limit).Scan(&models).Error
+	if err != nil {
+		return nil, dbError(err, "claiming webhook deliveries")
+	}
+	deliveries := make([]*domain.WebhookDelivery, 0, len(models))
+	for i := range models {
+		deliveries = append(deliveries, toDomainWebhookDelivery(&models[i]))
+	}
+	return deliveries, nil
+}
+
+func (r *postgresWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.deliveries.Update(ctx, delivery, webhookDeliveryStatus, webhookDeliveryAttempts, webhookDeliveryNextAttemptAt,
+		webhookDeliveryLastAttemptAt, webhookDeliveryLastStatusCode, webhookDeliveryLastResponse, webhookDeliveryLastError,
+		webhookDeliveryUpdatedAt)
+}
+
+// --- Helpers ---
+
+func toDomainWebhookEndpoints(models []WebhookEndpointModel) ([]*domain.WebhookEndpoint, error) {
+	endpoints := make([]*domain.WebhookEndpoint, 0, len(models))
+	for i := range models {
+		endpoint, err := toDomainWebhookEndpoint(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// jsonArray encodes a single string as a JSON array, for jsonb containment queries.
+func jsonArray(value string) string {
+	b, _ := json.Marshal([]string{value})
+	return string(b)
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package query /youGo/internal/repository/query/query.go
+// Package query is a typed DSL for the queries repositories run through GORM: conditions
+// (specifications) on typed columns, sorting, projection and relation preloading.
+//
+//	var (
+//		userEmail     = query.NewText("email")
+//		userIsActive  = query.NewColumn[bool]("is_active")
+//		userCreatedAt = query.NewColumn[time.Time]("created_at")
+//	)
+//
+//	q := query.Where(userIsActive.Eq(true), userEmail.HasSuffix("@example.com")).
+//		OrderBy(userCreatedAt.Desc()).
+//		Limit(20)
+//
+// Column names come from code, never from user input; values are always bound as parameters.
+package query
+
+import (
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Query describes what to read: conditions, order, columns, relations and page.
+// The zero value reads every row. Methods return a modified copy, so a Query can be reused as a base.
+type Query struct {
+	where    []Spec
+	order    []Order
+	fields   []Field
+	preloads []preload
+	limit    int
+	offset   int
+	lock     bool
+}
+
+type preload struct {
+	relation string
+	specs    []Spec
+}
+
+// Where returns a Query matching the rows that satisfy every spec.
+func Where(specs ...Spec) Query {
+	return Query{}.Where(specs...)
+}
+
+// Where adds conditions; all of them must hold.
+func (q Query) Where(specs ...Spec) Query {
+	q.where = append(slices.Clip(q.where), specs...)
+	return q
+}
+
+// OrderBy adds sort keys, the first one being the most significant.
+func (q Query) OrderBy(orders ...Order) Query {
+	q.order = append(slices.Clip(q.order), orders...)
+	return q
+}
+
+// Select limits the columns read; the others are left at their zero value in the model.
+func (q Query) Select(fields ...Field) Query {
+	q.fields = append(slices.Clip(q.fields), fields...)
+	return q
+}
+
+// Preload loads a relation of the model (a GORM association, e.g. "Deliveries") with a second
+// query, restricted to the related rows matching specs.
+func (q Query) Preload(relation string, specs ...Spec) Query {
+	q.preloads = append(slices.Clip(q.preloads), preload{relation: relation, specs: specs})
+	return q
+}
+
+// Limit caps the number of rows read; zero or less means no limit.
+func (q Query) Limit(n int) Query {
+	q.limit = n
+	return q
+}
+
+// Offset skips the first n rows.
+func (q Query) Offset(n int) Query {
+	q.offset = n
+	return q
+}
+
+// ForUpdate locks the rows read (SELECT ... FOR UPDATE) until the end of the transaction, so
+// that concurrent read-modify-write cycles on them run one after the other. SQLite has no row
+// locks and runs its write transactions one at a time, so it is left out there.
+func (q Query) ForUpdate() Query {
+	q.lock = true
+	return q
+}
+
+// Filter returns the query without order, projection, preloads, page and lock: the rows it
+// matches, e.g. to count them.
+func (q Query) Filter() Query {
+	return Query{where: q.where}
+}
+
+// Apply adds the query to db.
+func (q Query) Apply(db *gorm.DB) *gorm.DB {
+	db = applySpecs(db, q.where)
+	for _, o := range q.order {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: o.column}, Desc: o.desc})
+	}
+	if len(q.fields) > 0 {
+		columns := make([]string, 0, len(q.fields))
+		for _, f := range q.fields {
+			columns = append(columns, f.Name())
+		}
+		db = db.Select(columns)
+	}
+	for _, p := range q.preloads {
+		if len(p.specs) == 0 {
+			db = db.Preload(p.relation)
+			continue
+		}
+		specs := p.specs
+		db = db.Preload(p.relation, func(db *gorm.DB) *gorm.DB { return applySpecs(db, specs) })
+	}
+	if q.limit > 0 {
+		db = db.Limit(q.limit)
+	}
+	if q.offset > 0 {
+		db = db.Offset(q.offset)
+	}
+	if q.lock && db.Dialector.Name() != "sqlite" {
+		db = db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate})
+	}
+	return db
+}
+
+func applySpecs(db *gorm.DB, specs []Spec) *gorm.DB {
+	for _, s := range specs {
+		if s.expr != nil {
+			db = db.Where(s.expr)
+		}
+	}
+	return db
+}
+
+// Order is a sort key, see Column.Asc and Column.Desc.
+type Order struct {
+	column string
+	desc   bool
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package query /youGo/internal/repository/query/spec.go
+package query
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// Spec is a condition on rows. The zero Spec matches every row.
+type Spec struct {
+	expr clause.Expression
+}
+
+// Expression returns the condition as a GORM expression, nil for the zero Spec.
+func (s Spec) Expression() clause.Expression {
+	return s.expr
+}
+
+// And matches the rows satisfying every spec.
+func And(specs ...Spec) Spec {
+	exprs := expressions(specs)
+	switch len(exprs) {
+	case 0:
+		return Spec{}
+	case 1:
+		return Spec{expr: exprs[0]}
+	}
+	return Spec{expr: clause.And(exprs...)}
+}
+
+// Or matches the rows satisfying at least one spec. Without specs it matches nothing.
+func Or(specs ...Spec) Spec {
+	for _, s := range specs {
+		if s.expr == nil {
+			return Spec{} // One alternative matches every row
+		}
+	}
+	switch len(specs) {
+	case 0:
+		return Spec{expr: clause.Expr{SQL: "1 = 0"}}
+	case 1:
+		return specs[0]
+	}
+	return Spec{expr: clause.Or(expressions(specs)...)}
+}
+
+// Not matches the rows that don't satisfy s.
+func Not(s Spec) Spec {
+	if s.expr == nil {
+		return Spec{expr: clause.Expr{SQL: "1 = 0"}}
+	}
+	return Spec{expr: clause.Not(s.expr)}
+}
+
+// Raw is a condition written in SQL, with ? placeholders for args. It is meant for
+// dialect-specific operators the DSL doesn't cover, e.g. JSONB containment.
+func Raw(sql string, args ...any) Spec {
+	return Spec{expr: clause.Expr{SQL: sql, Vars: args}}
+}
+
+func expressions(specs []Spec) []clause.Expression {
+	exprs := make([]clause.Expression, 0, len(specs))
+	for _, s := range specs {
+		if s.expr != nil {
+			exprs = append(exprs, s.expr)
+		}
+	}
+	return exprs
+}
+
+// Field is a column that can be selected.
+type Field interface {
+	Name() string
+}
+
+// Column is a column holding values of type T: its conditions only accept a T.
+type Column[T any] struct {
+	name string
+}
+
+// NewColumn declares a column of the model's table.
+func NewColumn[T any](name string) Column[T] {
+	return Column[T]{name: name}
+}
+
+// Name returns the column name.
+func (c Column[T]) Name() string { return c.name }
+
+func (c Column[T]) ref() clause.Column { return clause.Column{Name: c.name} }
+
+// Eq matches the rows where the column equals v.
+func (c Column[T]) Eq(v T) Spec { return Spec{expr: clause.Eq{Column: c.ref(), Value: v}} }
+
+// Ne matches the rows where the column differs from v.
+func (c Column[T]) Ne(v T) Spec { return Spec{expr: clause.Neq{Column: c.ref(), Value: v}} }
+
+// Gt matches the rows where the column is greater than v.
+func (c Column[T]) Gt(v T) Spec { return Spec{expr: clause.Gt{Column: c.ref(), Value: v}} }
+
+// Gte matches the rows where the column is greater than or equal to v.
+func (c Column[T]) Gte(v T) Spec { return Spec{expr: clause.Gte{Column: c.ref(), Value: v}} }
+
+// Lt matches the rows where the column is less than v.
+func (c Column[T]) Lt(v T) Spec { return Spec{expr: clause.Lt{Column: c.ref(), Value: v}} }
+
+// Lte matches the rows where the column is less than or equal to v.
+func (c Column[T]) Lte(v T) Spec { return Spec{expr: clause.Lte{Column: c.ref(), Value: v}} }
+
+// Between matches the rows where the column lies in [from, to).
+func (c Column[T]) Between(from, to T) Spec { return And(c.Gte(from), c.Lt(to)) }
+
+// In matches the rows where the column equals one of values. Without values it matches nothing.
+func (c Column[T]) In(values ...T) Spec {
+	if len(values) == 0 {
+		return Spec{expr: clause.Expr{SQL: "1 = 0"}}
+	}
+	vars := make([]any, 0, len(values))
+	for _, v := range values {
+		vars = append(vars, v)
+	}
+	return Spec{expr: clause.IN{Column: c.ref(), Values: vars}}
+}
+
+// NotIn matches the rows where the column equals none of values. Without values it matches every row.
+func (c Column[T]) NotIn(values ...T) Spec {
+	if len(values) == 0 {
+		return Spec{}
+	}
+	return Not(c.In(values...))
+}
+
+// IsNull matches the rows where the column is NULL.
+func (c Column[T]) IsNull() Spec {
+	return Spec{expr: clause.Expr{SQL: "? IS NULL", Vars: []any{c.ref()}}}
+}
+
+// IsNotNull matches the rows where the column is not NULL.
+func (c Column[T]) IsNotNull() Spec {
+	return Spec{expr: clause.Expr{SQL: "? IS NOT NULL", Vars: []any{c.ref()}}}
+}
+
+// Asc sorts by the column, smallest first.
+func (c Column[T]) Asc() Order { return Order{column: c.name} }
+
+// Desc sorts by the column, largest first.
+func (c Column[T]) Desc() Order { return Order{column: c.name, desc: true} }
+
+// Text is a string column, which also supports pattern matching.
+type Text struct {
+	Column[string]
+}
+
+// NewText declares a string column of the model's table.
+func NewText(name string) Text {
+	return Text{Column: NewColumn[string](name)}
+}
+
+// Like matches the rows where the column matches a LIKE pattern (% and _ wildcards).
+func (c Text) Like(pattern string) Spec {
+	return Spec{expr: clause.Like{Column: c.ref(), Value: pattern}}
+}
+
+// ILike is Like ignoring case. It is written with LOWER so that it runs on any database.
+func (c Text) ILike(pattern string) Spec {
+	return Spec{expr: clause.Expr{SQL: "LOWER(?) LIKE LOWER(?)", Vars: []any{c.ref(), pattern}}}
+}
+
+// Contains matches the rows where the column contains s.
+func (c Text) Contains(s string) Spec { return c.likeEscaped("%"+escapeLike(s)+"%", false) }
+
+// ContainsFold is Contains ignoring case.
+func (c Text) ContainsFold(s string) Spec { return c.likeEscaped("%"+escapeLike(s)+"%", true) }
+
+// HasPrefix matches the rows where the column starts with s.
+func (c Text) HasPrefix(s string) Spec { return c.likeEscaped(escapeLike(s)+"%", false) }
+
+// HasSuffix matches the rows where the column ends with s.
+func (c Text) HasSuffix(s string) Spec { return c.likeEscaped("%"+escapeLike(s), false) }
+
+// likeEscaped matches an escaped pattern. The escape character is given explicitly since
+// only Postgres defaults to the backslash.
+func (c Text) likeEscaped(pattern string, fold bool) Spec {
+	sql := `? LIKE ? ESCAPE '\'`
+	if fold {
+		sql = `LOWER(?) LIKE LOWER(?) ESCAPE '\'`
+	}
+	return Spec{expr: clause.Expr{SQL: sql, Vars: []any{c.ref(), pattern}}}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes s match literally in a LIKE pattern.
+func escapeLike(s string) string { return likeEscaper.Replace(s) }
@@ -0,0 +1,122 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- The tables of migrations/ for SQLite. Postgres-only features (append-only trigger, GIN and
+-- partial indexes) are left out; UUIDs are generated as random version 4 text.
+CREATE TABLE IF NOT EXISTS users
+(
+    id            TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+                                               substr(hex(randomblob(2)), 2) || '-' ||
+                                               substr('89ab', 1 + (abs(random()) % 4), 1) ||
+                                               substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    name          VARCHAR(100) NOT NULL,
+    email         VARCHAR(255) NOT NULL,
+    password_hash TEXT         NOT NULL,
+    role          VARCHAR(50)  NOT NULL DEFAULT 'user',
+    is_active     BOOLEAN      NOT NULL DEFAULT true,
+    locale        VARCHAR(16)  NOT NULL DEFAULT '',
+    created_at    DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT users_email_key UNIQUE (email)
+);
+
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id          TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+                                             substr(hex(randomblob(2)), 2) || '-' ||
+                                             substr('89ab', 1 + (abs(random()) % 4), 1) ||
+                                             substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    actor_id    TEXT,
+    action      VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50)  NOT NULL,
+    target_id   VARCHAR(255) NOT NULL,
+    changes     BLOB,
+    request_id  VARCHAR(100),
+    ip          VARCHAR(64),
+    occurred_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_occurred_at ON audit_events (occurred_at);
+
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id        TEXT         NOT NULL UNIQUE,
+    aggregate_type  VARCHAR(50)  NOT NULL,
+    aggregate_id    VARCHAR(255) NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    payload         BLOB         NOT NULL,
+    occurred_at     DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at    DATETIME,
+    dead_at         DATETIME,
+    owner           VARCHAR(100) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints
+(
+    id          TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+                                             substr(hex(randomblob(2)), 2) || '-' ||
+                                             substr('89ab', 1 + (abs(random()) % 4), 1) ||
+                                             substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    url         TEXT     NOT NULL,
+    secret      TEXT     NOT NULL,
+    event_types BLOB     NOT NULL DEFAULT '[]',
+    description VARCHAR(255),
+    is_active   BOOLEAN  NOT NULL DEFAULT true,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    owner       VARCHAR(100) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_owner ON webhook_endpoints (owner, created_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id               TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+                                                  substr(hex(randomblob(2)), 2) || '-' ||
+                                                  substr('89ab', 1 + (abs(random()) % 4), 1) ||
+                                                  substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    endpoint_id      TEXT         NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    event_id         TEXT         NOT NULL,
+    event_type       VARCHAR(100) NOT NULL,
+    payload          BLOB         NOT NULL,
+    status           VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts         INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_attempt_at  DATETIME,
+    last_status_code INTEGER,
+    last_response    TEXT,
+    last_error       TEXT,
+    created_at       DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    owner            VARCHAR(100) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint ON webhook_deliveries (endpoint_id, created_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint_event ON webhook_deliveries (endpoint_id, event_id);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key         VARCHAR(64) PRIMARY KEY,
+    fingerprint VARCHAR(64) NOT NULL,
+    status      INTEGER     NOT NULL DEFAULT 0,
+    header      BLOB,
+    body        BLOB,
+    created_at  DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at  DATETIME    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE IF NOT EXISTS usage_counters
+(
+    subject      VARCHAR(100) NOT NULL,
+    meter        VARCHAR(100) NOT NULL,
+    period_start DATETIME     NOT NULL,
+    value        INTEGER      NOT NULL DEFAULT 0,
+    updated_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (subject, period_start, meter)
+);
@@ -0,0 +1,45 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package sqlite /youGo/internal/repository/sqlite/sqlite.go
+// Package sqlite runs the application on SQLite (database.driver: sqlite), for local development
+// without a Postgres server. The GORM repositories of package postgres only emit portable SQL
+// for the user, audit and webhook administration paths, so they are reused as they are; what
+// differs is the schema, created by Migrate instead of the Postgres migrations.
+package sqlite
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+	repoImpl "youGo/internal/repository/postgres"
+)
+
+//go:embed schema.sql
+var schema string
+
+// Migrate creates the tables that don't exist yet. It is idempotent, and run on every startup.
+func Migrate(ctx context.Context, db *gorm.DB) error {
+	if err := db.WithContext(ctx).Exec(schema).Error; err != nil {
+		return fmt.Errorf("creating SQLite schema: %w", err)
+	}
+	return nil
+}
+
+// NewUserRepository creates a user repository on a SQLite database prepared by Migrate.
+// The database must be opened with gorm.Config.TranslateError so that a taken email is
+// reported as domain.ErrDuplicateEntry.
+func NewUserRepository(db *gorm.DB) domain.UserRepository {
+	return repoImpl.NewUserRepository(db)
+}
+
+// NewUserSearchRepository creates the user search of SQLite: the LIKE fallback, as SQLite has
+// neither tsvector nor pg_trgm.
+func NewUserSearchRepository(db *gorm.DB) domain.UserSearcher {
+	return repoImpl.NewLikeUserSearchRepository(db)
+}
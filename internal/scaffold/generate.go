@@ -0,0 +1,266 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package scaffold /youGo/internal/scaffold/generate.go
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.tmpl"))
+
+// ModulesMarker is the line of modules.Default() above which generated modules are registered.
+const ModulesMarker = "// generate resource: new modules are added above this line"
+
+// modulesFile lists the application's modules, relative to the project root.
+const modulesFile = "internal/modules/modules.go"
+
+// Options controls where and how a resource is generated.
+type Options struct {
+	Dir    string // Project root, the directory of go.mod
+	Force  bool   // Overwrite existing files
+	DryRun bool   // Report the files without writing them
+}
+
+// output is a file rendered from a template.
+type output struct {
+	template string
+	path     string // Relative to the project root
+	shared   bool   // Written once for all resources, kept if it exists
+	// Declarations of a shared file: it is also kept if another file of its package declares
+	// them, as two declarations of a name don't compile
+	declares []string
+}
+
+func outputs(r *Resource) []output {
+	migration := fmt.Sprintf("migrations/%s/%s_create_%s_table", r.SnakePlural, r.Version, r.SnakePlural)
+	return []output{
+		{template: "domain.go.tmpl", path: "internal/domain/" + r.Snake + ".go"},
+		{template: "repository.go.tmpl", path: "internal/repository/postgres/" + r.Snake + "_repository.go"},
+		{template: "service.go.tmpl", path: "internal/service/" + r.Snake + "_service.go"},
+		{template: "request.go.tmpl", path: "internal/api/request/" + r.Snake + "_request.go"},
+		{template: "response.go.tmpl", path: "internal/api/response/" + r.Snake + "_response.go"},
+		{template: "handler.go.tmpl", path: "internal/api/handler/" + r.Snake + "_handler.go"},
+		{template: "module.go.tmpl", path: "internal/modules/" + r.Snake + ".go"},
+		{template: "migration.up.sql.tmpl", path: migration + ".up.sql"},
+		{template: "migration.down.sql.tmpl", path: migration + ".down.sql"},
+		{template: "service_test.go.tmpl", path: "test/" + r.Snake + "_service_test.go"},
+		{template: "fakes_test.go.tmpl", path: "test/fakes_test.go", shared: true, declares: []string{"passthroughTxManager", "recordingAuditor"}},
+	}
+}
+
+// Generate writes every layer of the resource into the project at opts.Dir and registers its
+// module in modules.Default(). It returns the paths written or changed, relative to opts.Dir.
+// Nothing is written if any file already exists, unless opts.Force is set.
+func Generate(r *Resource, opts Options) ([]string, error) {
+	root := opts.Dir
+	if root == "" {
+		root = "."
+	}
+	var err error
+	if r.Module, err = modulePath(root); err != nil {
+		return nil, err
+	}
+	if r.Version, err = nextMigrationVersion(root); err != nil {
+		return nil, err
+	}
+
+	modules, err := os.ReadFile(filepath.Join(root, modulesFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading the module list: %w", err)
+	}
+	registered, err := registerModule(modules, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render everything before writing anything, so that a failure leaves the project untouched
+	rendered := make(map[string][]byte)
+	var paths []string
+	for _, out := range outputs(r) {
+		_, statErr := os.Stat(filepath.Join(root, out.path))
+		exists := statErr == nil
+		if exists && out.shared {
+			continue
+		}
+		if out.shared && len(out.declares) > 0 {
+			declared, err := declaredElsewhere(root, out)
+			if err != nil {
+				return nil, err
+			}
+			if len(declared) == len(out.declares) {
+				continue // The package has its own
+			}
+			if len(declared) > 0 {
+				return nil, fmt.Errorf("%s declares %s but not the rest of %s: move them to %s",
+					filepath.Dir(out.path), strings.Join(declared, ", "), strings.Join(out.declares, ", "), out.path)
+			}
+		}
+		if exists && !opts.Force {
+			return nil, fmt.Errorf("%s already exists (use --force to overwrite)", out.path)
+		}
+		content, err := render(out.template, r)
+		if err != nil {
+			return nil, err
+		}
+		rendered[out.path] = content
+		paths = append(paths, out.path)
+	}
+	if registered != nil {
+		rendered[modulesFile] = registered
+		paths = append(paths, modulesFile)
+	}
+	if opts.DryRun {
+		return paths, nil
+	}
+
+	for _, path := range paths {
+		target := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, rendered[path], 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// render executes a template, formatting Go output with gofmt.
+func render(name string, r *Resource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, r); err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", name, err)
+	}
+	if !strings.HasSuffix(name, ".go.tmpl") {
+		return buf.Bytes(), nil
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("rendering %s: invalid Go code: %w", name, err)
+	}
+	return formatted, nil
+}
+
+// declaredElsewhere returns the names of out.declares that the Go files of the directory of
+// out declare at package level.
+func declaredElsewhere(root string, out output) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(root, filepath.Dir(out.path), "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		parsed, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		for _, decl := range parsed.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					found[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						found[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							found[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	var declared []string
+	for _, name := range out.declares {
+		if found[name] {
+			declared = append(declared, name)
+		}
+	}
+	return declared, nil
+}
+
+// registerModule adds the resource's module above ModulesMarker. It returns nil if the module
+// is already listed.
+func registerModule(src []byte, r *Resource) ([]byte, error) {
+	entry := r.Plural + "(),"
+	lines := strings.SplitAfter(string(src), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == entry {
+			return nil, nil
+		}
+		if trimmed != ModulesMarker {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		out := append(append(append([]string(nil), lines[:i]...), indent+entry+"\n"), lines[i:]...)
+		return []byte(strings.Join(out, "")), nil
+	}
+	return nil, fmt.Errorf("%s has no %q line in Default(); add it to the module list", modulesFile, ModulesMarker)
+}
+
+// modulePath reads the module path from go.mod.
+func modulePath(root string) (string, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("run generate from the project root: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if path, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(path), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("go.mod has no module directive")
+}
+
+// nextMigrationVersion returns the version following the highest one in any module's directory
+// of migrations/, as versions are shared by all modules.
+func nextMigrationVersion(root string) (string, error) {
+	matches, err := fs.Glob(os.DirFS(root), "migrations/*/*.up.sql")
+	if err != nil {
+		return "", err
+	}
+	var highest uint64
+	for _, match := range matches {
+		prefix, _, ok := strings.Cut(filepath.Base(match), "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue // Not a migration, the runner reports it
+		}
+		highest = max(highest, version)
+	}
+	return fmt.Sprintf("%06d", highest+1), nil
+}
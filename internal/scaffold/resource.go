@@ -0,0 +1,248 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package scaffold /youGo/internal/scaffold/resource.go
+// Package scaffold generates the layers of a new CRUD resource (domain entity, repository,
+// service, DTOs, handler, module, migration and test) following the project's conventions.
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// FieldType describes how a field type maps onto each layer.
+type FieldType struct {
+	GoType         string // Type in the domain entity, model and requests
+	ResponseGoType string // Type in the response DTO
+	SQLType        string // Column type, always NOT NULL
+	GormTag        string
+	Validate       string // Validation of the create request, "" for none
+	ValidateUpdate string // Validation of the update request, "" for none
+	Example        string // Swagger example
+	Sample         string // Go expression used by the generated test
+	OtherSample    string // A different Go expression of the same type
+}
+
+// fieldTypes are the types accepted in field:type specs.
+var fieldTypes = map[string]FieldType{
+	"string": {GoType: "string", ResponseGoType: "string", SQLType: "VARCHAR(255)", GormTag: "size:255;not null", Validate: "required,max=255", ValidateUpdate: "omitempty,max=255", Example: "example", Sample: `"example"`, OtherSample: `"changed"`},
+	"text":   {GoType: "string", ResponseGoType: "string", SQLType: "TEXT", GormTag: "type:text;not null", Validate: "required", Example: "Some longer text", Sample: `"some text"`, OtherSample: `"other text"`},
+	"email":  {GoType: "string", ResponseGoType: "string", SQLType: "VARCHAR(255)", GormTag: "size:255;not null", Validate: "required,email,max=255", ValidateUpdate: "omitempty,email,max=255", Example: "someone@example.com", Sample: `"someone@example.com"`, OtherSample: `"other@example.com"`},
+	"url":    {GoType: "string", ResponseGoType: "string", SQLType: "TEXT", GormTag: "type:text;not null", Validate: "required,http_url", ValidateUpdate: "omitempty,http_url", Example: "https://example.com", Sample: `"https://example.com"`, OtherSample: `"https://example.org"`},
+	"int":    {GoType: "int", ResponseGoType: "int", SQLType: "INTEGER", GormTag: "not null", Example: "42", Sample: "42", OtherSample: "43"},
+	"int64":  {GoType: "int64", ResponseGoType: "int64", SQLType: "BIGINT", GormTag: "not null", Example: "42", Sample: "42", OtherSample: "int64(43)"},
+	"float":  {GoType: "float64", ResponseGoType: "float64", SQLType: "DOUBLE PRECISION", GormTag: "not null", Example: "9.99", Sample: "9.99", OtherSample: "19.99"},
+	"bool":   {GoType: "bool", ResponseGoType: "bool", SQLType: "BOOLEAN", GormTag: "not null", Example: "true", Sample: "true", OtherSample: "false"},
+	"time":   {GoType: "time.Time", ResponseGoType: "time.Time", SQLType: "TIMESTAMPTZ", GormTag: "not null", Validate: "required", Example: "2025-01-01T00:00:00Z", Sample: "time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)", OtherSample: "time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)"},
+	"uuid":   {GoType: "uuid.UUID", ResponseGoType: "string", SQLType: "UUID", GormTag: "type:uuid;not null", Example: "00000000-0000-0000-0000-000000000000", Sample: `uuid.MustParse("9b2f4c8e-1a6d-4a57-8c1e-3f0b6f4d2a10")`, OtherSample: `uuid.MustParse("4e7d1b2a-5c3f-4e8a-9d6b-0a1c2e3f4b5d")`},
+}
+
+// FieldTypeNames lists the accepted field types, for help texts.
+func FieldTypeNames() []string {
+	return []string{"string", "text", "email", "url", "int", "int64", "float", "bool", "time", "uuid"}
+}
+
+// Field is one attribute of a resource.
+type Field struct {
+	Name   string // Go name, e.g. UnitPrice
+	JSON   string // JSON name, e.g. unitPrice or ownerId
+	Column string // Column name, e.g. unit_price
+	Kind   string // Field type name, e.g. float
+	FieldType
+}
+
+// ResponseValue returns the expression converting v.<field> to its response type.
+func (f Field) ResponseValue(v string) string {
+	if f.Kind == "uuid" {
+		return v + "." + f.Name + ".String()"
+	}
+	return v + "." + f.Name
+}
+
+// QueryColumn returns the expression declaring the field's column for the query DSL.
+func (f Field) QueryColumn() string {
+	if f.GoType == "string" {
+		return fmt.Sprintf("query.NewText(%q)", f.Column)
+	}
+	return fmt.Sprintf("query.NewColumn[%s](%q)", f.GoType, f.Column)
+}
+
+// Resource is a CRUD entity to generate, with its name in every case the templates need.
+type Resource struct {
+	Module      string // Go module path, e.g. youGo
+	Name        string // BlogPost
+	Plural      string // BlogPosts
+	Camel       string // blogPost
+	Snake       string // blog_post: file names, audit target
+	SnakePlural string // blog_posts: table and migration directory
+	KebabPlural string // blog-posts: URL path
+	Human       string // blog post: messages
+	HumanPlural string // blog posts
+	Version     string // Migration version, e.g. 000005
+	Fields      []Field
+}
+
+// HasKind reports whether any field has the given type, to decide on imports.
+func (r *Resource) HasKind(kind string) bool {
+	for _, f := range r.Fields {
+		if f.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+var identPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// reservedFields are generated for every resource.
+var reservedFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// reservedNames can't name a resource: its camelCase form is used as a variable next to these
+// keywords, packages and locals in the generated code.
+var reservedNames = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true,
+	"if": true, "import": true, "interface": true, "map": true, "package": true, "range": true,
+	"return": true, "select": true, "struct": true, "switch": true, "type": true, "var": true,
+	"app": true, "context": true, "domain": true, "echo": true, "errors": true, "fmt": true, "fs": true,
+	"gorm": true, "handler": true, "http": true, "migrations": true, "modules": true, "pgconn": true,
+	"request": true, "response": true, "router": true, "service": true, "time": true, "uuid": true, "zap": true,
+	"auditor": true, "before": true, "filter": true, "group": true, "items": true, "list": true, "model": true,
+	"models": true, "now": true, "query": true, "repo": true, "req": true, "resp": true, "result": true, "total": true,
+}
+
+// ParseResource validates a resource name (e.g. BlogPost or blog_post) and field specs
+// (e.g. title:string, unit_price:float).
+func ParseResource(name string, specs []string) (*Resource, error) {
+	if !identPattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name %q: use letters, digits and underscores, starting with a letter", name)
+	}
+	words := splitWords(name)
+	if reservedNames[camel(words)] {
+		return nil, fmt.Errorf("resource name %q clashes with an identifier of the generated code", name)
+	}
+	r := &Resource{
+		Name:  pascal(words),
+		Snake: strings.Join(words, "_"),
+		Human: strings.Join(words, " "),
+	}
+	pluralWords := append(append([]string(nil), words[:len(words)-1]...), pluralize(words[len(words)-1]))
+	r.Plural = pascal(pluralWords)
+	r.Camel = camel(words)
+	r.SnakePlural = strings.Join(pluralWords, "_")
+	r.KebabPlural = strings.Join(pluralWords, "-")
+	r.HumanPlural = strings.Join(pluralWords, " ")
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%s needs at least one field, e.g. name:string", r.Name)
+	}
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		fieldName, kind, ok := strings.Cut(spec, ":")
+		if !ok || !identPattern.MatchString(fieldName) {
+			return nil, fmt.Errorf("invalid field %q: expected name:type", spec)
+		}
+		fieldType, ok := fieldTypes[strings.ToLower(kind)]
+		if !ok {
+			return nil, fmt.Errorf("field %q: unknown type %q (one of %s)", fieldName, kind, strings.Join(FieldTypeNames(), ", "))
+		}
+		fieldWords := splitWords(fieldName)
+		field := Field{
+			Name:      pascal(fieldWords),
+			JSON:      jsonName(fieldWords),
+			Column:    strings.Join(fieldWords, "_"),
+			Kind:      strings.ToLower(kind),
+			FieldType: fieldType,
+		}
+		if reservedFields[field.Column] {
+			return nil, fmt.Errorf("field %q is generated for every resource", fieldName)
+		}
+		if seen[field.Column] {
+			return nil, fmt.Errorf("field %q is listed twice", fieldName)
+		}
+		seen[field.Column] = true
+		r.Fields = append(r.Fields, field)
+	}
+	return r, nil
+}
+
+// splitWords splits snake_case, kebab-case, camelCase and PascalCase into lower-case words.
+// Runs of capitals are kept together: HTTPServer -> http, server.
+func splitWords(s string) []string {
+	var (
+		words   []string
+		current []rune
+	)
+	runes := []rune(s)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+	for i, c := range runes {
+		switch {
+		case c == '_' || c == '-':
+			flush()
+			continue
+		case unicode.IsUpper(c) && len(current) > 0:
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, c)
+	}
+	flush()
+	return words
+}
+
+// initialisms are written in capitals in Go names, as golint expects.
+var initialisms = map[string]bool{
+	"id": true, "url": true, "uri": true, "api": true, "http": true, "https": true, "json": true,
+	"ip": true, "uuid": true, "sql": true, "html": true, "sku": true, "vat": true,
+}
+
+func pascal(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		if initialisms[w] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+func camel(words []string) string {
+	return words[0] + pascal(words[1:])
+}
+
+// jsonName is the camelCase JSON key, with initialisms capitalised as words: ownerId, not ownerID.
+func jsonName(words []string) string {
+	var b strings.Builder
+	b.WriteString(words[0])
+	for _, w := range words[1:] {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// pluralize applies the regular English plural rules, which is enough for table and route names.
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	default:
+		return word + "s"
+	}
+}
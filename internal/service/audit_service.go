@@ -0,0 +1,237 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package service /youGo/internal/service/audit_service.go
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+	"youGo/internal/platform/requestctx"
+)
+
+// Audit export formats.
+const (
+	AuditExportCSV    = "csv"
+	AuditExportNDJSON = "ndjson"
+)
+
+const (
+	defaultAuditPageSize = 50
+	auditExportBatchSize = 500
+)
+
+// AuditService records audit events and serves them to administrators.
+type AuditService interface {
+	domain.AuditRecorder
+	List(ctx context.Context, req *request.ListAuditEventsRequest) (*response.AuditEventListResponse, error)
+	Export(ctx context.Context, req *request.ExportAuditEventsRequest, w io.Writer) error
+}
+
+type auditService struct {
+	auditRepo domain.AuditRepository
+	logger    *zap.Logger
+}
+
+// NewAuditService constructor
+func NewAuditService(repo domain.AuditRepository, logger *zap.Logger) AuditService {
+	return &auditService{
+		auditRepo: repo,
+		logger:    logger,
+	}
+}
+
+// Record fills in the actor, request ID, IP and timestamp from ctx and appends the event.
+func (s *auditService) Record(ctx context.Context, event *domain.AuditEvent) error {
+	meta := requestctx.FromContext(ctx)
+	if event.ActorID == nil && meta.ActorID != uuid.Nil {
+		actorID := meta.ActorID
+		event.ActorID = &actorID
+	}
+	if event.RequestID == "" {
+		event.RequestID = meta.RequestID
+	}
+	if event.IP == "" {
+		event.IP = meta.IP
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	if err := s.auditRepo.Append(ctx, event); err != nil {
+		s.logger.Error("Failed to record audit event",
+			zap.String("action", event.Action),
+			zap.String("targetType", event.TargetType),
+			zap.String("targetID", event.TargetID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed recording audit event")
+	}
+	return nil
+}
+
+// List implementation
+func (s *auditService) List(ctx context.Context, req *request.ListAuditEventsRequest) (*response.AuditEventListResponse, error) {
+	filter, err := toAuditFilter(&req.AuditEventFilterRequest)
+	if err != nil {
+		return nil, err
+	}
+	filter.Limit = req.Limit
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditPageSize
+	}
+	filter.Offset = req.Offset
+
+	events, total, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list audit events", zap.Error(err))
+		return nil, fmt.Errorf("failed retrieving audit events")
+	}
+
+	items := make([]response.AuditEventResponse, 0, len(events))
+	for _, event := range events {
+		items = append(items, response.NewAuditEventResponse(event))
+	}
+	return &response.AuditEventListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// Export streams every matching audit event to w, newest first, as CSV or NDJSON.
+func (s *auditService) Export(ctx context.Context, req *request.ExportAuditEventsRequest, w io.Writer) error {
+	filter, err := toAuditFilter(&req.AuditEventFilterRequest)
+	if err != nil {
+		return err
+	}
+	// Pin the upper bound so that the export is what was recorded when it started. The pages are
+	// read by keyset (see domain.AuditCursor), so events sharing a timestamp across a page boundary
+	// are neither skipped nor repeated.
+	if filter.To == nil {
+		now := time.Now().UTC()
+		filter.To = &now
+	}
+	filter.Limit = auditExportBatchSize
+
+	var write func(*domain.AuditEvent) error
+	var flush func() error
+	switch req.Format {
+	case "", AuditExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"id", "occurred_at", "actor_id", "action", "target_type", "target_id", "request_id", "ip", "changes"}); err != nil {
+			return err
+		}
+		write = func(event *domain.AuditEvent) error {
+			row := response.NewAuditEventResponse(event)
+			changes := ""
+			if len(row.Changes) > 0 {
+				b, err := json.Marshal(row.Changes)
+				if err != nil {
+					return err
+				}
+				changes = string(b)
+			}
+			return cw.Write([]string{row.ID, row.OccurredAt.Format(time.RFC3339Nano), row.ActorID, row.Action, row.TargetType, row.TargetID, row.RequestID, row.IP, changes})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case AuditExportNDJSON:
+		enc := json.NewEncoder(w)
+		write = func(event *domain.AuditEvent) error {
+			return enc.Encode(response.NewAuditEventResponse(event))
+		}
+		flush = func() error { return nil }
+	default:
+		return &domain.InvalidArgumentError{ArgumentName: "format", Reason: "must be csv or ndjson", Code: domain.ReasonExportFormat}
+	}
+
+	for {
+		events, _, err := s.auditRepo.List(ctx, filter)
+		if err != nil {
+			s.logger.Error("Failed to export audit events", zap.Error(err))
+			return fmt.Errorf("failed exporting audit events")
+		}
+		for _, event := range events {
+			if err := write(event); err != nil {
+				return fmt.Errorf("failed writing audit export: %w", err)
+			}
+		}
+		if err := flush(); err != nil {
+			return fmt.Errorf("failed writing audit export: %w", err)
+		}
+		if len(events) < filter.Limit {
+			return nil
+		}
+		last := events[len(events)-1]
+		filter.After = &domain.AuditCursor{OccurredAt: last.OccurredAt, ID: last.ID}
+	}
+}
+
+// toAuditFilter converts the query DTO into a domain.AuditFilter.
+func toAuditFilter(req *request.AuditEventFilterRequest) (domain.AuditFilter, error) {
+	filter := domain.AuditFilter{
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+	}
+	if req.ActorID != "" {
+		actorID, err := uuid.Parse(req.ActorID)
+		if err != nil {
+			return filter, &domain.InvalidArgumentError{ArgumentName: "actor_id", Reason: "must be a UUID", Code: domain.ReasonUUID}
+		}
+		filter.ActorID = &actorID
+	}
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return filter, &domain.InvalidArgumentError{ArgumentName: "from", Reason: "must be an RFC 3339 timestamp", Code: domain.ReasonTimestamp}
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return filter, &domain.InvalidArgumentError{ArgumentName: "to", Reason: "must be an RFC 3339 timestamp", Code: domain.ReasonTimestamp}
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
+
+// auditDiff returns the fields whose values differ between two snapshots.
+// Pass a nil snapshot for the side that doesn't exist (before a create, after a delete).
+func auditDiff(before, after map[string]interface{}) map[string]domain.FieldChange {
+	changes := make(map[string]domain.FieldChange)
+	for field, oldValue := range before {
+		newValue, ok := after[field]
+		if !ok {
+			newValue = nil
+		}
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes[field] = domain.FieldChange{Before: oldValue, After: newValue}
+		}
+	}
+	for field, newValue := range after {
+		if _, seen := before[field]; !seen {
+			changes[field] = domain.FieldChange{Before: nil, After: newValue}
+		}
+	}
+	return changes
+}
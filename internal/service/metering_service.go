@@ -0,0 +1,356 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package service /youGo/internal/service/metering_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+)
+
+const defaultMeteringFlushInterval = 10 * time.Second
+
+// MeteringService records billable usage and enforces the quotas of the plans. Usage is
+// aggregated in memory and written every flush interval, so that metering a request doesn't
+// cost a write. Quotas are therefore soft: they are checked against counts at most that old,
+// which concurrent instances may overshoot by what they recorded in the meantime. Within an
+// instance, Consume enforces them exactly.
+type MeteringService interface {
+	// Record counts delta events of meter (e.g. API calls) for each subject in the current period.
+	Record(ctx context.Context, meter string, delta int64, subjects ...string)
+	// Consume records n events of meter for each subject unless that exceeds the quota of one of
+	// them, in which case it records nothing and returns a *domain.QuotaExceededError. The check
+	// and the recording are atomic within the instance. A subject whose usage can't be loaded
+	// isn't checked: its usage is recorded, and the error returned along.
+	Consume(ctx context.Context, meter string, n int64, subjects ...string) error
+	// SetLevel records the current level of a level meter (seats) for subject.
+	SetLevel(ctx context.Context, subject, meter string, value int64) error
+	// CheckQuota returns a *domain.QuotaExceededError if n more of meter would exceed the quota
+	// of subject's plan.
+	CheckQuota(ctx context.Context, subject, meter string, n int64) error
+	// Report returns the usage of subject in the current period, with the quotas of its plan.
+	Report(ctx context.Context, subject string) (*response.UsageReportResponse, error)
+	// Flush writes the usage recorded since the last flush.
+	Flush(ctx context.Context) error
+	// Run flushes every flush interval until ctx is cancelled.
+	Run(ctx context.Context)
+}
+
+// Plan is a set of quotas: the usage allowed per period (or level) by meter. Meters without
+// a quota are unlimited.
+type Plan struct {
+	Name   string
+	Quotas map[string]int64
+}
+
+// MeteringOptions configure NewMeteringService.
+type MeteringOptions struct {
+	Daily         bool              // Billing periods are UTC days rather than months
+	FlushInterval time.Duration     // How often usage is written, and cached counts reloaded
+	DefaultPlan   string            // Plan of the subjects not in Subjects; empty for no quotas
+	Plans         map[string]Plan   // By name
+	Subjects      map[string]string // Plan name by subject, e.g. "org:acme": "pro"
+}
+
+// usageKey identifies a counter.
+type usageKey struct {
+	subject string
+	meter   string
+	period  time.Time
+}
+
+// usageEntry is the cached value of a counter.
+type usageEntry struct {
+	stored   int64     // In the repository when last loaded, plus what was flushed since
+	pending  int64     // Recorded but not flushed yet
+	loadedAt time.Time // Zero until loaded
+}
+
+type meteringService struct {
+	usageRepo domain.UsageRepository
+	opts      MeteringOptions
+	now       func() time.Time
+	logger    *zap.Logger
+
+	mu    sync.Mutex
+	usage map[usageKey]*usageEntry
+}
+
+// NewMeteringService constructor
+func NewMeteringService(repo domain.UsageRepository, opts MeteringOptions, logger *zap.Logger) MeteringService {
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultMeteringFlushInterval
+	}
+	return &meteringService{
+		usageRepo: repo,
+		opts:      opts,
+		now:       time.Now,
+		logger:    logger.Named("MeteringService"),
+		usage:     make(map[usageKey]*usageEntry),
+	}
+}
+
+// period returns the start and end of the billing period containing t.
+func (s *meteringService) period(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	if s.opts.Daily {
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1)
+	}
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// key returns the key of the counter of meter for subject at now.
+func (s *meteringService) key(subject, meter string, now time.Time) usageKey {
+	if domain.IsLevelMeter(meter) {
+		return usageKey{subject: subject, meter: meter, period: domain.LevelPeriod}
+	}
+	start, _ := s.period(now)
+	return usageKey{subject: subject, meter: meter, period: start}
+}
+
+// entry returns the cached counter of key, creating it. The caller holds mu.
+func (s *meteringService) entry(key usageKey) *usageEntry {
+	e, ok := s.usage[key]
+	if !ok {
+		e = new(usageEntry)
+		s.usage[key] = e
+	}
+	return e
+}
+
+// plan returns the plan of subject, if any.
+func (s *meteringService) plan(subject string) (Plan, bool) {
+	name, ok := s.opts.Subjects[subject]
+	if !ok {
+		name = s.opts.DefaultPlan
+	}
+	plan, ok := s.opts.Plans[name]
+	return plan, ok
+}
+
+func (s *meteringService) Record(_ context.Context, meter string, delta int64, subjects ...string) {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subject := range subjects {
+		s.entry(s.key(subject, meter, now)).pending += delta
+	}
+}
+
+func (s *meteringService) Consume(ctx context.Context, meter string, n int64, subjects ...string) error {
+	now := s.now()
+	type quota struct {
+		key   usageKey
+		plan  string
+		limit int64
+	}
+	var quotas []quota
+	var loadErrs []error
+	for _, subject := range subjects {
+		plan, ok := s.plan(subject)
+		if !ok {
+			continue
+		}
+		limit, ok := plan.Quotas[meter]
+		if !ok {
+			continue
+		}
+		// Loads the counter, or reloads it if it is stale; it is checked below under the lock
+		key := s.key(subject, meter, now)
+		if _, err := s.used(ctx, key, now); err != nil {
+			loadErrs = append(loadErrs, fmt.Errorf("%s: %w", subject, err))
+			continue
+		}
+		quotas = append(quotas, quota{key: key, plan: plan.Name, limit: limit})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, q := range quotas {
+		e := s.entry(q.key)
+		if e.stored+e.pending+n > q.limit {
+			_, reset := s.period(now)
+			return &domain.QuotaExceededError{Subject: q.key.subject, Meter: meter, Plan: q.plan, Limit: q.limit, Reset: reset}
+		}
+	}
+	for _, subject := range subjects {
+		s.entry(s.key(subject, meter, now)).pending += n
+	}
+	return errors.Join(loadErrs...)
+}
+
+func (s *meteringService) SetLevel(ctx context.Context, subject, meter string, value int64) error {
+	if !domain.IsLevelMeter(meter) {
+		return fmt.Errorf("metering: %s is not a level meter", meter)
+	}
+	now := s.now()
+	key := s.key(subject, meter, now)
+	if err := s.usageRepo.Set(ctx, domain.UsageCounter{Subject: subject, Meter: meter, PeriodStart: key.period, Value: value}); err != nil {
+		return fmt.Errorf("setting usage level: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*s.entry(key) = usageEntry{stored: value, loadedAt: now}
+	return nil
+}
+
+func (s *meteringService) CheckQuota(ctx context.Context, subject, meter string, n int64) error {
+	plan, ok := s.plan(subject)
+	if !ok {
+		return nil
+	}
+	limit, ok := plan.Quotas[meter]
+	if !ok {
+		return nil
+	}
+	now := s.now()
+	used, err := s.used(ctx, s.key(subject, meter, now), now)
+	if err != nil {
+		return err
+	}
+	if used+n <= limit {
+		return nil
+	}
+	_, reset := s.period(now)
+	return &domain.QuotaExceededError{Subject: subject, Meter: meter, Plan: plan.Name, Limit: limit, Reset: reset}
+}
+
+// used returns the usage of a counter, reloading the stored value once it is older than the
+// flush interval: other instances flush theirs meanwhile.
+func (s *meteringService) used(ctx context.Context, key usageKey, now time.Time) (int64, error) {
+	s.mu.Lock()
+	if e, ok := s.usage[key]; ok && now.Sub(e.loadedAt) < s.opts.FlushInterval {
+		used := e.stored + e.pending
+		s.mu.Unlock()
+		return used, nil
+	}
+	s.mu.Unlock()
+
+	// The other counters of the subject in the period come along
+	counters, err := s.usageRepo.List(ctx, key.subject, key.period)
+	if err != nil {
+		return 0, fmt.Errorf("loading usage: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.entry(key)
+	e.stored, e.loadedAt = 0, now
+	for _, counter := range counters {
+		loaded := s.entry(usageKey{subject: key.subject, meter: counter.Meter, period: key.period})
+		loaded.stored, loaded.loadedAt = counter.Value, now
+	}
+	return e.stored + e.pending, nil
+}
+
+func (s *meteringService) Report(ctx context.Context, subject string) (*response.UsageReportResponse, error) {
+	if !domain.ValidSubject(subject) {
+		return nil, &domain.InvalidArgumentError{ArgumentName: "subject", Reason: "must be user:<id> or org:<id>", Code: domain.ReasonUsageSubject}
+	}
+	now := s.now()
+	start, end := s.period(now)
+	periodCounters, err := s.usageRepo.List(ctx, subject, start)
+	if err != nil {
+		return nil, fmt.Errorf("listing usage: %w", err)
+	}
+	levelCounters, err := s.usageRepo.List(ctx, subject, domain.LevelPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("listing usage levels: %w", err)
+	}
+
+	used := make(map[string]int64)
+	for _, counter := range append(periodCounters, levelCounters...) {
+		used[counter.Meter] = counter.Value
+	}
+	s.mu.Lock()
+	for key, e := range s.usage {
+		if key.subject == subject && (key.period.Equal(start) || key.period.Equal(domain.LevelPeriod)) {
+			used[key.meter] += e.pending
+		}
+	}
+	s.mu.Unlock()
+
+	report := &response.UsageReportResponse{Subject: subject, PeriodStart: start, PeriodEnd: end, Meters: []response.UsageMeterResponse{}}
+	plan, hasPlan := s.plan(subject)
+	if hasPlan {
+		report.Plan = plan.Name
+		for meter := range plan.Quotas {
+			if _, ok := used[meter]; !ok {
+				used[meter] = 0 // Quotas not used yet are reported too
+			}
+		}
+	}
+	for meter, value := range used {
+		m := response.UsageMeterResponse{Meter: meter, Used: value}
+		if limit, ok := plan.Quotas[meter]; hasPlan && ok {
+			remaining := max(0, limit-value)
+			m.Limit, m.Remaining = &limit, &remaining
+		}
+		report.Meters = append(report.Meters, m)
+	}
+	slices.SortFunc(report.Meters, func(a, b response.UsageMeterResponse) int {
+		return strings.Compare(a.Meter, b.Meter)
+	})
+	return report, nil
+}
+
+func (s *meteringService) Flush(ctx context.Context) error {
+	now := s.now()
+	s.mu.Lock()
+	var counters []domain.UsageCounter
+	for key, e := range s.usage {
+		if e.pending != 0 {
+			counters = append(counters, domain.UsageCounter{Subject: key.subject, Meter: key.meter, PeriodStart: key.period, Value: e.pending})
+			e.stored += e.pending
+			e.pending = 0
+		} else if now.Sub(e.loadedAt) >= s.opts.FlushInterval {
+			delete(s.usage, key) // Reloaded when needed, e.g. by subjects active again or a new period
+		}
+	}
+	s.mu.Unlock()
+	if len(counters) == 0 {
+		return nil
+	}
+
+	if err := s.usageRepo.Add(ctx, counters); err != nil {
+		// Kept for the next flush
+		s.mu.Lock()
+		for _, counter := range counters {
+			e := s.entry(usageKey{subject: counter.Subject, meter: counter.Meter, period: counter.PeriodStart})
+			e.stored -= counter.Value
+			e.pending += counter.Value
+		}
+		s.mu.Unlock()
+		return fmt.Errorf("flushing usage: %w", err)
+	}
+	s.logger.Debug("Flushed usage", zap.Int("counters", len(counters)))
+	return nil
+}
+
+func (s *meteringService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Flush(ctx); err != nil {
+				s.logger.Error("Failed to flush usage", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package service /youGo/internal/service/outbox_relay.go
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youGo/internal/domain"
+)
+
+const (
+	defaultRelayInterval  = time.Second
+	defaultRelayBatchSize = 100
+	maxRelayBackoff       = 5 * time.Minute
+	relayPublishTimeout   = 30 * time.Second // Of each event, so that a stuck sink doesn't hold the batch
+)
+
+// OutboxRelay is a background worker that publishes outbox events to a sink.
+// Delivery is at-least-once: an event is only marked published after the sink accepted it,
+// and a failed event holds back later events of the same aggregate until it succeeds.
+// After maxAttempts failures, if set, the event goes dead instead, and still holds them back.
+type OutboxRelay struct {
+	outbox      domain.OutboxRepository
+	publisher   domain.EventPublisher
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	logger      *zap.Logger
+}
+
+// NewOutboxRelay constructor. Non-positive interval or batchSize fall back to defaults;
+// a non-positive maxAttempts retries forever.
+func NewOutboxRelay(outbox domain.OutboxRepository, publisher domain.EventPublisher, interval time.Duration, batchSize, maxAttempts int, logger *zap.Logger) *OutboxRelay {
+	if interval <= 0 {
+		interval = defaultRelayInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultRelayBatchSize
+	}
+	return &OutboxRelay{
+		outbox:      outbox,
+		publisher:   publisher,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		logger:      logger.Named("OutboxRelay"),
+	}
+}
+
+// Run polls the outbox until ctx is cancelled. Full batches are followed up immediately.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	r.logger.Info("Outbox relay started", zap.Duration("interval", r.interval), zap.Int("batchSize", r.batchSize))
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := r.RelayOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error("Outbox relay batch failed", zap.Error(err))
+		}
+		if processed < r.batchSize || err != nil {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("Outbox relay stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// RelayOnce publishes one batch of due events and returns how many were published.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	return r.outbox.ProcessPending(ctx, r.batchSize, func(ctx context.Context, event *domain.DomainEvent) (time.Time, error) {
+		ctx, cancel := context.WithTimeout(ctx, relayPublishTimeout)
+		defer cancel()
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			if r.maxAttempts > 0 && event.Attempts+1 >= r.maxAttempts {
+				r.logger.Error("Giving up on outbox event, later events of its aggregate are held back until it is requeued with `outbox requeue <eventID>`",
+					zap.String("eventID", event.ID.String()),
+					zap.String("type", event.Type),
+					zap.String("aggregateID", event.AggregateID),
+					zap.Int("attempts", event.Attempts+1),
+					zap.Error(err),
+				)
+				return time.Time{}, err
+			}
+			retryAt := time.Now().UTC().Add(relayBackoff(event.Attempts + 1))
+			r.logger.Warn("Failed to publish outbox event",
+				zap.String("eventID", event.ID.String()),
+				zap.String("type", event.Type),
+				zap.Int("attempt", event.Attempts+1),
+				zap.Time("retryAt", retryAt),
+				zap.Error(err),
+			)
+			return retryAt, err
+		}
+		r.logger.Debug("Published outbox event", zap.String("eventID", event.ID.String()), zap.String("type", event.Type))
+		return time.Time{}, nil
+	})
+}
+
+// relayBackoff doubles the delay with every attempt: 1s, 2s, 4s... capped at maxRelayBackoff.
+func relayBackoff(attempt int) time.Duration {
+	if attempt > 20 {
+		return maxRelayBackoff
+	}
+	delay := time.Second << (attempt - 1)
+	if delay > maxRelayBackoff {
+		return maxRelayBackoff
+	}
+	return delay
+}
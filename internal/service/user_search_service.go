@@ -0,0 +1,66 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package service /youGo/internal/service/user_search_service.go
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+	"youGo/internal/platform/textsearch"
+)
+
+const defaultUserSearchPageSize = 20
+
+// UserSearchService finds users for support staff.
+type UserSearchService interface {
+	Search(ctx context.Context, req *request.SearchUsersRequest) (*response.UserSearchResponse, error)
+}
+
+type userSearchService struct {
+	searcher domain.UserSearcher
+	logger   *zap.Logger
+}
+
+// NewUserSearchService constructor
+func NewUserSearchService(searcher domain.UserSearcher, logger *zap.Logger) UserSearchService {
+	return &userSearchService{
+		searcher: searcher,
+		logger:   logger,
+	}
+}
+
+// Search returns a page of the users matching req.Query, most relevant first.
+func (s *userSearchService) Search(ctx context.Context, req *request.SearchUsersRequest) (*response.UserSearchResponse, error) {
+	if len(textsearch.Terms(req.Query)) == 0 {
+		return nil, &domain.InvalidArgumentError{ArgumentName: "q", Reason: "must contain a letter or digit", Code: domain.ReasonSearchTerms}
+	}
+	q := domain.UserSearchQuery{Text: req.Query, Limit: req.Limit, Offset: req.Offset}
+	if q.Limit <= 0 {
+		q.Limit = defaultUserSearchPageSize
+	}
+
+	hits, total, err := s.searcher.Search(ctx, q)
+	if err != nil {
+		s.logger.Error("Failed to search users", zap.Error(err))
+		return nil, fmt.Errorf("failed searching users")
+	}
+
+	items := make([]response.UserSearchHitResponse, 0, len(hits))
+	for _, hit := range hits {
+		items = append(items, response.NewUserSearchHitResponse(hit))
+	}
+	return &response.UserSearchResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}, nil
+}
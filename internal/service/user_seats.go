@@ -0,0 +1,76 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package service /youGo/internal/service/user_seats.go
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+)
+
+// seatedUserService meters the user accounts of a UserService as the seats of one subject.
+type seatedUserService struct {
+	UserService
+	repo     domain.UserRepository
+	metering MeteringService
+	subject  string
+	logger   *zap.Logger
+}
+
+// MeterSeats returns svc billing its users as domain.MeterSeats of subject (e.g. "org:default"):
+// a user is created only while the seats quota of subject's plan allows one more, and the
+// seats level is set to the number of users after every create and delete. Concurrent creates
+// may overshoot the quota by one each, like the other quotas across instances.
+func MeterSeats(svc UserService, repo domain.UserRepository, metering MeteringService, subject string, logger *zap.Logger) UserService {
+	return &seatedUserService{UserService: svc, repo: repo, metering: metering, subject: subject, logger: logger}
+}
+
+func (s *seatedUserService) Create(ctx context.Context, req *request.CreateUserRequest) (*response.UserResponse, error) {
+	return s.CreateWithRole(ctx, req, domain.RoleUser)
+}
+
+func (s *seatedUserService) CreateWithRole(ctx context.Context, req *request.CreateUserRequest, role string) (*response.UserResponse, error) {
+	// Seats whose usage can't be loaded aren't checked, as metered API calls aren't
+	if err := s.metering.CheckQuota(ctx, s.subject, domain.MeterSeats, 1); err != nil {
+		var quota *domain.QuotaExceededError
+		if errors.As(err, &quota) {
+			return nil, err
+		}
+		s.logger.Warn("Failed to check the seats quota", zap.String("subject", s.subject), zap.Error(err))
+	}
+	user, err := s.UserService.CreateWithRole(ctx, req, role)
+	if err != nil {
+		return nil, err
+	}
+	s.updateSeats(ctx)
+	return user, nil
+}
+
+func (s *seatedUserService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.UserService.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.updateSeats(ctx)
+	return nil
+}
+
+// updateSeats sets the seats level to the number of users. The change is already saved, so a
+// failure is only logged: the next create or delete sets the level again.
+func (s *seatedUserService) updateSeats(ctx context.Context) {
+	count, err := s.repo.Count(ctx)
+	if err == nil {
+		err = s.metering.SetLevel(ctx, s.subject, domain.MeterSeats, count)
+	}
+	if err != nil {
+		s.logger.Error("Failed to update the seats level", zap.String("subject", s.subject), zap.Error(err))
+	}
+}
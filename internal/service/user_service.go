@@ -18,33 +18,54 @@ import (
 
 	"youGo/internal/auth"
 	"youGo/internal/domain"
+	"youGo/internal/platform/tracing"
 )
 
 // UserService interface (signatures already use uuid.UUID)
 type UserService interface {
 	Create(ctx context.Context, req *request.CreateUserRequest) (*response.UserResponse, error)
+	// CreateWithRole is Create giving the user role instead of domain.RoleUser, e.g. for the
+	// first admin; the role isn't part of CreateUserRequest so that clients can't pick it.
+	CreateWithRole(ctx context.Context, req *request.CreateUserRequest, role string) (*response.UserResponse, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*response.UserResponse, error)
+	// GetByIDForUpdate is GetByID locking the user until the end of the transaction carried by
+	// ctx, for updates computed from its current state (see domain.TxManager).
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*response.UserResponse, error)
 	Update(ctx context.Context, id uuid.UUID, req *request.UpdateUserRequest) (*response.UserResponse, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 // userService struct (remains the same)
 type userService struct {
-	userRepo domain.UserRepository
-	logger   *zap.Logger
+	userRepo  domain.UserRepository
+	outbox    domain.OutboxRepository
+	auditor   domain.AuditRecorder
+	txManager domain.TxManager
+	logger    *zap.Logger
 }
 
-// NewUserService constructor (remains the same)
-func NewUserService(repo domain.UserRepository, logger *zap.Logger) UserService {
+// NewUserService constructor. Every create, update and delete is saved in one transaction
+// together with its outbox event and audit record.
+func NewUserService(repo domain.UserRepository, outbox domain.OutboxRepository, auditor domain.AuditRecorder, txManager domain.TxManager, logger *zap.Logger) UserService {
 	return &userService{
-		userRepo: repo,
-		logger:   logger,
+		userRepo:  repo,
+		outbox:    outbox,
+		auditor:   auditor,
+		txManager: txManager,
+		logger:    logger,
 	}
 }
 
 // Create implementation
 func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest) (*response.UserResponse, error) {
-	s.logger.Debug("Attempting user creation", zap.String("email", req.Email))
+	return s.CreateWithRole(ctx, req, domain.RoleUser)
+}
+
+func (s *userService) CreateWithRole(ctx context.Context, req *request.CreateUserRequest, role string) (_ *response.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Create")
+	defer tracing.End(span, &err)
+
+	s.logger.Debug("Attempting user creation", zap.String("email", req.Email), zap.String("role", role))
 
 	existingUser, err := s.userRepo.FindByEmail(ctx, req.Email)
 	// ... (error checking remains same) ...
@@ -71,12 +92,24 @@ func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest
 		Email:        req.Email,
 		PasswordHash: hashedPassword,
 		IsActive:     true,
-		Role:         "user",
+		Role:         role,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
 
-	err = s.userRepo.Create(ctx, newUser) // Pass user object with uuid.UUID ID
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, newUser); err != nil { // Pass user object with uuid.UUID ID
+			return err
+		}
+		event, err := domain.NewUserEvent(domain.EventUserCreated, newUser)
+		if err != nil {
+			return err
+		}
+		if err := s.outbox.Append(ctx, event); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, domain.AuditActionUserCreated, newUser.ID, nil, userAuditSnapshot(newUser))
+	})
 	// ... (error checking remains same) ...
 	if err != nil {
 		s.logger.Error("Failed to create user in repository", zap.String("email", req.Email), zap.Error(err))
@@ -91,10 +124,26 @@ func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest
 }
 
 // GetByID implementation
-func (s *userService) GetByID(ctx context.Context, id uuid.UUID) (*response.UserResponse, error) {
+func (s *userService) GetByID(ctx context.Context, id uuid.UUID) (_ *response.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByID")
+	defer tracing.End(span, &err)
+
+	return s.getByID(ctx, id, s.userRepo.FindByID)
+}
+
+// GetByIDForUpdate implementation
+func (s *userService) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (_ *response.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetByIDForUpdate")
+	defer tracing.End(span, &err)
+
+	return s.getByID(ctx, id, s.userRepo.FindByIDForUpdate)
+}
+
+// getByID reads the user with find, hiding the repository errors other than domain.ErrNotFound.
+func (s *userService) getByID(ctx context.Context, id uuid.UUID, find func(context.Context, uuid.UUID) (*domain.User, error)) (*response.UserResponse, error) {
 	s.logger.Debug("Getting user by ID", zap.String("userID", id.String())) // Log string representation
 
-	user, err := s.userRepo.FindByID(ctx, id) // Pass uuid.UUID directly to repo
+	user, err := find(ctx, id) // Pass uuid.UUID directly to repo
 	if err != nil {
 		s.logger.Warn("Failed to get user by ID from repository", zap.String("userID", id.String()), zap.Error(err))
 		if errors.Is(err, domain.ErrNotFound) {
@@ -106,7 +155,10 @@ func (s *userService) GetByID(ctx context.Context, id uuid.UUID) (*response.User
 }
 
 // Update implementation
-func (s *userService) Update(ctx context.Context, id uuid.UUID, req *request.UpdateUserRequest) (*response.UserResponse, error) {
+func (s *userService) Update(ctx context.Context, id uuid.UUID, req *request.UpdateUserRequest) (_ *response.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	defer tracing.End(span, &err)
+
 	s.logger.Debug("Updating user profile", zap.String("userID", id.String())) // Log string representation
 
 	user, err := s.userRepo.FindByID(ctx, id) // Pass uuid.UUID directly to repo
@@ -119,6 +171,7 @@ func (s *userService) Update(ctx context.Context, id uuid.UUID, req *request.Upd
 		return nil, fmt.Errorf("failed retrieving user for update")
 	}
 
+	before := userAuditSnapshot(user)
 	updated := false
 	// ... (logic for updating fields remains same) ...
 	if req.Name != nil && *req.Name != user.Name {
@@ -133,10 +186,26 @@ func (s *userService) Update(ctx context.Context, id uuid.UUID, req *request.Upd
 		user.Role = *req.Role
 		updated = true
 	}
+	if req.Locale != nil && *req.Locale != user.Locale {
+		user.Locale = *req.Locale
+		updated = true
+	}
 
 	if updated {
 		user.UpdatedAt = time.Now().UTC()
-		err = s.userRepo.Update(ctx, user) // Pass user object with uuid.UUID ID
+		err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.userRepo.Update(ctx, user); err != nil { // Pass user object with uuid.UUID ID
+				return err
+			}
+			event, err := domain.NewUserEvent(domain.EventUserUpdated, user)
+			if err != nil {
+				return err
+			}
+			if err := s.outbox.Append(ctx, event); err != nil {
+				return err
+			}
+			return s.recordAudit(ctx, domain.AuditActionUserUpdated, user.ID, before, userAuditSnapshot(user))
+		})
 		if err != nil {
 			// ... (error handling remains same) ...
 			s.logger.Error("Failed to update user profile in repository", zap.String("userID", id.String()), zap.Error(err))
@@ -151,10 +220,35 @@ func (s *userService) Update(ctx context.Context, id uuid.UUID, req *request.Upd
 }
 
 // Delete implementation
-func (s *userService) Delete(ctx context.Context, id uuid.UUID) error {
+func (s *userService) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Delete")
+	defer tracing.End(span, &err)
+
 	s.logger.Debug("Deleting user", zap.String("userID", id.String())) // Log string representation
 
-	err := s.userRepo.Delete(ctx, id) // Pass uuid.UUID directly to repo
+	// Load the user first so the audit trail keeps what was deleted
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Warn("User not found for deletion", zap.String("userID", id.String()), zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed retrieving user for deletion")
+	}
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Delete(ctx, id); err != nil { // Pass uuid.UUID directly to repo
+			return err
+		}
+		event, err := domain.NewUserEventFromPayload(domain.EventUserDeleted, domain.UserEventPayload{ID: id})
+		if err != nil {
+			return err
+		}
+		if err := s.outbox.Append(ctx, event); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, domain.AuditActionUserDeleted, id, userAuditSnapshot(user), nil)
+	})
 	if err != nil {
 		s.logger.Error("Failed to delete user in repository", zap.String("userID", id.String()), zap.Error(err))
 		if errors.Is(err, domain.ErrNotFound) {
@@ -167,6 +261,28 @@ func (s *userService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// recordAudit reports a user change to the audit trail. It runs inside the change's
+// transaction, so a failure rolls the change back.
+func (s *userService) recordAudit(ctx context.Context, action string, userID uuid.UUID, before, after map[string]interface{}) error {
+	return s.auditor.Record(ctx, &domain.AuditEvent{
+		Action:     action,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   userID.String(),
+		Changes:    auditDiff(before, after),
+	})
+}
+
+// userAuditSnapshot captures the audited fields of a user. The password hash is deliberately left out.
+func userAuditSnapshot(user *domain.User) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     user.Name,
+		"email":    user.Email,
+		"isActive": user.IsActive,
+		"role":     user.Role,
+		"locale":   user.Locale,
+	}
+}
+
 // mapUserToUserResponse helper function
 func mapUserToUserResponse(user *domain.User) *response.UserResponse {
 	if user == nil {
@@ -178,6 +294,7 @@ func mapUserToUserResponse(user *domain.User) *response.UserResponse {
 		Email:     user.Email,
 		IsActive:  user.IsActive,
 		Role:      user.Role,
+		Locale:    user.Locale,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -0,0 +1,178 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package service /youGo/internal/service/webhook_dispatcher.go
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"youGo/internal/domain"
+	"youGo/internal/platform/webhook"
+)
+
+const (
+	defaultWebhookPollInterval = 5 * time.Second
+	defaultWebhookBatchSize    = 50
+	defaultWebhookMaxAttempts  = 8
+	webhookDeliveryLease       = 2 * time.Minute
+	minWebhookBackoff          = 30 * time.Second
+	maxWebhookBackoff          = time.Hour
+)
+
+// WebhookDispatcher is a background worker that sends queued webhook deliveries.
+// Failed attempts are retried with exponential backoff; after maxAttempts the delivery
+// is moved to the dead state, from where it can only be redelivered manually.
+type WebhookDispatcher struct {
+	webhookRepo domain.WebhookRepository
+	sender      *webhook.Sender
+	maxAttempts int
+	interval    time.Duration
+	batchSize   int
+	logger      *zap.Logger
+}
+
+// NewWebhookDispatcher constructor. Non-positive maxAttempts, interval or batchSize fall back to defaults.
+func NewWebhookDispatcher(repo domain.WebhookRepository, sender *webhook.Sender, maxAttempts int, interval time.Duration, batchSize int, logger *zap.Logger) *WebhookDispatcher {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	if interval <= 0 {
+		interval = defaultWebhookPollInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultWebhookBatchSize
+	}
+	return &WebhookDispatcher{
+		webhookRepo: repo,
+		sender:      sender,
+		maxAttempts: maxAttempts,
+		interval:    interval,
+		batchSize:   batchSize,
+		logger:      logger.Named("WebhookDispatcher"),
+	}
+}
+
+// Run polls for due deliveries until ctx is cancelled. Full batches are followed up immediately.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	d.logger.Info("Webhook dispatcher started", zap.Duration("interval", d.interval), zap.Int("batchSize", d.batchSize))
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		dispatched, err := d.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.Error("Webhook dispatch batch failed", zap.Error(err))
+		}
+		if dispatched < d.batchSize || err != nil {
+			select {
+			case <-ctx.Done():
+				d.logger.Info("Webhook dispatcher stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// DispatchOnce claims one batch of due deliveries, attempts each of them and returns how many were attempted.
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.webhookRepo.ClaimDueDeliveries(ctx, d.batchSize, webhookDeliveryLease)
+	if err != nil {
+		return 0, err
+	}
+
+	endpoints := make(map[uuid.UUID]*domain.WebhookEndpoint)
+	for i, delivery := range deliveries {
+		if ctx.Err() != nil {
+			// Unattempted deliveries become due again when their lease runs out.
+			return i, ctx.Err()
+		}
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			endpoint, err = d.webhookRepo.FindEndpointByID(ctx, delivery.Owner, delivery.EndpointID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return i, err
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+		d.attempt(ctx, endpoint, delivery)
+	}
+	return len(deliveries), nil
+}
+
+// attempt sends one delivery and records the outcome on it.
+func (d *WebhookDispatcher) attempt(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) {
+	now := time.Now().UTC()
+	log := d.logger.With(zap.String("deliveryID", delivery.ID.String()), zap.String("endpointID", delivery.EndpointID.String()))
+
+	if endpoint == nil || !endpoint.IsActive {
+		// Disabled while queued: park it rather than burn attempts. It can be redelivered once re-enabled.
+		delivery.Status = domain.WebhookDeliveryDead
+		delivery.LastError = "endpoint is disabled"
+		delivery.UpdatedAt = now
+		d.save(ctx, log, delivery)
+		return
+	}
+
+	result, err := d.sender.Send(ctx, webhook.Request{
+		URL:        endpoint.URL,
+		Secret:     endpoint.Secret,
+		DeliveryID: delivery.ID.String(),
+		EventID:    delivery.EventID.String(),
+		EventType:  delivery.EventType,
+		Body:       delivery.Payload,
+	})
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = result.StatusCode
+	delivery.LastResponse = result.Body
+	delivery.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.LastError = ""
+		log.Debug("Webhook delivered", zap.Int("attempt", delivery.Attempts), zap.Int("status", result.StatusCode))
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = domain.WebhookDeliveryDead
+		delivery.LastError = err.Error()
+		log.Warn("Webhook delivery failed permanently", zap.Int("attempts", delivery.Attempts), zap.Error(err))
+	default:
+		delivery.Status = domain.WebhookDeliveryPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+		log.Info("Webhook delivery failed, will retry",
+			zap.Int("attempt", delivery.Attempts),
+			zap.Time("retryAt", delivery.NextAttemptAt),
+			zap.Error(err),
+		)
+	}
+	d.save(ctx, log, delivery)
+}
+
+func (d *WebhookDispatcher) save(ctx context.Context, log *zap.Logger, delivery *domain.WebhookDelivery) {
+	// A failed save leaves the lease in place, so the delivery is simply attempted again later.
+	if err := d.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Error("Failed to record webhook delivery result", zap.Error(err))
+	}
+}
+
+// webhookBackoff doubles the delay with every attempt: 30s, 1m, 2m... capped at maxWebhookBackoff.
+func webhookBackoff(attempt int) time.Duration {
+	if attempt > 20 {
+		return maxWebhookBackoff
+	}
+	delay := minWebhookBackoff << (attempt - 1)
+	if delay > maxWebhookBackoff {
+		return maxWebhookBackoff
+	}
+	return delay
+}
@@ -0,0 +1,359 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package service /youGo/internal/service/webhook_service.go
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+	"youGo/internal/platform/eventbus"
+)
+
+const (
+	defaultWebhookPageSize = 50
+	webhookSecretPrefix    = "whsec_"
+)
+
+// WebhookService manages webhook endpoints and their delivery log on behalf of their owner,
+// a subject such as "user:<id>" or "org:<id>"; endpoints of other owners are not found.
+// It is also an outbox sink: Publish queues one delivery per subscribed endpoint of the
+// event's owner, which the WebhookDispatcher then sends.
+type WebhookService interface {
+	domain.EventPublisher
+	CreateEndpoint(ctx context.Context, owner string, req *request.CreateWebhookEndpointRequest) (*response.WebhookEndpointSecretResponse, error)
+	ListEndpoints(ctx context.Context, owner string) ([]response.WebhookEndpointResponse, error)
+	GetEndpoint(ctx context.Context, owner string, id uuid.UUID) (*response.WebhookEndpointResponse, error)
+	UpdateEndpoint(ctx context.Context, owner string, id uuid.UUID, req *request.UpdateWebhookEndpointRequest) (*response.WebhookEndpointResponse, error)
+	DeleteEndpoint(ctx context.Context, owner string, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, owner string, endpointID uuid.UUID, req *request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error)
+	Redeliver(ctx context.Context, owner string, deliveryID uuid.UUID) (*response.WebhookDeliveryResponse, error)
+}
+
+type webhookService struct {
+	webhookRepo domain.WebhookRepository
+	auditor     domain.AuditRecorder
+	txManager   domain.TxManager
+	checkURL    func(ctx context.Context, rawURL string) error
+	logger      *zap.Logger
+}
+
+// NewWebhookService constructor. Endpoint changes and redeliveries are saved in one transaction
+// together with their audit record. checkURL vets the URL of endpoints when they are created
+// or changed, e.g. httpclient.CheckPublicURL, so that users can't make the server call into
+// its own network.
+func NewWebhookService(repo domain.WebhookRepository, auditor domain.AuditRecorder, txManager domain.TxManager,
+	checkURL func(ctx context.Context, rawURL string) error, logger *zap.Logger) WebhookService {
+	return &webhookService{
+		webhookRepo: repo,
+		auditor:     auditor,
+		txManager:   txManager,
+		checkURL:    checkURL,
+		logger:      logger.Named("WebhookService"),
+	}
+}
+
+// CreateEndpoint registers an endpoint. A signing secret is generated when none is supplied;
+// this is the only response that ever contains it.
+func (s *webhookService) CreateEndpoint(ctx context.Context, owner string, req *request.CreateWebhookEndpointRequest) (*response.WebhookEndpointSecretResponse, error) {
+	if err := s.vetURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		generated, err := newWebhookSecret()
+		if err != nil {
+			s.logger.Error("Failed to generate webhook secret", zap.Error(err))
+			return nil, fmt.Errorf("internal server error generating webhook secret")
+		}
+		secret = generated
+	}
+
+	now := time.Now().UTC()
+	endpoint := &domain.WebhookEndpoint{
+		ID:          uuid.New(),
+		Owner:       owner,
+		URL:         req.URL,
+		Secret:      secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, domain.AuditActionWebhookCreated, domain.AuditTargetWebhookEndpoint, endpoint.ID, nil, webhookAuditSnapshot(endpoint))
+	})
+	if err != nil {
+		s.logger.Error("Failed to create webhook endpoint", zap.Error(err))
+		return nil, fmt.Errorf("failed creating webhook endpoint")
+	}
+
+	return &response.WebhookEndpointSecretResponse{
+		WebhookEndpointResponse: response.NewWebhookEndpointResponse(endpoint),
+		Secret:                  endpoint.Secret,
+	}, nil
+}
+
+// ListEndpoints implementation
+func (s *webhookService) ListEndpoints(ctx context.Context, owner string) ([]response.WebhookEndpointResponse, error) {
+	endpoints, err := s.webhookRepo.ListEndpoints(ctx, owner)
+	if err != nil {
+		s.logger.Error("Failed to list webhook endpoints", zap.Error(err))
+		return nil, fmt.Errorf("failed retrieving webhook endpoints")
+	}
+	items := make([]response.WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		items = append(items, response.NewWebhookEndpointResponse(endpoint))
+	}
+	return items, nil
+}
+
+// GetEndpoint implementation
+func (s *webhookService) GetEndpoint(ctx context.Context, owner string, id uuid.UUID) (*response.WebhookEndpointResponse, error) {
+	endpoint, err := s.findEndpoint(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := response.NewWebhookEndpointResponse(endpoint)
+	return &resp, nil
+}
+
+// UpdateEndpoint implementation
+func (s *webhookService) UpdateEndpoint(ctx context.Context, owner string, id uuid.UUID, req *request.UpdateWebhookEndpointRequest) (*response.WebhookEndpointResponse, error) {
+	endpoint, err := s.findEndpoint(ctx, owner, id)
+	if err != nil {
+		return nil, err
+	}
+	before := webhookAuditSnapshot(endpoint)
+
+	if req.URL != nil {
+		if err := s.vetURL(ctx, *req.URL); err != nil {
+			return nil, err
+		}
+		endpoint.URL = *req.URL
+	}
+	if req.EventTypes != nil {
+		endpoint.EventTypes = req.EventTypes
+	}
+	if req.Description != nil {
+		endpoint.Description = *req.Description
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+	endpoint.UpdatedAt = time.Now().UTC()
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, domain.AuditActionWebhookUpdated, domain.AuditTargetWebhookEndpoint, endpoint.ID, before, webhookAuditSnapshot(endpoint))
+	})
+	if err != nil {
+		s.logger.Error("Failed to update webhook endpoint", zap.String("endpointID", id.String()), zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed updating webhook endpoint")
+	}
+
+	resp := response.NewWebhookEndpointResponse(endpoint)
+	return &resp, nil
+}
+
+// DeleteEndpoint removes an endpoint together with its delivery log.
+func (s *webhookService) DeleteEndpoint(ctx context.Context, owner string, id uuid.UUID) error {
+	endpoint, err := s.findEndpoint(ctx, owner, id)
+	if err != nil {
+		return err
+	}
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.webhookRepo.DeleteEndpoint(ctx, owner, id); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, domain.AuditActionWebhookDeleted, domain.AuditTargetWebhookEndpoint, id, webhookAuditSnapshot(endpoint), nil)
+	})
+	if err != nil {
+		s.logger.Error("Failed to delete webhook endpoint", zap.String("endpointID", id.String()), zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed deleting webhook endpoint")
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log of one endpoint, newest first.
+func (s *webhookService) ListDeliveries(ctx context.Context, owner string, endpointID uuid.UUID, req *request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error) {
+	if _, err := s.findEndpoint(ctx, owner, endpointID); err != nil {
+		return nil, err
+	}
+	filter := domain.WebhookDeliveryFilter{
+		Owner:      owner,
+		EndpointID: &endpointID,
+		Status:     req.Status,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultWebhookPageSize
+	}
+
+	deliveries, total, err := s.webhookRepo.ListDeliveries(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list webhook deliveries", zap.String("endpointID", endpointID.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed retrieving webhook deliveries")
+	}
+	items := make([]response.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, response.NewWebhookDeliveryResponse(delivery))
+	}
+	return &response.WebhookDeliveryListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// Redeliver puts a delivery back in the queue with a fresh retry budget, whatever its status.
+// The previous attempt's result stays visible until the next attempt overwrites it.
+func (s *webhookService) Redeliver(ctx context.Context, owner string, deliveryID uuid.UUID) (*response.WebhookDeliveryResponse, error) {
+	delivery, err := s.webhookRepo.FindDeliveryByID(ctx, owner, deliveryID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		s.logger.Error("Failed to find webhook delivery", zap.String("deliveryID", deliveryID.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed retrieving webhook delivery")
+	}
+	previousStatus := delivery.Status
+
+	now := time.Now().UTC()
+	delivery.Status = domain.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.UpdatedAt = now
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		return s.recordAudit(ctx, domain.AuditActionWebhookRedelivered, domain.AuditTargetWebhookDelivery, delivery.ID,
+			map[string]interface{}{"status": previousStatus}, map[string]interface{}{"status": delivery.Status})
+	})
+	if err != nil {
+		s.logger.Error("Failed to requeue webhook delivery", zap.String("deliveryID", deliveryID.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed requeueing webhook delivery")
+	}
+
+	resp := response.NewWebhookDeliveryResponse(delivery)
+	return &resp, nil
+}
+
+// Publish queues event for every active endpoint of its owner subscribed to its type; events
+// without an owner go to no endpoint. Returning an error makes the outbox relay retry the event later.
+func (s *webhookService) Publish(ctx context.Context, event *domain.DomainEvent) error {
+	if event.Owner == "" {
+		return nil
+	}
+	endpoints, err := s.webhookRepo.ListActiveEndpointsFor(ctx, event.Owner, event.Type)
+	if err != nil {
+		return fmt.Errorf("finding webhook endpoints for %s: %w", event.Type, err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(eventbus.NewEnvelope(event))
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload for event %s: %w", event.ID, err)
+	}
+	now := time.Now().UTC()
+	deliveries := make([]*domain.WebhookDelivery, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			Owner:         endpoint.Owner,
+			EndpointID:    endpoint.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+	if err := s.webhookRepo.CreateDeliveries(ctx, deliveries...); err != nil {
+		return fmt.Errorf("queueing webhook deliveries for event %s: %w", event.ID, err)
+	}
+	s.logger.Debug("Queued webhook deliveries", zap.String("eventID", event.ID.String()), zap.Int("endpoints", len(endpoints)))
+	return nil
+}
+
+// vetURL returns an invalid argument error if the URL of an endpoint is refused by checkURL.
+func (s *webhookService) vetURL(ctx context.Context, rawURL string) error {
+	if err := s.checkURL(ctx, rawURL); err != nil {
+		s.logger.Info("Refused webhook endpoint URL", zap.String("url", rawURL), zap.Error(err))
+		return &domain.InvalidArgumentError{ArgumentName: "url", Reason: err.Error(), Code: domain.ReasonPublicURL}
+	}
+	return nil
+}
+
+func (s *webhookService) findEndpoint(ctx context.Context, owner string, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.webhookRepo.FindEndpointByID(ctx, owner, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		s.logger.Error("Failed to find webhook endpoint", zap.String("endpointID", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed retrieving webhook endpoint")
+	}
+	return endpoint, nil
+}
+
+// recordAudit writes an audit event inside the change's transaction, so a failure rolls the change back.
+func (s *webhookService) recordAudit(ctx context.Context, action, targetType string, id uuid.UUID, before, after map[string]interface{}) error {
+	return s.auditor.Record(ctx, &domain.AuditEvent{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   id.String(),
+		Changes:    auditDiff(before, after),
+	})
+}
+
+// webhookAuditSnapshot captures the audited fields of an endpoint. The secret is deliberately left out.
+func webhookAuditSnapshot(endpoint *domain.WebhookEndpoint) map[string]interface{} {
+	return map[string]interface{}{
+		"owner":       endpoint.Owner,
+		"url":         endpoint.URL,
+		"eventTypes":  endpoint.EventTypes,
+		"description": endpoint.Description,
+		"isActive":    endpoint.IsActive,
+	}
+}
+
+// newWebhookSecret returns a random signing secret with 256 bits of entropy.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,3 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_reject_modification();
@@ -0,0 +1,34 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+CREATE TABLE IF NOT EXISTS audit_events
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id    UUID,
+    action      VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50)  NOT NULL,
+    target_id   VARCHAR(255) NOT NULL,
+    changes     JSONB,
+    request_id  VARCHAR(100),
+    ip          VARCHAR(64),
+    occurred_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action);
+CREATE INDEX IF NOT EXISTS idx_audit_events_occurred_at ON audit_events (occurred_at);
+
+-- The audit trail is append-only: reject any attempt to rewrite or remove history.
+CREATE OR REPLACE FUNCTION audit_events_reject_modification() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only (% is not allowed)', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_reject_modification();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_reject_modification();
@@ -0,0 +1,15 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- Requests sent with an Idempotency-Key (see middleware.Idempotency): the lock of a request in
+-- flight (status 0), then its response, replayed to retries until expires_at.
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key         VARCHAR(64) PRIMARY KEY, -- SHA-256 of the client's key, scoped to the caller and route
+    fingerprint VARCHAR(64) NOT NULL,
+    status      INTEGER     NOT NULL DEFAULT 0,
+    header      JSONB,
+    body        BYTEA,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
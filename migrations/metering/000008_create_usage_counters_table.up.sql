@@ -0,0 +1,12 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- Billable usage (see service.MeteringService), aggregated per subject ("user:<id>", "org:<id>"),
+-- meter and period. Level meters (storage, seats) keep a single row, at period_start 1970-01-01.
+CREATE TABLE IF NOT EXISTS usage_counters
+(
+    subject      VARCHAR(100) NOT NULL,
+    meter        VARCHAR(100) NOT NULL, -- e.g. api_calls:standard, storage_bytes, seats
+    period_start TIMESTAMPTZ  NOT NULL,
+    value        BIGINT       NOT NULL DEFAULT 0,
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (subject, period_start, meter)
+);
@@ -0,0 +1,37 @@
+// Copyright 2025 raph-abdul
+// Licensed under the Apache License, Version 2.0.
+// Visit http://www.apache.org/licenses/LICENSE-2.0 for details
+
+// Package migrations /youGo/migrations/migrations.go
+// The SQL files are embedded so that the server binary can apply them itself (see platform/migrate).
+// They follow the golang-migrate naming scheme: <version>_<name>.up.sql and <version>_<name>.down.sql.
+// Each directory belongs to the module owning the tables (see internal/modules); versions are
+// numbered across all directories.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed */*.sql
+var files embed.FS
+
+// Migrations of each module.
+var (
+	Users       = Dir("users")
+	Audit       = Dir("audit")
+	Outbox      = Dir("outbox")
+	Webhooks    = Dir("webhooks")
+	Idempotency = Dir("idempotency")
+	Metering    = Dir("metering")
+)
+
+// Dir returns the migrations of the module owning the directory.
+func Dir(dir string) fs.FS {
+	fsys, err := fs.Sub(files, dir)
+	if err != nil {
+		panic(err) // Only fails for an invalid path
+	}
+	return fsys
+}
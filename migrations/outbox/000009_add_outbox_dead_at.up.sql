@@ -0,0 +1,5 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- Set when the relay gave up on an event after outbox.max_attempts. A dead event isn't retried,
+-- and keeps holding back later events of its aggregate; clear dead_at to requeue it.
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS dead_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+ALTER TABLE outbox DROP COLUMN IF EXISTS owner;
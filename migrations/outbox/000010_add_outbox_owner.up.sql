@@ -0,0 +1,5 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- Subject owning the event, e.g. 'user:<id>' or 'org:<id>': only webhook endpoints of that
+-- owner receive it. Empty for events recorded before owners existed.
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS owner VARCHAR(100) NOT NULL DEFAULT '';
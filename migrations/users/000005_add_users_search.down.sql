@@ -0,0 +1,6 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+DROP INDEX IF EXISTS idx_users_search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+-- pg_trgm is left installed: other objects may depend on it
@@ -0,0 +1,15 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- User search (see postgres.NewUserSearchRepository): full-text over the words of the name and
+-- email, and trigram similarity for misspellings. The 'simple' configuration doesn't stem, which
+-- suits names; the email is split on its punctuation so that its domain is searchable too.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', translate(email, '@.-_+', '     ')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING GIN (email gin_trgm_ops);
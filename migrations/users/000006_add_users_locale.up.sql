@@ -0,0 +1,5 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- Preferred language of the messages sent to the user, e.g. 'id'; empty to follow the
+-- Accept-Language header of each request.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(16) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
@@ -0,0 +1,38 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+CREATE TABLE IF NOT EXISTS webhook_endpoints
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url         TEXT        NOT NULL,
+    secret      TEXT        NOT NULL,
+    event_types JSONB       NOT NULL DEFAULT '[]'::jsonb,
+    description VARCHAR(255),
+    is_active   BOOLEAN     NOT NULL DEFAULT true,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_event_types ON webhook_endpoints USING GIN (event_types);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    endpoint_id      UUID         NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    event_id         UUID         NOT NULL,
+    event_type       VARCHAR(100) NOT NULL,
+    payload          JSONB        NOT NULL,
+    status           VARCHAR(20)  NOT NULL DEFAULT 'pending',
+    attempts         INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    last_attempt_at  TIMESTAMPTZ,
+    last_status_code INTEGER,
+    last_response    TEXT,
+    last_error       TEXT,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint ON webhook_deliveries (endpoint_id, created_at DESC);
+-- An event is delivered to an endpoint at most once; redelivery reuses the row.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint_event ON webhook_deliveries (endpoint_id, event_id);
+-- The dispatcher only ever scans pending deliveries.
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
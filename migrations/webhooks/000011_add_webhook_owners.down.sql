@@ -0,0 +1,5 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS owner;
+
+DROP INDEX IF EXISTS idx_webhook_endpoints_owner;
+ALTER TABLE webhook_endpoints DROP COLUMN IF EXISTS owner;
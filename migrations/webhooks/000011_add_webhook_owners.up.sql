@@ -0,0 +1,12 @@
+-- Licensed under the Apache License, Version 2.0. See LICENSE file.
+-- Endpoints belong to a subject, e.g. 'user:<id>' or 'org:<id>', which manages them and whose
+-- events they receive. Endpoints registered before owners existed keep an empty owner: no one
+-- can see them and they receive no more events, so they have to be registered again.
+ALTER TABLE webhook_endpoints
+    ADD COLUMN IF NOT EXISTS owner VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_owner ON webhook_endpoints (owner, created_at);
+
+-- Copied from the endpoint, so that the delivery log is scoped without a join.
+ALTER TABLE webhook_deliveries
+    ADD COLUMN IF NOT EXISTS owner VARCHAR(100) NOT NULL DEFAULT '';
@@ -10,6 +10,8 @@ OUTPUT_DIR="./build"
 BINARY_NAME="you-go-server"
 # Path to the main Go package
 MAIN_PACKAGE="youGo/cmd/api"
+# Package holding the variables set by -ldflags (see internal/platform/buildinfo)
+BUILDINFO_PACKAGE="youGo/internal/platform/buildinfo"
 # Target Operating System (see `go tool dist list`)
 TARGET_OS="linux"
 # Target Architecture (see `go tool dist list`)
@@ -21,19 +23,19 @@ BUILD_DATE=$(date -u +'%Y-%m-%dT%H:%M:%SZ')
 
 # --- Build Flags ---
 # -ldflags allow embedding variables into the binary
-# Variable paths must match variables defined in your Go code (e.g., in main package)
-# Example Go code:
+# Variable paths must match the variables of the buildinfo package:
 #   var (
-#       AppVersion string
-#       BuildCommit string
-#       BuildDate   string
+#       Version = DevVersion
+#       Commit  string
+#       Date    string
 #   )
+# They are served at /version, printed by --version and exported as the build_info metric.
 #
 LDFLAGS=(
   "-s -w" # Strip debugging information and symbol table (-w omits DWARF symbols)
-  "-X '${MAIN_PACKAGE}.AppVersion=${VERSION}'"
-  "-X '${MAIN_PACKAGE}.BuildCommit=${COMMIT_HASH}'"
-  "-X '${MAIN_PACKAGE}.BuildDate=${BUILD_DATE}'"
+  "-X '${BUILDINFO_PACKAGE}.Version=${VERSION}'"
+  "-X '${BUILDINFO_PACKAGE}.Commit=${COMMIT_HASH}'"
+  "-X '${BUILDINFO_PACKAGE}.Date=${BUILD_DATE}'"
 )
 # Join the array elements into a single string for the ldflags argument
 LDFLAGS_STR="${LDFLAGS[*]}"
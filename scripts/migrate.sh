@@ -6,61 +6,36 @@ set -e
 # --- Configuration ---
 # Load .env file if it exists in the parent directory (for local execution)
 if [ -f ".env" ]; then
-  # Use 'export' with 'source' or '.' to make variables available to migrate command
+  # Use 'export' with 'source' or '.' to make variables available to the server binary
   echo "Loading environment variables from .env file"
   export $(grep -v '^#' .env | xargs)
 fi
 
-# Check if DATABASE_URL is set
-if [ -z "$DATABASE_URL" ]; then
-  echo "Error: DATABASE_URL environment variable is not set."
-  exit 1
+# The migrations are embedded in the server binary, which reads the same DB_* settings as the API.
+# Inside the container the binary is on the PATH; locally we fall back to `go run`.
+if command -v you-go-server >/dev/null 2>&1; then
+  SERVER="you-go-server"
+else
+  SERVER="go run ./cmd/api"
 fi
 
-MIGRATIONS_DIR="./migrations" # Path to your migrations folder
-
-# --- Command Logic ---
-COMMAND=$1
-
 # Print usage instructions if no command is given
-if [ -z "$COMMAND" ]; then
+if [ -z "$1" ]; then
   echo "Usage: $0 <command>"
   echo "Commands:"
   echo "  up          Apply all available migrations"
   echo "  down [N]    Revert N last migrations (default: 1)"
+  echo "  goto <V>    Migrate up or down to version V"
   echo "  force <V>   Set migration version V forcefully (use with caution)"
-  echo "  version     Print current migration version"
+  echo "  status      Print current migration version and pending migrations"
   exit 1
 fi
 
-# Execute migrate command
-case "$COMMAND" in
-  up)
-    echo "Applying migrations..."
-    migrate -database "$DATABASE_URL" -path "$MIGRATIONS_DIR" up
-    ;;
-  down)
-    COUNT=${2:-1} # Default to reverting 1 migration if no number is provided
-    echo "Reverting last $COUNT migration(s)..."
-    migrate -database "$DATABASE_URL" -path "$MIGRATIONS_DIR" down "$COUNT"
-    ;;
-  force)
-    VERSION=$2
-    if [ -z "$VERSION" ]; then
-      echo "Error: Please specify a version number for force."
-      exit 1
-    fi
-    echo "Forcing migration version to $VERSION..."
-    migrate -database "$DATABASE_URL" -path "$MIGRATIONS_DIR" force "$VERSION"
-    ;;
-  version)
-    echo "Checking migration version..."
-    migrate -database "$DATABASE_URL" -path "$MIGRATIONS_DIR" version
-    ;;
-  *)
-    echo "Error: Unknown command '$COMMAND'"
-    exit 1
-    ;;
-esac
+# Kept for compatibility with the golang-migrate CLI this script used to wrap
+if [ "$1" = "version" ]; then
+  set -- status
+fi
+
+$SERVER migrate "$@"
 
-echo "Migration command '$COMMAND' executed successfully."
\ No newline at end of file
+echo "Migration command '$1' executed successfully."
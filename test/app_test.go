@@ -0,0 +1,200 @@
+// /test/app_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/app"
+	"youGo/internal/modules"
+	"youGo/internal/platform/ratelimit"
+	repoImpl "youGo/internal/repository/postgres"
+)
+
+type greeter interface{ Greet() string }
+
+type englishGreeter struct{ name string }
+
+func (g englishGreeter) Greet() string { return "hello " + g.name }
+
+func TestContainerResolvesLazilyOnce(t *testing.T) {
+	c := app.NewContainer()
+	builds := 0
+	app.Provide(c, func(c *app.Container) (greeter, error) {
+		builds++
+		name, err := app.Resolve[string](c)
+		if err != nil {
+			return nil, err
+		}
+		return englishGreeter{name: name}, nil
+	})
+	// Registered after its dependent: order doesn't matter until Resolve
+	app.Supply(c, "world")
+	assert.Equal(t, 0, builds)
+
+	g, err := app.Resolve[greeter](c)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", g.Greet())
+	_, err = app.Resolve[greeter](c)
+	require.NoError(t, err)
+	assert.Equal(t, 1, builds, "components are built once and shared")
+
+	assert.True(t, app.Has[greeter](c))
+	assert.False(t, app.Has[int](c))
+	_, err = app.Resolve[int](c)
+	assert.ErrorContains(t, err, "nothing provides int")
+	assert.Panics(t, func() { app.Supply(c, "again") }, "a type may only be provided once")
+}
+
+func TestContainerReportsCycles(t *testing.T) {
+	c := app.NewContainer()
+	app.Provide(c, func(c *app.Container) (string, error) {
+		_, err := app.Resolve[int](c)
+		return "", err
+	})
+	app.Provide(c, func(c *app.Container) (int, error) {
+		_, err := app.Resolve[string](c)
+		return 0, err
+	})
+
+	_, err := app.Resolve[string](c)
+	assert.ErrorContains(t, err, "dependency cycle: string -> int -> string")
+}
+
+// sharedStoreModule replaces the rate limit store, like a deployment sharing counters in Redis.
+type sharedStoreModule struct{ store ratelimit.Store }
+
+func (sharedStoreModule) Name() string { return "sharedstore" }
+
+func (m sharedStoreModule) Provide(c *app.Container) {
+	app.Replace(c, func(*app.Container) (ratelimit.Store, error) { return m.store, nil })
+}
+
+func TestContainerReplace(t *testing.T) {
+	shared := ratelimit.NewRedisStore(ratelimit.NewFakeRedis(), "ratelimit:")
+	for name, order := range map[string][]app.Module{
+		"after the module": {modules.RateLimit(), sharedStoreModule{shared}},
+		"before it":        {sharedStoreModule{shared}, modules.RateLimit()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := app.NewContainer()
+			app.New(c, zap.NewNop(), order...)
+			store, err := app.Resolve[ratelimit.Store](c)
+			require.NoError(t, err)
+			assert.Same(t, shared, store)
+		})
+	}
+
+	t.Run("once", func(t *testing.T) {
+		c := app.NewContainer()
+		app.Replace(c, func(*app.Container) (string, error) { return "a", nil })
+		assert.Panics(t, func() { app.Replace(c, func(*app.Container) (string, error) { return "b", nil }) })
+	})
+
+	t.Run("not once built", func(t *testing.T) {
+		c := app.NewContainer()
+		app.Supply(c, "a")
+		_, err := app.Resolve[string](c)
+		require.NoError(t, err)
+		assert.Panics(t, func() { app.Replace(c, func(*app.Container) (string, error) { return "b", nil }) })
+	})
+}
+
+func TestLifecycleOrder(t *testing.T) {
+	var events []string
+	hook := func(name string, startErr error) app.Hook {
+		return app.Hook{
+			Name: name,
+			OnStart: func(context.Context) error {
+				events = append(events, "start "+name)
+				return startErr
+			},
+			OnStop: func(context.Context) error {
+				events = append(events, "stop "+name)
+				return nil
+			},
+		}
+	}
+
+	lc := app.NewLifecycle(zap.NewNop())
+	lc.Append(hook("worker", nil))
+	lc.Append(hook("server", nil))
+	require.NoError(t, lc.Start(context.Background()))
+	require.NoError(t, lc.Stop(context.Background()))
+	assert.Equal(t, []string{"start worker", "start server", "stop server", "stop worker"}, events)
+
+	// A failed start stops what was already started, and only that
+	events = nil
+	lc = app.NewLifecycle(zap.NewNop())
+	lc.Append(hook("worker", nil))
+	lc.Append(hook("server", errors.New("address in use")))
+	lc.Append(hook("never", nil))
+	err := lc.Start(context.Background())
+	assert.ErrorContains(t, err, "starting server: address in use")
+	assert.Equal(t, []string{"start worker", "start server", "stop worker"}, events)
+}
+
+func TestLifecycleGoWaitsForWorker(t *testing.T) {
+	lc := app.NewLifecycle(zap.NewNop())
+	stopped := make(chan struct{})
+	lc.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+	require.NoError(t, lc.Start(context.Background()))
+	require.NoError(t, lc.Stop(context.Background()))
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("Stop returned before the worker did")
+	}
+
+	// A worker ignoring cancellation makes Stop give up at the deadline
+	lc = app.NewLifecycle(zap.NewNop())
+	release := make(chan struct{})
+	defer close(release)
+	lc.Go("stuck", func(context.Context) { <-release })
+	require.NoError(t, lc.Start(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, lc.Stop(ctx), context.DeadlineExceeded)
+}
+
+func TestDefaultModulesWireUp(t *testing.T) {
+	cfg := validConfig()
+	c := app.NewContainer()
+	app.Supply(c, &cfg)
+	app.Supply(c, zap.NewNop())
+	app.Supply(c, (*gorm.DB)(nil)) // Nothing queries the database while wiring
+	app.Supply(c, repoImpl.NewTxManager(nil, 0))
+	application := app.New(c, zap.NewNop(), modules.Default()...)
+
+	require.NoError(t, application.RegisterHooks())
+	e := echo.New()
+	require.NoError(t, application.SetupRoutes(e))
+
+	routes := make(map[string]bool)
+	for _, r := range e.Routes() {
+		routes[r.Method+" "+r.Path] = true
+	}
+	for _, route := range []string{
+		"POST /api/v1/auth/login",
+		"PATCH /api/v1/users/:id",
+		"GET /api/v1/admin/audit-events",
+		"POST /api/v1/webhooks/deliveries/:deliveryId/redeliver",
+		"GET /api/v1/admin/diagnostics/database",
+		"GET /api/v1/admin/users/search",
+		"GET /healthz",
+		"GET /readyz",
+	} {
+		assert.True(t, routes[route], "missing route %s", route)
+	}
+}
@@ -0,0 +1,178 @@
+// /test/audit_test.go
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/database"
+	"youGo/internal/repository/memory"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+	"youGo/internal/service"
+)
+
+// openSchemaDB returns an in-memory SQLite database with the schema of the application.
+func openSchemaDB(t *testing.T) *gorm.DB {
+	db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, sqlite.Migrate(context.Background(), db))
+	return db
+}
+
+func TestAuditDiffOfUserChanges(t *testing.T) {
+	auditor := &recordingAuditor{}
+	users := service.NewUserService(memory.NewUserRepository(), &recordingOutbox{}, auditor, passthroughTxManager{}, zap.NewNop())
+	ctx := context.Background()
+
+	created, err := users.Create(ctx, &request.CreateUserRequest{Name: "Ann", Email: "ann@example.com", Password: "password123"})
+	require.NoError(t, err)
+	id := uuid.MustParse(created.ID)
+	require.Len(t, auditor.events, 1)
+	assert.Equal(t, domain.AuditActionUserCreated, auditor.events[0].Action)
+	assert.Equal(t, map[string]domain.FieldChange{
+		"name":     {Before: nil, After: "Ann"},
+		"email":    {Before: nil, After: "ann@example.com"},
+		"isActive": {Before: nil, After: true},
+		"role":     {Before: nil, After: domain.RoleUser},
+		"locale":   {Before: nil, After: ""},
+	}, auditor.events[0].Changes, "the password hash is never recorded")
+
+	name, sameRole := "Anna", domain.RoleUser
+	_, err = users.Update(ctx, id, &request.UpdateUserRequest{Name: &name, Role: &sameRole})
+	require.NoError(t, err)
+	require.Len(t, auditor.events, 2)
+	assert.Equal(t, map[string]domain.FieldChange{"name": {Before: "Ann", After: "Anna"}}, auditor.events[1].Changes,
+		"unchanged fields are left out")
+
+	_, err = users.Update(ctx, id, &request.UpdateUserRequest{Name: &name})
+	require.NoError(t, err)
+	assert.Len(t, auditor.events, 2, "an update changing nothing isn't audited")
+
+	require.NoError(t, users.Delete(ctx, id))
+	require.Len(t, auditor.events, 3)
+	deleted := auditor.events[2].Changes
+	assert.Equal(t, domain.FieldChange{Before: "Anna", After: nil}, deleted["name"])
+	assert.NotContains(t, deleted, "passwordHash")
+}
+
+func TestAuditExportPagesByKeyset(t *testing.T) {
+	db := openSchemaDB(t)
+	repo := repoImpl.NewAuditRepository(db)
+	audits := service.NewAuditService(repo, zap.NewNop())
+	ctx := context.Background()
+
+	// Groups of 7 events share a timestamp, so that the pages of 500 end in the middle of groups
+	const events = 1203
+	newest := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repoImpl.NewTxManager(db, 0).WithinTransaction(ctx, func(ctx context.Context) error {
+		for i := range events {
+			if err := repo.Append(ctx, &domain.AuditEvent{
+				Action: domain.AuditActionUserUpdated, TargetType: domain.AuditTargetUser, TargetID: uuid.NewString(),
+				OccurredAt: newest.Add(-time.Duration(i/7) * time.Second),
+			}); err != nil {
+				return err
+			}
+		}
+		// Recorded after the upper bound: left out
+		return repo.Append(ctx, &domain.AuditEvent{Action: domain.AuditActionUserDeleted, TargetType: domain.AuditTargetUser,
+			TargetID: "late", OccurredAt: newest.Add(time.Hour)})
+	}))
+
+	var out bytes.Buffer
+	export := &request.ExportAuditEventsRequest{Format: service.AuditExportNDJSON}
+	export.To = newest.Add(time.Second).Format(time.RFC3339)
+	require.NoError(t, audits.Export(ctx, export, &out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, events, "every event once, across 3 pages")
+	seen := make(map[string]bool, events)
+	var previous response.AuditEventResponse
+	for i, line := range lines {
+		var event response.AuditEventResponse
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		assert.False(t, seen[event.ID], "event %s repeated", event.ID)
+		seen[event.ID] = true
+		assert.NotEqual(t, "late", event.TargetID)
+		if i > 0 {
+			ordered := event.OccurredAt.Before(previous.OccurredAt) ||
+				event.OccurredAt.Equal(previous.OccurredAt) && event.ID < previous.ID
+			assert.True(t, ordered, "line %d isn't after line %d", i, i-1)
+		}
+		previous = event
+	}
+
+	// The cursor alone selects what follows it
+	page, _, err := repo.List(ctx, domain.AuditFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page, 10)
+	next, _, err := repo.List(ctx, domain.AuditFilter{Limit: 3, After: &domain.AuditCursor{OccurredAt: page[4].OccurredAt, ID: page[4].ID}})
+	require.NoError(t, err)
+	require.Len(t, next, 3)
+	for i, event := range next {
+		assert.Equal(t, page[5+i].ID, event.ID)
+	}
+}
+
+func TestAuditExportFormats(t *testing.T) {
+	db := openSchemaDB(t)
+	repo := repoImpl.NewAuditRepository(db)
+	audits := service.NewAuditService(repo, zap.NewNop())
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	occurredAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	tricky := &domain.AuditEvent{
+		ActorID: &actorID, Action: domain.AuditActionUserUpdated, TargetType: domain.AuditTargetUser,
+		TargetID:  "a,\"b\"\nc",
+		Changes:   map[string]domain.FieldChange{"name": {Before: `Ann "the admin"`, After: "Ann,\nSmith"}},
+		RequestID: "req-1", IP: "10.0.0.1", OccurredAt: occurredAt,
+	}
+	plain := &domain.AuditEvent{Action: domain.AuditActionAuthLoginFailed, TargetType: domain.AuditTargetEmail,
+		TargetID: "nobody@example.com", OccurredAt: occurredAt.Add(-time.Minute)}
+	require.NoError(t, repo.Append(ctx, tricky))
+	require.NoError(t, repo.Append(ctx, plain))
+
+	var out bytes.Buffer
+	require.NoError(t, audits.Export(ctx, &request.ExportAuditEventsRequest{}, &out), "CSV by default")
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "occurred_at", "actor_id", "action", "target_type", "target_id", "request_id", "ip", "changes"}, records[0])
+	assert.Equal(t, []string{tricky.ID.String(), "2025-05-01T12:00:00Z", actorID.String(), domain.AuditActionUserUpdated,
+		domain.AuditTargetUser, "a,\"b\"\nc", "req-1", "10.0.0.1", `{"name":{"before":"Ann \"the admin\"","after":"Ann,\nSmith"}}`}, records[1])
+	assert.Equal(t, "", records[2][2], "no actor")
+	assert.Equal(t, "", records[2][8], "no changes")
+
+	out.Reset()
+	require.NoError(t, audits.Export(ctx, &request.ExportAuditEventsRequest{Format: service.AuditExportNDJSON}, &out))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "newlines in values are escaped")
+	var first response.AuditEventResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "a,\"b\"\nc", first.TargetID)
+	assert.Equal(t, "Ann,\nSmith", first.Changes["name"].After)
+	assert.Equal(t, actorID.String(), first.ActorID)
+
+	var argErr *domain.InvalidArgumentError
+	require.ErrorAs(t, audits.Export(ctx, &request.ExportAuditEventsRequest{Format: "xml"}, &out), &argErr)
+	assert.Equal(t, "format", argErr.ArgumentName)
+}
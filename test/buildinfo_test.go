@@ -0,0 +1,95 @@
+// /test/buildinfo_test.go
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/router"
+	"youGo/internal/platform/buildinfo"
+)
+
+// setBuildVars sets the variables of buildinfo as -ldflags would, for the duration of the test.
+func setBuildVars(t *testing.T, version, commit, date string) {
+	t.Helper()
+	previousVersion, previousCommit, previousDate := buildinfo.Version, buildinfo.Commit, buildinfo.Date
+	buildinfo.Version, buildinfo.Commit, buildinfo.Date = version, commit, date
+	t.Cleanup(func() {
+		buildinfo.Version, buildinfo.Commit, buildinfo.Date = previousVersion, previousCommit, previousDate
+	})
+}
+
+func TestBuildInfoRead(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.24.2",
+		Main:      debug.Module{Path: "youGo", Version: "(devel)"},
+		Deps: []*debug.Module{
+			{Path: "github.com/labstack/echo/v4", Version: "v4.13.3", Sum: "h1:abc="},
+			{Path: "gorm.io/gorm", Version: "v1.25.0", Replace: &debug.Module{Path: "../gorm", Version: "(devel)"}},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "4f2a9c1e0b7d"},
+			{Key: "vcs.time", Value: "2025-05-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	setBuildVars(t, "", "", "")
+	info := buildinfo.Read(bi)
+	assert.Equal(t, buildinfo.DevVersion, info.Version)
+	assert.Equal(t, "4f2a9c1e0b7d", info.Commit, "the VCS revision without -ldflags")
+	assert.Equal(t, "2025-05-01T10:00:00Z", info.Date)
+	assert.True(t, info.Dirty)
+	assert.Equal(t, "go1.24.2", info.GoVersion)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+	assert.Equal(t, "youGo", info.Module)
+	assert.Equal(t, []buildinfo.Module{
+		{Path: "github.com/labstack/echo/v4", Version: "v4.13.3", Sum: "h1:abc="},
+		{Path: "gorm.io/gorm", Version: "(devel)", Replace: "../gorm"},
+	}, info.Deps)
+	assert.Equal(t, "dev (commit 4f2a9c1e0b7d-dirty, built 2025-05-01T10:00:00Z, go1.24.2 "+info.Platform+")", info.String())
+
+	setBuildVars(t, "1.2.0", "4f2a9c1", "2025-05-02T08:00:00Z")
+	info = buildinfo.Read(bi)
+	assert.Equal(t, "1.2.0", info.Version)
+	assert.Equal(t, "4f2a9c1", info.Commit, "-ldflags win")
+	assert.Equal(t, "2025-05-02T08:00:00Z", info.Date)
+	assert.Equal(t, buildinfo.Summary{Version: "1.2.0", Commit: "4f2a9c1"}, info.Summary())
+
+	info = buildinfo.Read(nil)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.False(t, info.Dirty)
+	assert.Empty(t, info.Deps)
+
+	setBuildVars(t, "1.2.0", "", "")
+	assert.Equal(t, "1.2.0 (commit unknown, built unknown, "+runtime.Version()+" "+info.Platform+")", buildinfo.Read(nil).String())
+}
+
+func TestVersionEndpoints(t *testing.T) {
+	e := echo.New()
+	require.NoError(t, router.SetupRoutes(e, router.Dependencies{Logger: zap.NewNop()}))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var public map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &public))
+	assert.Equal(t, buildinfo.Get().Version, public["version"])
+	assert.NotContains(t, public, "dependencies", "the public endpoint hides the build details")
+	assert.NotContains(t, public, "go_version")
+
+	rec = httptest.NewRecorder()
+	buildinfo.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var detailed buildinfo.Info
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &detailed))
+	assert.Equal(t, buildinfo.Get(), detailed, "the admin port serves the build in detail")
+}
@@ -0,0 +1,101 @@
+// /test/config_test.go
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"youGo/internal/config"
+)
+
+func validConfig() config.Config {
+	return config.Config{
+		App:    config.AppConfig{Env: "development"},
+		Server: config.ServerConfig{Port: "8080"},
+		Log:    config.LogConfig{Level: "info", Format: "json"},
+		Auth: config.AuthConfig{
+			JWTSecret:            "secret",
+			AccessTokenDuration:  "15m",
+			RefreshTokenDuration: "168h",
+		},
+		Database: config.Database{Host: "localhost", Port: "5432", User: "app", Password: "pw", DBName: "app"},
+		Outbox:   config.OutboxConfig{Enabled: true, PollInterval: "1s", Sinks: []string{"inprocess", "webhook_subscriptions"}},
+		Webhooks: config.WebhooksConfig{Enabled: true, PollInterval: "5s", Timeout: "10s", MaxAttempts: 8},
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := validConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Server.Port = "http"
+	cfg.Server.TrustedProxies = []string{"10.0.0.0/8", "10.0.0.1"}
+	cfg.Auth.JWTSecret = ""
+	cfg.Auth.AccessTokenDuration = "15 minutes"
+	cfg.Log.Level = "verbose"
+	cfg.Outbox.Sinks = []string{"webhook", "kafka"}
+	cfg.Metrics = config.MetricsConfig{Enabled: true, Port: "http"}
+	cfg.Health.ShutdownDelay = "soon"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	for _, problem := range []string{
+		`server.port: invalid port "http"`,
+		`server.trusted_proxies: invalid CIDR range "10.0.0.1"`,
+		"auth.jwt_secret is required",
+		`auth.access_token_duration: invalid duration "15 minutes"`,
+		`log.level: unknown level "verbose"`,
+		`outbox.webhook_url is required by the "webhook" sink`,
+		`outbox.sinks: unknown sink "kafka"`,
+		`metrics.port: invalid port "http"`,
+		`health.shutdown_delay: invalid duration "soon"`,
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+}
+
+func TestConfigValidateMetrics(t *testing.T) {
+	cfg := validConfig()
+	cfg.Metrics = config.MetricsConfig{Enabled: true, Port: "9090"}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, config.DefaultMetricsPath, cfg.Metrics.PathOrDefault())
+
+	cfg.Metrics = config.MetricsConfig{Enabled: true, Port: cfg.Server.Port, Path: "metrics"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metrics.port must differ from server.port")
+	assert.Contains(t, err.Error(), `metrics.path must start with "/"`)
+
+	cfg.Metrics.Enabled = false
+	assert.NoError(t, cfg.Validate(), "unused settings aren't checked")
+}
+
+func TestConfigValidateDriver(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database = config.Database{Driver: "SQLite", DBName: "dev.db"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outbox.enabled requires the postgres driver")
+	assert.Contains(t, err.Error(), "webhooks.enabled requires the postgres driver")
+	assert.NotContains(t, err.Error(), "database.host", "SQLite only needs a file")
+
+	cfg.Outbox.Enabled, cfg.Webhooks.Enabled = false, false
+	require.NoError(t, cfg.Validate())
+
+	cfg.Database.Driver = "mysql"
+	assert.ErrorContains(t, cfg.Validate(), `database.driver: unknown driver "mysql"`)
+}
+
+func TestConfigRedacted(t *testing.T) {
+	cfg := validConfig()
+	redacted := cfg.Redacted()
+
+	assert.Equal(t, "[REDACTED]", redacted.Database.Password)
+	assert.Equal(t, "[REDACTED]", redacted.Auth.JWTSecret)
+	assert.Equal(t, "localhost", redacted.Database.Host)
+	// The original is left untouched
+	assert.Equal(t, "pw", cfg.Database.Password)
+	assert.Equal(t, "secret", cfg.Auth.JWTSecret)
+}
@@ -0,0 +1,110 @@
+// /test/database_settings_test.go
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/response"
+	"youGo/internal/config"
+	"youGo/internal/platform/database"
+)
+
+func TestDatabaseSettingsFor(t *testing.T) {
+	assert.Equal(t, database.Settings{
+		Driver:              config.DriverPostgres,
+		MaxOpenConns:        100,
+		MaxIdleConns:        10,
+		ConnMaxLifetime:     time.Hour,
+		ConnectRetryBackoff: time.Second,
+		SlowQueryThreshold:  2 * time.Second,
+	}, database.SettingsFor(config.Database{}), "defaults")
+
+	cfg := config.Database{
+		MaxOpenConns: 20, MaxIdleConns: 5, ConnMaxLifetime: "0", ConnMaxIdleTime: "10m",
+		StatementTimeout: "30s", ConnectRetries: 3, ConnectRetryBackoff: "250ms", SlowQueryThreshold: "0",
+	}
+	assert.Equal(t, database.Settings{
+		Driver:              config.DriverPostgres,
+		MaxOpenConns:        20,
+		MaxIdleConns:        5,
+		ConnMaxIdleTime:     10 * time.Minute,
+		StatementTimeout:    30 * time.Second,
+		ConnectRetries:      3,
+		ConnectRetryBackoff: 250 * time.Millisecond,
+	}, database.SettingsFor(cfg), `"0" lifts the limit`)
+
+	cfg.Driver = config.DriverSQLite
+	sqlite := database.SettingsFor(cfg)
+	assert.Equal(t, []int{1, 1}, []int{sqlite.MaxOpenConns, sqlite.MaxIdleConns}, "a single connection")
+	assert.Zero(t, sqlite.StatementTimeout)
+	assert.Equal(t, 3, sqlite.ConnectRetries)
+}
+
+func TestConfigValidateDatabaseSettings(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.MaxOpenConns = -1
+	cfg.Database.ConnectRetries = -2
+	cfg.Database.StatementTimeout = "-1s"
+	cfg.Database.ConnMaxIdleTime = "0"
+	cfg.Database.ConnectRetryBackoff = "0"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.max_open_conns must not be negative")
+	assert.Contains(t, err.Error(), "database.connect_retries must not be negative")
+	assert.Contains(t, err.Error(), `database.statement_timeout: invalid duration "-1s"`)
+	assert.Contains(t, err.Error(), `database.connect_retry_backoff: invalid duration "0"`)
+	assert.NotContains(t, err.Error(), "conn_max_idle_time")
+}
+
+func TestDatabaseConnectRetries(t *testing.T) {
+	cfg := config.Database{
+		Driver:              config.DriverSQLite,
+		DBName:              filepath.Join(t.TempDir(), "missing", "app.db"), // The directory doesn't exist
+		ConnectRetries:      2,
+		ConnectRetryBackoff: "20ms",
+	}
+	core, logs := observer.New(zap.WarnLevel)
+	start := time.Now()
+	_, err := database.NewGORMConnection(cfg, zap.New(core))
+	require.Error(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond, "waits 20ms, then 40ms")
+	assert.Equal(t, 2, logs.FilterMessage("Database connection failed, retrying").Len())
+}
+
+func TestDatabaseDiagnosticsHandler(t *testing.T) {
+	cfg := config.Database{Driver: config.DriverSQLite, DBName: filepath.Join(t.TempDir(), "app.db"), SlowQueryThreshold: "250ms"}
+	db, err := database.NewGORMConnection(cfg, zap.NewNop())
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	h := handler.NewDiagnosticsHandler(db, database.SettingsFor(cfg), nil)
+	rec := httptest.NewRecorder()
+	e := echo.New()
+	require.NoError(t, h.GetDatabaseDiagnostics(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Data response.DatabaseDiagnosticsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "sqlite", body.Data.Driver)
+	assert.Equal(t, 1, body.Data.Settings.MaxOpenConns)
+	assert.Equal(t, "250ms", body.Data.Settings.SlowQueryThreshold)
+	assert.Equal(t, "0s", body.Data.Settings.StatementTimeout)
+	assert.Equal(t, 1, body.Data.Pool.OpenConnections, "the connection opened by the startup ping")
+	assert.Empty(t, body.Data.Replicas)
+}
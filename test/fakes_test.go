@@ -0,0 +1,40 @@
+// /test/fakes_test.go
+package test
+
+import (
+	"context"
+	"sync"
+
+	"youGo/internal/domain"
+)
+
+// passthroughTxManager runs the function without a transaction, for services under test with fake repositories.
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// recordingAuditor keeps the audit events a service records.
+type recordingAuditor struct {
+	mu     sync.Mutex
+	events []*domain.AuditEvent
+}
+
+func (a *recordingAuditor) Record(_ context.Context, event *domain.AuditEvent) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events = append(a.events, event)
+	return nil
+}
+
+// actions returns the actions recorded so far, in order.
+func (a *recordingAuditor) actions() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	actions := make([]string, 0, len(a.events))
+	for _, event := range a.events {
+		actions = append(actions, event.Action)
+	}
+	return actions
+}
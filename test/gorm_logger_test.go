@@ -0,0 +1,116 @@
+// /test/gorm_logger_test.go
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/config"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/requestctx"
+)
+
+// openLoggedDB opens a SQLite database with a notes table whose SQL is logged at level.
+func openLoggedDB(t *testing.T, level zapcore.Level, slowQueryThreshold string) (*gorm.DB, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	db, err := database.NewGORMConnection(config.Database{
+		Driver:             config.DriverSQLite,
+		DBName:             filepath.Join(t.TempDir(), "app.db"),
+		SlowQueryThreshold: slowQueryThreshold,
+	}, zap.New(core))
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY, source TEXT NOT NULL UNIQUE)").Error)
+	return db, logs
+}
+
+func TestGORMLogger(t *testing.T) {
+	ctx := requestctx.WithMetadata(context.Background(), requestctx.Metadata{
+		RequestID: "req-1",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+	})
+
+	t.Run("debug logs every statement, without its parameters", func(t *testing.T) {
+		db, logs := openLoggedDB(t, zap.DebugLevel, "")
+		require.NoError(t, db.WithContext(ctx).Create(&noteModel{Source: "s3cret"}).Error)
+
+		entries := logs.FilterMessage("SQL query").FilterFieldKey("request_id").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "gorm", entries[0].LoggerName)
+		assert.Contains(t, fields["sql"], "INSERT INTO `notes`")
+		assert.NotContains(t, fields["sql"], "s3cret", "bind parameters are redacted")
+		assert.Equal(t, int64(1), fields["rows"])
+		assert.Contains(t, fields, "duration")
+		assert.Equal(t, "req-1", fields["request_id"])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", fields["trace_id"])
+	})
+
+	t.Run("info logs no statement", func(t *testing.T) {
+		db, logs := openLoggedDB(t, zap.InfoLevel, "")
+		require.NoError(t, db.WithContext(ctx).Create(&noteModel{Source: "a"}).Error)
+		assert.Zero(t, logs.Len())
+	})
+
+	t.Run("slow queries", func(t *testing.T) {
+		db, logs := openLoggedDB(t, zap.InfoLevel, "1ns")
+		var notes []noteModel
+		require.NoError(t, db.WithContext(ctx).Where("source = ?", "s3cret").Find(&notes).Error)
+
+		entries := logs.FilterMessage("Slow SQL query").FilterFieldKey("request_id").All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, zap.WarnLevel, entries[0].Level)
+		fields := entries[0].ContextMap()
+		assert.NotContains(t, fields["sql"], "s3cret")
+		assert.Equal(t, int64(0), fields["rows"])
+		assert.Equal(t, time.Nanosecond, fields["slow_query_threshold"])
+		assert.Equal(t, "req-1", fields["request_id"])
+	})
+
+	t.Run("failed queries", func(t *testing.T) {
+		db, logs := openLoggedDB(t, zap.WarnLevel, "")
+		require.NoError(t, db.Create(&noteModel{Source: "a"}).Error)
+		require.Error(t, db.WithContext(ctx).Create(&noteModel{Source: "a"}).Error)
+
+		entries := logs.FilterMessage("SQL query failed").All()
+		require.Len(t, entries, 1)
+		assert.Contains(t, entries[0].ContextMap()["error"], "duplicated key")
+
+		var note noteModel
+		require.Error(t, db.First(&note, 42).Error)
+		assert.Equal(t, 1, logs.FilterMessage("SQL query failed").Len(), "record not found isn't logged")
+	})
+}
+
+func TestRequestContextTraceID(t *testing.T) {
+	for traceparent, want := range map[string]string{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01": "",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01": "",
+		"garbage": "",
+		"":        "",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("traceparent", traceparent)
+		var got requestctx.Metadata
+		e := echo.New()
+		err := middleware.RequestContext()(func(c echo.Context) error {
+			got = requestctx.FromContext(c.Request().Context())
+			return nil
+		})(e.NewContext(req, httptest.NewRecorder()))
+		require.NoError(t, err)
+		assert.Equal(t, want, got.TraceID, "traceparent %q", traceparent)
+	}
+}
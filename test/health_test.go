@@ -0,0 +1,169 @@
+// /test/health_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/config"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/health"
+	"youGo/internal/platform/migrate"
+)
+
+func TestHealthReadiness(t *testing.T) {
+	registry := health.NewRegistry(health.Options{Timeout: 50 * time.Millisecond, CacheTTL: time.Hour})
+	registry.Register("database", func(context.Context) error { return nil })
+	registry.Register("cache", func(context.Context) error { return errors.New("connection refused") })
+	registry.Register("broker", func(ctx context.Context) error {
+		<-ctx.Done() // Hangs until the timeout
+		return ctx.Err()
+	})
+	registry.Register("search", func(context.Context) error { panic("nil index") })
+	assert.Equal(t, []string{"broker", "cache", "database", "search"}, registry.Names())
+
+	report := registry.Readiness(context.Background())
+	assert.False(t, report.OK())
+	assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["broker"].Error)
+	assert.GreaterOrEqual(t, report.Checks["broker"].Duration, 50.0)
+	assert.Contains(t, report.Checks["search"].Error, "nil index", "panics fail the check")
+	assert.False(t, report.Checks["database"].CheckedAt.IsZero())
+
+	live := registry.Liveness(context.Background())
+	assert.True(t, live.OK(), "dependencies don't fail liveness")
+	assert.Empty(t, live.Checks)
+
+	registry.RegisterLiveness("worker", func(context.Context) error { return errors.New("deadlocked") })
+	assert.False(t, registry.Liveness(context.Background()).OK())
+}
+
+func TestHealthCache(t *testing.T) {
+	registry := health.NewRegistry(health.Options{CacheTTL: 100 * time.Millisecond})
+	var calls atomic.Int32
+	registry.Register("database", func(context.Context) error {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(t, registry.Readiness(context.Background()).OK())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load(), "concurrent probes share one check")
+
+	time.Sleep(150 * time.Millisecond)
+	registry.Readiness(context.Background())
+	assert.Equal(t, int32(2), calls.Load(), "expired results are checked again")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	time.Sleep(150 * time.Millisecond)
+	assert.True(t, registry.Readiness(ctx).OK(), "a probe giving up doesn't fail the shared result")
+}
+
+func TestHealthShutdown(t *testing.T) {
+	registry := health.NewRegistry(health.Options{})
+	registry.Register("database", func(context.Context) error { return nil })
+	require.True(t, registry.Readiness(context.Background()).OK())
+
+	registry.StartShutdown()
+	report := registry.Readiness(context.Background())
+	assert.False(t, report.OK())
+	assert.Equal(t, health.ErrShuttingDown.Error(), report.Checks[health.ShutdownCheck].Error)
+	assert.True(t, registry.Liveness(context.Background()).OK(), "the process is still alive")
+}
+
+func TestHealthHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	registry := health.NewRegistry(health.Options{CacheTTL: time.Nanosecond, Logger: zap.New(core)})
+	var failing atomic.Bool
+	registry.Register("database", func(context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	h := handler.NewHealthHandler(registry)
+	e := echo.New()
+	e.GET("/healthz", h.Liveness)
+	e.GET("/readyz", h.Readiness)
+
+	probe := func(path string) (int, string) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+		return rec.Code, rec.Body.String()
+	}
+
+	code, body := probe("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"status": "ok", "checks": {"database": "ok"}}`, body)
+
+	failing.Store(true)
+	time.Sleep(time.Millisecond)
+	code, body = probe("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.JSONEq(t, `{"status": "fail", "checks": {"database": "fail"}}`, body, "why checks fail isn't public")
+	require.Equal(t, 1, logs.FilterMessage("Health check failed").Len(), "it is logged")
+	entry := logs.All()[0].ContextMap()
+	assert.Equal(t, "database", entry["check"])
+	assert.Equal(t, "connection refused", entry["error"])
+	code, _ = probe("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestHealthChecks(t *testing.T) {
+	db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+	runner, err := migrate.NewRunner(sqlDB, []fs.FS{fstest.MapFS{
+		"000001_create_t.up.sql":   file("CREATE TABLE t (c INT);"),
+		"000001_create_t.down.sql": file("DROP TABLE t;"),
+		"000002_add_d.up.sql":      file("ALTER TABLE t ADD d INT;"),
+		"000002_add_d.down.sql":    file("ALTER TABLE t DROP d;"),
+	}}, zap.NewNop())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, runner.CheckUpToDate(ctx), migrate.ErrPending, "no version table yet")
+	require.NoError(t, db.Exec("CREATE TABLE schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)").Error)
+	assert.ErrorIs(t, runner.CheckUpToDate(ctx), migrate.ErrPending)
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (2, true)").Error)
+	assert.ErrorIs(t, runner.CheckUpToDate(ctx), migrate.ErrDirty)
+	require.NoError(t, db.Exec("UPDATE schema_migrations SET dirty = false").Error)
+	assert.NoError(t, runner.CheckUpToDate(ctx))
+	require.NoError(t, db.Exec("UPDATE schema_migrations SET version = 3").Error)
+	assert.NoError(t, runner.CheckUpToDate(ctx), "migrated by a newer build")
+
+	ping := health.PingDB(sqlDB)
+	assert.NoError(t, ping(ctx))
+	require.NoError(t, sqlDB.Close())
+	assert.Error(t, ping(ctx))
+}
@@ -0,0 +1,226 @@
+// /test/idempotency_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/requestctx"
+	"youGo/internal/repository/memory"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+)
+
+var idempotencyStoreBackends = map[string]func(t *testing.T) domain.IdempotencyStore{
+	"memory": func(t *testing.T) domain.IdempotencyStore {
+		return memory.NewIdempotencyStore()
+	},
+	"sqlite": func(t *testing.T) domain.IdempotencyStore {
+		db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = sqlDB.Close() })
+		require.NoError(t, sqlite.Migrate(context.Background(), db))
+		return repoImpl.NewIdempotencyStore(db)
+	},
+}
+
+func TestIdempotencyStoreContract(t *testing.T) {
+	for name, newStore := range idempotencyStoreBackends {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			ctx := context.Background()
+			now := time.Now()
+
+			record, err := store.Acquire(ctx, "k1", "fp1", now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Nil(t, record, "the first request takes the lock")
+
+			record, err = store.Acquire(ctx, "k1", "fp1", now.Add(time.Minute))
+			require.NoError(t, err)
+			require.NotNil(t, record, "a retry finds the lock")
+			assert.False(t, record.Completed())
+			assert.Equal(t, "fp1", record.Fingerprint)
+
+			assert.ErrorIs(t, store.Complete(ctx, &domain.IdempotencyRecord{Key: "k1", Fingerprint: "other", Status: 201, ExpiresAt: now.Add(time.Hour)}),
+				domain.ErrNotFound, "only the request holding the lock completes it")
+			require.NoError(t, store.Complete(ctx, &domain.IdempotencyRecord{
+				Key: "k1", Fingerprint: "fp1", Status: http.StatusCreated,
+				Header: map[string][]string{"Content-Type": {"application/json"}}, Body: []byte(`{"id":1}`),
+				ExpiresAt: now.Add(time.Hour),
+			}))
+			require.NoError(t, store.Release(ctx, "k1"), "completed records are kept")
+			record, err = store.Acquire(ctx, "k1", "fp1", now.Add(time.Minute))
+			require.NoError(t, err)
+			require.NotNil(t, record)
+			assert.Equal(t, http.StatusCreated, record.Status)
+			assert.Equal(t, []string{"application/json"}, record.Header["Content-Type"])
+			assert.Equal(t, `{"id":1}`, string(record.Body))
+
+			_, err = store.Acquire(ctx, "k2", "fp2", now.Add(time.Minute))
+			require.NoError(t, err)
+			require.NoError(t, store.Release(ctx, "k2"))
+			record, err = store.Acquire(ctx, "k2", "fp2", now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Nil(t, record, "a released key can be locked again")
+
+			_, err = store.Acquire(ctx, "k3", "fp3", now.Add(-time.Second))
+			require.NoError(t, err)
+			record, err = store.Acquire(ctx, "k3", "fp4", now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Nil(t, record, "an expired lock is replaced")
+
+			deleted, err := store.DeleteExpired(ctx, now.Add(2*time.Hour))
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), deleted)
+		})
+	}
+}
+
+// idempotentServer serves POST /things behind middleware.Idempotency with handle, counting its calls.
+func idempotentServer(t *testing.T, handle echo.HandlerFunc) (*echo.Echo, *atomic.Int32) {
+	t.Helper()
+	calls := new(atomic.Int32)
+	e := echo.New()
+	e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), nil)
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error { // Stands in for JWTAuth
+			if actor, err := uuid.Parse(c.Request().Header.Get("X-Actor")); err == nil {
+				c.SetRequest(c.Request().WithContext(requestctx.WithActor(c.Request().Context(), actor)))
+			}
+			return next(c)
+		}
+	})
+	idempotent := middleware.Idempotency(memory.NewIdempotencyStore(), middleware.IdempotencyOptions{}, zap.NewNop())
+	e.POST("/things", func(c echo.Context) error {
+		calls.Add(1)
+		return handle(c)
+	}, idempotent)
+	return e, calls
+}
+
+func postIdempotent(e *echo.Echo, key, body string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/things", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	created := func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderLocation, "/things/"+uuid.NewString())
+		return c.String(http.StatusCreated, "created "+uuid.NewString())
+	}
+
+	t.Run("replays the response", func(t *testing.T) {
+		e, calls := idempotentServer(t, created)
+		first := postIdempotent(e, "key-1", `{"name":"a"}`)
+		require.Equal(t, http.StatusCreated, first.Code)
+		retry := postIdempotent(e, "key-1", `{"name":"a"}`)
+		assert.Equal(t, int32(1), calls.Load(), "the handler runs once")
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, first.Header().Get(echo.HeaderLocation), retry.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, "true", retry.Header().Get(middleware.HeaderIdempotentReplayed))
+		assert.Empty(t, first.Header().Get(middleware.HeaderIdempotentReplayed))
+	})
+
+	t.Run("other payload", func(t *testing.T) {
+		e, calls := idempotentServer(t, created)
+		postIdempotent(e, "key-1", `{"name":"a"}`)
+		rec := postIdempotent(e, "key-1", `{"name":"b"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, problem.CodeIdempotencyKeyReused, decodeProblem(t, rec).Code)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("keys are scoped to the caller", func(t *testing.T) {
+		e, calls := idempotentServer(t, created)
+		postIdempotent(e, "key-1", `{}`, "X-Actor", uuid.NewString())
+		rec := postIdempotent(e, "key-1", `{}`, "X-Actor", uuid.NewString())
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get(middleware.HeaderIdempotentReplayed))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("client errors are replayed, server errors are not", func(t *testing.T) {
+		failures := []error{domain.ErrDuplicateEntry, errors.New("connection reset")}
+		e, calls := idempotentServer(t, func(echo.Context) error {
+			return failures[0]
+		})
+		first := postIdempotent(e, "key-1", `{}`)
+		retry := postIdempotent(e, "key-1", `{}`)
+		assert.Equal(t, http.StatusConflict, first.Code)
+		assert.Equal(t, http.StatusConflict, retry.Code)
+		assert.Equal(t, problem.ContentType, retry.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "true", retry.Header().Get(middleware.HeaderIdempotentReplayed))
+		assert.Equal(t, int32(1), calls.Load())
+
+		failures = failures[1:]
+		assert.Equal(t, http.StatusInternalServerError, postIdempotent(e, "key-2", `{}`).Code)
+		assert.Equal(t, http.StatusInternalServerError, postIdempotent(e, "key-2", `{}`).Code)
+		assert.Equal(t, int32(3), calls.Load(), "the key is released for a retry")
+	})
+
+	t.Run("requests in flight", func(t *testing.T) {
+		entered, release := make(chan struct{}), make(chan struct{})
+		e, calls := idempotentServer(t, func(c echo.Context) error {
+			close(entered)
+			<-release
+			return c.NoContent(http.StatusCreated)
+		})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			postIdempotent(e, "key-1", `{}`)
+		}()
+		<-entered
+		rec := postIdempotent(e, "key-1", `{}`)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, problem.CodeIdempotencyInProgress, decodeProblem(t, rec).Code)
+		assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, http.StatusCreated, postIdempotent(e, "key-1", `{}`).Code)
+	})
+
+	t.Run("without a key", func(t *testing.T) {
+		e, calls := idempotentServer(t, created)
+		postIdempotent(e, "", `{}`)
+		postIdempotent(e, "", `{}`)
+		assert.Equal(t, int32(2), calls.Load())
+
+		rec := postIdempotent(e, strings.Repeat("k", middleware.MaxIdempotencyKeyLength+1), `{}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, map[string]string{"max": "255"}, decodeProblem(t, rec).Params)
+	})
+}
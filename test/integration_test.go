@@ -6,18 +6,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/labstack/echo/v4"
-	"youGo/internal/api/handler"
-	"youGo/internal/api/middleware"
 	"youGo/internal/api/request"                  // Import request DTOs
 	"youGo/internal/api/response"                 // Import response DTOs
-	"youGo/internal/api/router"                   // Import router setup
-	"youGo/internal/auth"                         // Import auth service for DI
+	"youGo/internal/app"                          // Import module assembly for DI
 	"youGo/internal/config"                       // Import config loader
 	"youGo/internal/domain"                       // Import domain types
+	"youGo/internal/modules"                      // Import feature modules
 	"youGo/internal/platform/database"            // Import DB setup
 	"youGo/internal/platform/logger"              // Import logger setup
 	repoImpl "youGo/internal/repository/postgres" // Import repo implementation
-	"youGo/internal/service"                      // Import service layer
 	// "github.com/joho/godotenv" // If using .env files for test config
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,7 +56,7 @@ func setupIntegrationTests(t *testing.T) {
 	require.NoError(t, err, "Failed to initialize logger")
 
 	// --- Initialize Test Database ---
-	dbInstance, err := database.NewGORMConnection(testConfig.Database)
+	dbInstance, err := database.NewGORMConnection(testConfig.Database, appLogger)
 	require.NoError(t, err, "Failed to connect to test database")
 	testDB = dbInstance
 
@@ -69,33 +66,20 @@ func setupIntegrationTests(t *testing.T) {
 	require.NoError(t, err, "Failed to clean user table")
 	testUserIDs = []string{} // Reset cleanup tracker
 
-	// --- Initialize Dependencies (similar to main.go but with test DB/config) ---
-	userRepo := repoImpl.NewUserRepository(testDB)
-
-	// Parse durations for auth service
-	accessDuration, err := time.ParseDuration(cfg.Auth.AccessTokenDuration)
-	require.NoError(t, err, "Invalid access token duration")
-	refreshDuration, err := time.ParseDuration(cfg.Auth.RefreshTokenDuration)
-	require.NoError(t, err, "Invalid refresh token duration")
-
-	authSvc := auth.NewAuthService(userRepo, []byte(cfg.Auth.JWTSecret), accessDuration, refreshDuration)
-	userSvc := service.NewUserService(userRepo, appLogger)
-
-	authHandler := handler.NewAuthHandler(authSvc, userSvc, appLogger)
-	userHandler := handler.NewUserHandler(userSvc) // Pass logger
+	// --- Initialize Dependencies (the same modules as the server, with test DB/config) ---
+	c := app.NewContainer()
+	app.Supply(c, testConfig)
+	app.Supply(c, appLogger)
+	app.Supply(c, testDB)
+	app.Supply(c, repoImpl.NewTxManager(testDB, 0))
+	application := app.New(c, appLogger, modules.Default()...)
 
 	// --- Setup Router & Test Server ---
 	e := echo.New()
 	// Need to configure validator for request validation to work
 	// e.Validator = ... // Setup validator instance here (e.g., go-playground/validator)
 
-	deps := router.Dependencies{
-		Logger:         appLogger,
-		AuthMiddleware: middleware.JWTAuth(authSvc, appLogger), // Assuming middleware package exists
-		AuthHandler:    authHandler,
-		UserHandler:    userHandler,
-	}
-	router.SetupRoutes(e, deps)
+	require.NoError(t, application.SetupRoutes(e), "Failed to set up routes")
 
 	testServer = httptest.NewServer(e)
 }
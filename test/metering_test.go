@@ -0,0 +1,313 @@
+// /test/metering_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/repository/memory"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+	"youGo/internal/service"
+)
+
+func newUsageRepository(t *testing.T) domain.UsageRepository {
+	t.Helper()
+	db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, sqlite.Migrate(context.Background(), db))
+	return repoImpl.NewUsageRepository(db)
+}
+
+// failingUsageRepository fails every call, like an unreachable database.
+type failingUsageRepository struct{}
+
+func (failingUsageRepository) Add(context.Context, []domain.UsageCounter) error {
+	return errors.New("connection refused")
+}
+func (failingUsageRepository) Set(context.Context, domain.UsageCounter) error {
+	return errors.New("connection refused")
+}
+func (failingUsageRepository) List(context.Context, string, time.Time) ([]domain.UsageCounter, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestUsageRepository(t *testing.T) {
+	repo := newUsageRepository(t)
+	ctx := context.Background()
+	period := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Add(ctx, []domain.UsageCounter{
+		{Subject: "user:a", Meter: "api_calls:standard", PeriodStart: period, Value: 2},
+		{Subject: "user:b", Meter: "api_calls:standard", PeriodStart: period, Value: 1},
+	}))
+	require.NoError(t, repo.Add(ctx, []domain.UsageCounter{
+		{Subject: "user:a", Meter: "api_calls:standard", PeriodStart: period, Value: 3},
+		{Subject: "user:a", Meter: "api_calls:search", PeriodStart: period, Value: 1},
+		{Subject: "user:a", Meter: "api_calls:standard", PeriodStart: period.AddDate(0, 1, 0), Value: 7},
+	}))
+	require.NoError(t, repo.Set(ctx, domain.UsageCounter{Subject: "user:a", Meter: domain.MeterSeats, PeriodStart: domain.LevelPeriod, Value: 4}))
+	require.NoError(t, repo.Set(ctx, domain.UsageCounter{Subject: "user:a", Meter: domain.MeterSeats, PeriodStart: domain.LevelPeriod, Value: 3}))
+
+	counters, err := repo.List(ctx, "user:a", period)
+	require.NoError(t, err)
+	require.Len(t, counters, 2)
+	assert.Equal(t, "api_calls:search", counters[0].Meter)
+	assert.Equal(t, int64(1), counters[0].Value)
+	assert.Equal(t, int64(5), counters[1].Value, "additions are summed")
+
+	levels, err := repo.List(ctx, "user:a", domain.LevelPeriod)
+	require.NoError(t, err)
+	require.Len(t, levels, 1)
+	assert.Equal(t, int64(3), levels[0].Value, "levels are replaced")
+}
+
+func newMeteringService(repo domain.UsageRepository) service.MeteringService {
+	return service.NewMeteringService(repo, service.MeteringOptions{
+		FlushInterval: time.Hour, // Flushed by the tests
+		DefaultPlan:   "free",
+		Plans: map[string]service.Plan{
+			"free": {Name: "free", Quotas: map[string]int64{"api_calls:standard": 3, domain.MeterSeats: 2}},
+			"pro":  {Name: "pro", Quotas: map[string]int64{"api_calls:standard": 100}},
+		},
+		Subjects: map[string]string{"org:acme": "pro"},
+	}, zap.NewNop())
+}
+
+func TestMeteringQuotas(t *testing.T) {
+	repo := newUsageRepository(t)
+	svc := newMeteringService(repo)
+	ctx := context.Background()
+	user := domain.UserSubject(uuid.New())
+
+	require.NoError(t, svc.CheckQuota(ctx, user, "api_calls:standard", 1))
+	svc.Record(ctx, "api_calls:standard", 3, user, "org:acme")
+	err := svc.CheckQuota(ctx, user, "api_calls:standard", 1)
+	var quota *domain.QuotaExceededError
+	require.ErrorAs(t, err, &quota, "recorded usage counts before it is flushed")
+	assert.Equal(t, "free", quota.Plan)
+	assert.False(t, quota.PaymentRequired())
+	assert.True(t, quota.Reset.After(time.Now()))
+	assert.NoError(t, svc.CheckQuota(ctx, "org:acme", "api_calls:standard", 1), "subjects have the quotas of their plan")
+	assert.NoError(t, svc.CheckQuota(ctx, user, "api_calls:search", 1000), "meters without a quota are unlimited")
+
+	require.NoError(t, svc.Flush(ctx))
+	restarted := newMeteringService(repo)
+	assert.ErrorAs(t, restarted.CheckQuota(ctx, user, "api_calls:standard", 1), &quota, "flushed usage is stored")
+
+	require.NoError(t, svc.SetLevel(ctx, "org:acme", domain.MeterSeats, 2))
+	require.NoError(t, svc.CheckQuota(ctx, user, domain.MeterSeats, 1), "pro has no seat quota")
+	require.NoError(t, svc.SetLevel(ctx, user, domain.MeterSeats, 2))
+	err = svc.CheckQuota(ctx, user, domain.MeterSeats, 1)
+	require.ErrorAs(t, err, &quota)
+	assert.True(t, quota.PaymentRequired(), "levels need a bigger plan")
+	p := problem.FromError(err)
+	assert.Equal(t, http.StatusPaymentRequired, p.Status)
+	assert.Equal(t, problem.TypeQuotaExceeded, p.Type)
+	assert.Equal(t, problem.CodeQuotaUpgradeRequired, p.Code)
+}
+
+func TestMeteredSeats(t *testing.T) {
+	metering := newMeteringService(newUsageRepository(t))
+	repo := memory.NewUserRepository()
+	users := service.MeterSeats(
+		service.NewUserService(repo, &recordingOutbox{}, &recordingAuditor{}, passthroughTxManager{}, zap.NewNop()),
+		repo, metering, "org:default", zap.NewNop())
+	ctx := context.Background()
+	create := func(email string) (*response.UserResponse, error) {
+		return users.Create(ctx, &request.CreateUserRequest{Name: "Ann", Email: email, Password: "password123"})
+	}
+
+	ann, err := create("ann@example.com")
+	require.NoError(t, err)
+	_, err = users.CreateWithRole(ctx, &request.CreateUserRequest{Name: "Bob", Email: "bob@example.com", Password: "password123"}, domain.RoleAdmin)
+	require.NoError(t, err)
+	report, err := metering.Report(ctx, "org:default")
+	require.NoError(t, err)
+	require.Len(t, report.Meters, 2)
+	assert.Equal(t, domain.MeterSeats, report.Meters[1].Meter)
+	assert.Equal(t, int64(2), report.Meters[1].Used, "the users are the seats")
+
+	_, err = create("cat@example.com")
+	var quota *domain.QuotaExceededError
+	require.ErrorAs(t, err, &quota, "the free plan has 2 seats")
+	assert.Equal(t, "org:default", quota.Subject)
+	_, err = repo.FindByEmail(ctx, "cat@example.com")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, users.Delete(ctx, uuid.MustParse(ann.ID)))
+	_, err = create("cat@example.com")
+	assert.NoError(t, err, "deleting a user frees its seat")
+}
+
+func TestMeteringReport(t *testing.T) {
+	repo := newUsageRepository(t)
+	svc := newMeteringService(repo)
+	ctx := context.Background()
+	user := domain.UserSubject(uuid.New())
+
+	svc.Record(ctx, "api_calls:standard", 2, user)
+	require.NoError(t, svc.Flush(ctx))
+	svc.Record(ctx, "api_calls:standard", 1, user)
+	svc.Record(ctx, "api_calls:search", 5, user)
+
+	report, err := svc.Report(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, "free", report.Plan)
+	assert.Equal(t, report.PeriodStart.AddDate(0, 1, 0), report.PeriodEnd)
+	require.Len(t, report.Meters, 3)
+	assert.Equal(t, "api_calls:search", report.Meters[0].Meter)
+	assert.Nil(t, report.Meters[0].Limit)
+	assert.Equal(t, int64(3), report.Meters[1].Used, "stored and pending usage")
+	assert.Equal(t, int64(3), *report.Meters[1].Limit)
+	assert.Equal(t, int64(0), *report.Meters[1].Remaining)
+	assert.Equal(t, domain.MeterSeats, report.Meters[2].Meter, "quotas not used yet are listed")
+
+	_, err = svc.Report(ctx, "team:1")
+	var argErr *domain.InvalidArgumentError
+	assert.ErrorAs(t, err, &argErr)
+}
+
+// meteredServer serves /api/things and /api/things/search behind middleware.Metering, billing
+// requests to the user of the X-User header.
+func meteredServer(svc service.MeteringService) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), nil)
+	classes := []config.RouteClass{{Name: "standard", Prefix: "/api"}, {Name: "search", Prefix: "/api/things/search"}}
+	subjects := func(r *http.Request) []string {
+		if userID, err := uuid.Parse(r.Header.Get("X-User")); err == nil {
+			return []string{domain.UserSubject(userID)}
+		}
+		return nil
+	}
+	e.Use(middleware.Metering(svc, classes, subjects, zap.NewNop()))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	e.GET("/api/things", ok)
+	e.GET("/api/things/search", ok)
+	e.GET("/health", ok)
+	return e
+}
+
+func getMetered(e *echo.Echo, path string, userID uuid.UUID) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if userID != uuid.Nil {
+		req.Header.Set("X-User", userID.String())
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMeteringMiddleware(t *testing.T) {
+	t.Run("quota", func(t *testing.T) {
+		svc := newMeteringService(newUsageRepository(t))
+		e := meteredServer(svc)
+		userID := uuid.New()
+		for range 3 {
+			require.Equal(t, http.StatusNoContent, getMetered(e, "/api/things", userID).Code)
+		}
+		rec := getMetered(e, "/api/things", userID)
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		p := decodeProblem(t, rec)
+		assert.Equal(t, problem.TypeQuotaExceeded, p.Type)
+		assert.Equal(t, problem.CodeQuotaExceeded, p.Code)
+		assert.Equal(t, "api_calls:standard", p.Params["meter"])
+		assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+
+		assert.Equal(t, http.StatusNoContent, getMetered(e, "/api/things/search", userID).Code, "route classes have their own quotas")
+		assert.Equal(t, http.StatusNoContent, getMetered(e, "/api/things", uuid.New()).Code)
+
+		report, err := svc.Report(context.Background(), domain.UserSubject(userID))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), report.Meters[1].Used, "rejected requests aren't billed")
+	})
+
+	t.Run("unmetered requests", func(t *testing.T) {
+		e := meteredServer(newMeteringService(newUsageRepository(t)))
+		userID := uuid.New()
+		for range 5 {
+			assert.Equal(t, http.StatusNoContent, getMetered(e, "/api/things", uuid.Nil).Code)
+			assert.Equal(t, http.StatusNoContent, getMetered(e, "/health", userID).Code)
+		}
+	})
+
+	t.Run("fails open", func(t *testing.T) {
+		e := meteredServer(newMeteringService(failingUsageRepository{}))
+		for range 5 {
+			assert.Equal(t, http.StatusNoContent, getMetered(e, "/api/things", uuid.New()).Code)
+		}
+	})
+
+	t.Run("no retry-after when waiting doesn't help", func(t *testing.T) {
+		upgrade := &domain.QuotaExceededError{Subject: "user:a", Meter: domain.MeterSeats, Plan: "free", Limit: 2}
+		rec := getMetered(meteredServer(exceededMeteringService{err: upgrade}), "/api/things", uuid.New())
+		assert.Equal(t, http.StatusPaymentRequired, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+	})
+}
+
+// exceededMeteringService rejects every request with err.
+type exceededMeteringService struct {
+	service.MeteringService
+	err error
+}
+
+func (s exceededMeteringService) Consume(context.Context, string, int64, ...string) error {
+	return s.err
+}
+
+func TestMeteringConsumeIsAtomic(t *testing.T) {
+	svc := newMeteringService(newUsageRepository(t))
+	ctx := context.Background()
+	user := domain.UserSubject(uuid.New())
+
+	var wg sync.WaitGroup
+	var allowed atomic.Int32
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if svc.Consume(ctx, "api_calls:standard", 1, user, "org:acme") == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(3), allowed.Load(), "exactly the quota of the free plan")
+
+	report, err := svc.Report(ctx, "org:acme")
+	require.NoError(t, err)
+	require.Len(t, report.Meters, 1)
+	assert.Equal(t, int64(3), report.Meters[0].Used, "a request over the quota of one subject isn't billed to the others")
+
+	var quota *domain.QuotaExceededError
+	require.ErrorAs(t, svc.Consume(ctx, "api_calls:standard", 1, "org:acme", user), &quota)
+	assert.Equal(t, user, quota.Subject)
+
+	err = newMeteringService(failingUsageRepository{}).Consume(ctx, "api_calls:standard", 1, user)
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &quota), "usage that can't be loaded isn't checked")
+}
@@ -0,0 +1,119 @@
+// /test/metrics_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/api/request"
+	"youGo/internal/auth"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/metrics"
+)
+
+// stubAuthService accepts the password "right" and the token "valid".
+type stubAuthService struct{ auth.Service }
+
+func (stubAuthService) Login(_ context.Context, req *request.LoginRequest) (string, string, error) {
+	if req.Password != "right" {
+		return "", "", auth.ErrInvalidCredentials
+	}
+	return "access", "refresh", nil
+}
+
+func (stubAuthService) ValidateToken(token string) (uuid.UUID, error) {
+	if token != "valid" {
+		return uuid.Nil, errors.New("token is expired")
+	}
+	return uuid.New(), nil
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := metrics.New()
+	e := echo.New()
+	e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), nil)
+	e.Use(middleware.Metrics(m))
+	e.GET("/api/things/:id", func(c echo.Context) error {
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.HTTPInFlight), "the request is in flight")
+		if c.Param("id") == "missing" {
+			return domain.ErrNotFound
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/api/things/1", "/api/things/2", "/api/things/missing", "/wp-login.php"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("/api/things/:id", "GET", "204")), "labelled by route template")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("/api/things/:id", "GET", "404")), "status of the problem")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("unmatched", "GET", "404")))
+	assert.Equal(t, 3, testutil.CollectAndCount(m.HTTPDuration))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.HTTPInFlight))
+}
+
+func TestAuthObserve(t *testing.T) {
+	m := metrics.New()
+	svc := auth.Observe(stubAuthService{}, m)
+	ctx := context.Background()
+
+	_, _, err := svc.Login(ctx, &request.LoginRequest{Email: "a@example.com", Password: "right"})
+	require.NoError(t, err)
+	_, _, err = svc.Login(ctx, &request.LoginRequest{Email: "a@example.com", Password: "wrong"})
+	require.ErrorIs(t, err, auth.ErrInvalidCredentials, "errors are returned unchanged")
+	_, _, _ = svc.Login(ctx, &request.LoginRequest{Email: "a@example.com", Password: "wrong"})
+	_, err = svc.ValidateToken("valid")
+	require.NoError(t, err)
+	_, err = svc.ValidateToken("forged")
+	require.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.Logins.WithLabelValues(metrics.LoginSuccess)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.Logins.WithLabelValues(metrics.LoginFailure)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.TokenValidationFailures))
+}
+
+func TestMetricsHandler(t *testing.T) {
+	db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	m := metrics.New()
+	require.NoError(t, m.RegisterDB(sqlDB, "primary"))
+	m.LoginAttempted(nil)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	for _, metric := range []string{
+		`go_sql_open_connections{db_name="primary"}`,
+		`auth_logins_total{result="success"} 1`,
+		`auth_logins_total{result="failure"} 0`,
+		"auth_token_validation_failures_total 0",
+		"http_requests_in_flight 0",
+		"go_goroutines",
+		`build_info{commit="`,
+		`version="dev"} 1`,
+	} {
+		assert.Contains(t, string(body), metric)
+	}
+}
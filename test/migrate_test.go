@@ -0,0 +1,76 @@
+// /test/migrate_test.go
+package test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/app"
+	"youGo/internal/modules"
+	"youGo/internal/platform/migrate"
+)
+
+func TestEmbeddedMigrationsAreComplete(t *testing.T) {
+	application := app.New(app.NewContainer(), zap.NewNop(), modules.Default()...)
+	loaded, err := migrate.Load(application.Migrations()...)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for i, m := range loaded {
+		assert.Equal(t, uint64(i+1), m.Version, "versions should be contiguous, %s breaks the sequence", m.Name)
+		assert.NotEmpty(t, m.Up, "version %d has an empty up migration", m.Version)
+		assert.NotEmpty(t, m.Down, "version %d has an empty down migration", m.Version)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+
+	loaded, err := migrate.Load(fstest.MapFS{
+		"000002_add_index.up.sql":    file("CREATE INDEX i ON t (c);"),
+		"000002_add_index.down.sql":  file("DROP INDEX i;"),
+		"000001_create_t.up.sql":     file("CREATE TABLE t (c INT);"),
+		"000001_create_t.down.sql":   file("DROP TABLE t;"),
+		"migrations.go":              file("package migrations"),
+		"README.md":                  file("not a migration"),
+		"000003_not_sql.up.txt":      file("ignored"),
+		"000003_not_sql.down.sql.gz": file("ignored"),
+	})
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, uint64(1), loaded[0].Version)
+	assert.Equal(t, "create_t", loaded[0].Name)
+	assert.Equal(t, "DROP TABLE t;", loaded[0].Down)
+	assert.Equal(t, uint64(2), loaded[1].Version)
+
+	_, err = migrate.Load(fstest.MapFS{"000001_create_t.up.sql": file("CREATE TABLE t (c INT);")})
+	assert.ErrorContains(t, err, "needs both an up and a down file")
+
+	_, err = migrate.Load(fstest.MapFS{
+		"000001_create_t.up.sql":   file("CREATE TABLE t (c INT);"),
+		"000001_other.down.sql":    file("DROP TABLE t;"),
+		"000001_create_t.down.sql": file("DROP TABLE t;"),
+	})
+	assert.ErrorContains(t, err, "has two names")
+
+	_, err = migrate.Load(fstest.MapFS{"000000_zero.up.sql": file("SELECT 1;"), "000000_zero.down.sql": file("SELECT 1;")})
+	assert.ErrorContains(t, err, "invalid version")
+
+	// Modules share one sequence of versions
+	loaded, err = migrate.Load(
+		fstest.MapFS{"000001_create_t.up.sql": file("CREATE TABLE t (c INT);"), "000001_create_t.down.sql": file("DROP TABLE t;")},
+		fstest.MapFS{"000002_add_index.up.sql": file("CREATE INDEX i ON t (c);"), "000002_add_index.down.sql": file("DROP INDEX i;")},
+	)
+	require.NoError(t, err)
+	assert.Len(t, loaded, 2)
+
+	_, err = migrate.Load(
+		fstest.MapFS{"000001_create_t.up.sql": file("CREATE TABLE t (c INT);"), "000001_create_t.down.sql": file("DROP TABLE t;")},
+		fstest.MapFS{"000001_create_t.up.sql": file("CREATE TABLE t (c INT);"), "000001_create_t.down.sql": file("DROP TABLE t;")},
+	)
+	assert.ErrorContains(t, err, "is defined twice")
+}
@@ -0,0 +1,244 @@
+// /test/outbox_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+	"youGo/internal/platform/eventbus"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/service"
+)
+
+// scriptedPublisher fails the events listed in failing, and records the ones it published.
+type scriptedPublisher struct {
+	mu        sync.Mutex
+	failing   map[uuid.UUID]bool
+	published []uuid.UUID
+	block     chan struct{} // When set, Publish waits for it to be closed
+	started   chan struct{} // When set, closed by the first Publish
+}
+
+func (p *scriptedPublisher) Publish(ctx context.Context, event *domain.DomainEvent) error {
+	if p.started != nil {
+		close(p.started)
+		p.started = nil
+	}
+	if p.block != nil {
+		select {
+		case <-p.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failing[event.ID] {
+		return errors.New("sink unavailable")
+	}
+	p.published = append(p.published, event.ID)
+	return nil
+}
+
+func (p *scriptedPublisher) fail(id uuid.UUID, failing bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failing[id] = failing
+}
+
+func (p *scriptedPublisher) take() []uuid.UUID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	published := p.published
+	p.published = nil
+	return published
+}
+
+// appendOutboxEvents stores one event per aggregate ID, in order.
+func appendOutboxEvents(t *testing.T, outbox domain.OutboxRepository, aggregateIDs ...string) []*domain.DomainEvent {
+	events := make([]*domain.DomainEvent, 0, len(aggregateIDs))
+	for _, aggregateID := range aggregateIDs {
+		event, err := domain.NewDomainEvent("user", aggregateID, "UserUpdated", map[string]string{"id": aggregateID})
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+	require.NoError(t, outbox.Append(context.Background(), events...))
+	return events
+}
+
+func loadOutboxEvent(t *testing.T, db *gorm.DB, id uuid.UUID) repoImpl.OutboxEventModel {
+	var model repoImpl.OutboxEventModel
+	require.NoError(t, db.Where("event_id = ?", id).First(&model).Error)
+	return model
+}
+
+// makeOutboxDue makes every unpublished event due, as if its retry delay or lease had passed.
+func makeOutboxDue(t *testing.T, db *gorm.DB) {
+	require.NoError(t, db.Exec("UPDATE outbox SET next_attempt_at = ? WHERE published_at IS NULL", time.Now().UTC().Add(-time.Second)).Error)
+}
+
+func TestOutboxRelayKeepsAggregateOrder(t *testing.T) {
+	db := openSchemaDB(t)
+	outbox := repoImpl.NewOutboxRepository(db)
+	publisher := &scriptedPublisher{failing: map[uuid.UUID]bool{}}
+	relay := service.NewOutboxRelay(outbox, publisher, time.Second, 10, 0, zap.NewNop())
+	ctx := context.Background()
+
+	events := appendOutboxEvents(t, outbox, "a", "a", "b", "a")
+	a1, a2, b1, a3 := events[0], events[1], events[2], events[3]
+	publisher.fail(a1.ID, true)
+
+	started := time.Now().UTC()
+	processed, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, []uuid.UUID{b1.ID}, publisher.take(), "the failure of a1 holds back a2 and a3, not b1")
+
+	failed := loadOutboxEvent(t, db, a1.ID)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "sink unavailable", failed.LastError)
+	assert.WithinDuration(t, started.Add(time.Second), failed.NextAttemptAt, time.Second, "first retry after 1s")
+	assert.Nil(t, failed.DeadAt)
+	for _, held := range []*domain.DomainEvent{a2, a3} {
+		model := loadOutboxEvent(t, db, held.ID)
+		assert.Nil(t, model.PublishedAt)
+		assert.Zero(t, model.Attempts, "held back events aren't attempted")
+		assert.False(t, model.NextAttemptAt.After(time.Now().UTC()), "held back events are released, not leased")
+	}
+
+	processed, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "a2 and a3 wait for the retry of a1")
+	assert.Empty(t, publisher.take())
+
+	// The delay doubles with every attempt
+	makeOutboxDue(t, db)
+	started = time.Now().UTC()
+	_, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	failed = loadOutboxEvent(t, db, a1.ID)
+	assert.Equal(t, 2, failed.Attempts)
+	assert.WithinDuration(t, started.Add(2*time.Second), failed.NextAttemptAt, time.Second, "second retry after 2s")
+
+	publisher.fail(a1.ID, false)
+	makeOutboxDue(t, db)
+	processed, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, processed)
+	assert.Equal(t, []uuid.UUID{a1.ID, a2.ID, a3.ID}, publisher.take())
+	assert.NotNil(t, loadOutboxEvent(t, db, a3.ID).PublishedAt)
+}
+
+func TestOutboxRelayBackoffIsCapped(t *testing.T) {
+	db := openSchemaDB(t)
+	outbox := repoImpl.NewOutboxRepository(db)
+	publisher := &scriptedPublisher{failing: map[uuid.UUID]bool{}}
+	relay := service.NewOutboxRelay(outbox, publisher, time.Second, 10, 0, zap.NewNop())
+
+	event := appendOutboxEvents(t, outbox, "a")[0]
+	publisher.fail(event.ID, true)
+	require.NoError(t, db.Exec("UPDATE outbox SET attempts = 30").Error)
+
+	started := time.Now().UTC()
+	_, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	failed := loadOutboxEvent(t, db, event.ID)
+	assert.Equal(t, 31, failed.Attempts)
+	assert.WithinDuration(t, started.Add(5*time.Minute), failed.NextAttemptAt, time.Second)
+	assert.Nil(t, failed.DeadAt, "without max attempts, events are retried forever")
+}
+
+func TestOutboxRelayGivesUpAfterMaxAttempts(t *testing.T) {
+	db := openSchemaDB(t)
+	outbox := repoImpl.NewOutboxRepository(db)
+	publisher := &scriptedPublisher{failing: map[uuid.UUID]bool{}}
+	relay := service.NewOutboxRelay(outbox, publisher, time.Second, 10, 2, zap.NewNop())
+	ctx := context.Background()
+
+	events := appendOutboxEvents(t, outbox, "a", "a", "b")
+	a1, a2, b1 := events[0], events[1], events[2]
+	publisher.fail(a1.ID, true)
+
+	for range 2 {
+		makeOutboxDue(t, db)
+		_, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+	}
+	dead := loadOutboxEvent(t, db, a1.ID)
+	assert.Equal(t, 2, dead.Attempts)
+	assert.NotNil(t, dead.DeadAt)
+	assert.Equal(t, []uuid.UUID{b1.ID}, publisher.take())
+
+	makeOutboxDue(t, db)
+	processed, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "a dead event is not retried, and still holds back a2")
+	assert.Equal(t, 2, loadOutboxEvent(t, db, a1.ID).Attempts)
+
+	assert.ErrorIs(t, outbox.Requeue(ctx, a2.ID), domain.ErrNotFound, "only dead events are requeued")
+	assert.ErrorIs(t, outbox.Requeue(ctx, uuid.New()), domain.ErrNotFound)
+	publisher.fail(a1.ID, false)
+	require.NoError(t, outbox.Requeue(ctx, a1.ID))
+	requeued := loadOutboxEvent(t, db, a1.ID)
+	assert.Zero(t, requeued.Attempts)
+	assert.Nil(t, requeued.DeadAt)
+	processed, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []uuid.UUID{a1.ID, a2.ID}, publisher.take())
+}
+
+func TestOutboxRelayPublishesOutsideTheClaim(t *testing.T) {
+	db := openSchemaDB(t)
+	outbox := repoImpl.NewOutboxRepository(db)
+	blocked := &scriptedPublisher{failing: map[uuid.UUID]bool{}, block: make(chan struct{}), started: make(chan struct{})}
+	other := &scriptedPublisher{failing: map[uuid.UUID]bool{}}
+	ctx := context.Background()
+
+	events := appendOutboxEvents(t, outbox, "a", "b")
+	started := blocked.started
+
+	done := make(chan int)
+	go func() {
+		processed, err := service.NewOutboxRelay(outbox, blocked, time.Second, 10, 0, zap.NewNop()).RelayOnce(ctx)
+		assert.NoError(t, err)
+		done <- processed
+	}()
+	<-started
+
+	// SQLite has a single connection: this would wait forever if the first relay held a transaction
+	processed, err := service.NewOutboxRelay(outbox, other, time.Second, 10, 0, zap.NewNop()).RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, processed, "claimed events are leased to the first relay")
+	assert.Empty(t, other.take())
+
+	close(blocked.block)
+	assert.Equal(t, 2, <-done)
+	assert.Equal(t, []uuid.UUID{events[0].ID, events[1].ID}, blocked.take())
+}
+
+func TestLocalBrokerKeepsTheLatestMessages(t *testing.T) {
+	broker := eventbus.NewLocalBroker()
+	received := 0
+	broker.Subscribe(eventbus.AllEvents, func(eventbus.Message) { received++ })
+	for i := range eventbus.LocalBrokerLogSize + 10 {
+		require.NoError(t, broker.Send(context.Background(), eventbus.Message{Subject: "users", Key: strconv.Itoa(i)}))
+	}
+
+	messages := broker.Messages()
+	assert.Equal(t, eventbus.LocalBrokerLogSize+10, received, "subscribers get every message")
+	require.Len(t, messages, eventbus.LocalBrokerLogSize)
+	assert.Equal(t, "10", messages[0].Key)
+	assert.Equal(t, strconv.Itoa(eventbus.LocalBrokerLogSize+9), messages[len(messages)-1].Key)
+}
@@ -0,0 +1,232 @@
+// /test/patch_test.go
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/patch"
+	"youGo/internal/platform/validator"
+	"youGo/internal/repository/memory"
+	"youGo/internal/service"
+)
+
+func TestPatchApply(t *testing.T) {
+	const doc = `{"name":"Ann","isActive":true,"role":"user","locale":""}`
+	userPaths := request.PatchableUserPaths[domain.RoleUser]
+	adminPaths := request.PatchableUserPaths[domain.RoleAdmin]
+
+	for _, tc := range []struct {
+		name      string
+		mediaType string
+		patch     string
+		allowed   []string
+		want      string // Patched document, if it applies
+		forbidden string // Path reported by a *patch.PathNotAllowedError
+		err       error
+	}{
+		{name: "merge patch", mediaType: patch.MediaTypeMergePatch, patch: `{"name":"Anna"}`, allowed: userPaths,
+			want: `{"name":"Anna","isActive":true,"role":"user","locale":""}`},
+		{name: "merge patch of a forbidden member", mediaType: patch.MediaTypeMergePatch, patch: `{"name":"Anna","role":"admin"}`,
+			allowed: userPaths, forbidden: "/role"},
+		{name: "merge patch of an unknown member", mediaType: patch.MediaTypeMergePatch, patch: `{"passwordHash":"x"}`,
+			allowed: adminPaths, forbidden: "/passwordHash"},
+		{name: "nested merge patch below an allowed member", mediaType: patch.MediaTypeMergePatch, patch: `{"email":{"verified":true}}`,
+			allowed: []string{"/email"}, want: `{"name":"Ann","isActive":true,"role":"user","locale":"","email":{"verified":true}}`},
+		{name: "admins may patch the role", mediaType: patch.MediaTypeMergePatch, patch: `{"role":"admin"}`, allowed: adminPaths,
+			want: `{"name":"Ann","isActive":true,"role":"admin","locale":""}`},
+		{name: "array merge patch", mediaType: patch.MediaTypeMergePatch, patch: `["name"]`, allowed: adminPaths, err: patch.ErrInvalidPatch},
+		{name: "null merge patch", mediaType: patch.MediaTypeMergePatch, patch: `null`, allowed: adminPaths, err: patch.ErrInvalidPatch},
+		{name: "string merge patch", mediaType: patch.MediaTypeMergePatch, patch: `"Anna"`, allowed: adminPaths, err: patch.ErrInvalidPatch},
+		{name: "malformed merge patch", mediaType: patch.MediaTypeMergePatch, patch: `{"name":`, allowed: adminPaths, err: patch.ErrInvalidPatch},
+		{name: "JSON patch", mediaType: patch.MediaTypeJSONPatch,
+			patch:   `[{"op":"test","path":"/name","value":"Ann"},{"op":"replace","path":"/name","value":"Anna"}]`,
+			allowed: userPaths, want: `{"name":"Anna","isActive":true,"role":"user","locale":""}`},
+		{name: "JSON patch of a forbidden path", mediaType: patch.MediaTypeJSONPatch,
+			patch: `[{"op":"replace","path":"/isActive","value":false}]`, allowed: userPaths, forbidden: "/isActive"},
+		{name: "a path sharing a prefix isn't nested", mediaType: patch.MediaTypeJSONPatch,
+			patch: `[{"op":"add","path":"/names","value":"x"}]`, allowed: userPaths, forbidden: "/names"},
+		{name: "move from a forbidden path", mediaType: patch.MediaTypeJSONPatch,
+			patch: `[{"op":"move","from":"/role","path":"/name"}]`, allowed: userPaths, forbidden: "/role"},
+		{name: "copy from a forbidden path", mediaType: patch.MediaTypeJSONPatch,
+			patch: `[{"op":"copy","from":"/role","path":"/locale"}]`, allowed: userPaths, forbidden: "/role"},
+		{name: "copy between allowed paths", mediaType: patch.MediaTypeJSONPatch,
+			patch: `[{"op":"copy","from":"/name","path":"/locale"}]`, allowed: userPaths,
+			want: `{"name":"Ann","isActive":true,"role":"user","locale":"Ann"}`},
+		{name: "forbidden operation after allowed ones", mediaType: patch.MediaTypeJSONPatch,
+			patch:   `[{"op":"replace","path":"/name","value":"Anna"},{"op":"remove","path":"/role"}]`,
+			allowed: userPaths, forbidden: "/role"},
+		{name: "failed test", mediaType: patch.MediaTypeJSONPatch,
+			patch:   `[{"op":"test","path":"/name","value":"Bob"},{"op":"replace","path":"/name","value":"Anna"}]`,
+			allowed: userPaths, err: patch.ErrTestFailed},
+		{name: "unknown operation", mediaType: patch.MediaTypeJSONPatch, patch: `[{"op":"rename","path":"/name"}]`,
+			allowed: userPaths, err: patch.ErrInvalidPatch},
+		{name: "JSON patch that isn't an array", mediaType: patch.MediaTypeJSONPatch, patch: `{"op":"remove","path":"/name"}`,
+			allowed: userPaths, err: patch.ErrInvalidPatch},
+		{name: "no allowed paths", mediaType: patch.MediaTypeMergePatch, patch: `{"name":"Anna"}`, forbidden: "/name"},
+		{name: "unsupported media type", mediaType: "application/json", patch: `{"name":"Anna"}`, allowed: userPaths,
+			err: patch.ErrUnsupportedMediaType},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			patched, err := patch.Apply(tc.mediaType, []byte(doc), []byte(tc.patch), tc.allowed)
+			switch {
+			case tc.forbidden != "":
+				var pathErr *patch.PathNotAllowedError
+				require.ErrorAs(t, err, &pathErr)
+				assert.Equal(t, tc.forbidden, pathErr.Path)
+				assert.Nil(t, patched)
+			case tc.err != nil:
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, patched)
+			default:
+				require.NoError(t, err)
+				assert.JSONEq(t, tc.want, string(patched))
+			}
+		})
+	}
+}
+
+func TestPatchMediaType(t *testing.T) {
+	for contentType, want := range map[string]string{
+		"application/merge-patch+json":               patch.MediaTypeMergePatch,
+		"application/json-patch+json; charset=utf-8": patch.MediaTypeJSONPatch,
+		"application/json":                           "",
+		"":                                           "",
+	} {
+		got, err := patch.MediaType(contentType)
+		if want == "" {
+			assert.ErrorIs(t, err, patch.ErrUnsupportedMediaType, contentType)
+			continue
+		}
+		require.NoError(t, err, contentType)
+		assert.Equal(t, want, got)
+	}
+}
+
+// countingTxManager counts the units of work it runs, without a transaction.
+type countingTxManager struct {
+	calls int
+}
+
+func (m *countingTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+// lockCountingUserRepository counts the users read for update.
+type lockCountingUserRepository struct {
+	domain.UserRepository
+	locks int
+}
+
+func (r *lockCountingUserRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	r.locks++
+	return r.UserRepository.FindByIDForUpdate(ctx, id)
+}
+
+func TestPatchUserHandler(t *testing.T) {
+	repo := &lockCountingUserRepository{UserRepository: memory.NewUserRepository()}
+	users := service.NewUserService(repo, &recordingOutbox{}, &recordingAuditor{}, passthroughTxManager{}, zap.NewNop())
+	txManager := &countingTxManager{}
+	ctx := context.Background()
+	create := func(email string) uuid.UUID {
+		created, err := users.Create(ctx, &request.CreateUserRequest{Name: "Ann", Email: email, Password: "password123"})
+		require.NoError(t, err)
+		return uuid.MustParse(created.ID)
+	}
+	ann, bob, admin := create("ann@example.com"), create("bob@example.com"), create("admin@example.com")
+	adminRole := domain.RoleAdmin
+	_, err := users.Update(ctx, admin, &request.UpdateUserRequest{Role: &adminRole})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Validator = validator.NewValidator()
+	e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), nil)
+	e.PATCH("/users/:id", handler.NewUserHandler(users, txManager).PatchUser, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(string(middleware.UserIDContextKey), uuid.MustParse(c.Request().Header.Get("X-Test-User")))
+			return next(c)
+		}
+	})
+	send := func(actor, target uuid.UUID, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/users/"+target.String(), strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		req.Header.Set("X-Test-User", actor.String())
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, tc := range []struct {
+		name        string
+		actor       uuid.UUID
+		target      uuid.UUID
+		contentType string
+		body        string
+		status      int
+		code        string
+	}{
+		{"own name", ann, ann, patch.MediaTypeMergePatch, `{"name":"Anna"}`, http.StatusOK, ""},
+		{"own role", ann, ann, patch.MediaTypeMergePatch, `{"role":"admin"}`, http.StatusForbidden, problem.CodeUserPatchPathForbidden},
+		{"own activation", ann, ann, patch.MediaTypeJSONPatch, `[{"op":"replace","path":"/isActive","value":false}]`,
+			http.StatusForbidden, problem.CodeUserPatchPathForbidden},
+		{"role moved into the name", ann, ann, patch.MediaTypeJSONPatch, `[{"op":"move","from":"/role","path":"/name"}]`,
+			http.StatusForbidden, problem.CodeUserPatchPathForbidden},
+		{"another user", ann, bob, patch.MediaTypeMergePatch, `{"name":"Bobby"}`, http.StatusForbidden, problem.CodeUserPatchNotOwner},
+		{"admin patching a role", admin, bob, patch.MediaTypeMergePatch, `{"role":"admin","isActive":false}`, http.StatusOK, ""},
+		{"activation removed", admin, bob, patch.MediaTypeMergePatch, `{"isActive":null}`, http.StatusUnprocessableEntity, problem.CodeValidationFailed},
+		{"invalid role", admin, bob, patch.MediaTypeJSONPatch, `[{"op":"replace","path":"/role","value":"root"}]`,
+			http.StatusUnprocessableEntity, problem.CodeValidationFailed},
+		{"failed test", admin, bob, patch.MediaTypeJSONPatch,
+			`[{"op":"test","path":"/name","value":"Robert"},{"op":"replace","path":"/name","value":"Rob"}]`, http.StatusConflict, problem.CodePatchTestFailed},
+		{"non-object merge patch", admin, bob, patch.MediaTypeMergePatch, `["name"]`, http.StatusBadRequest, problem.CodePatchInvalid},
+		{"malformed JSON patch", admin, bob, patch.MediaTypeJSONPatch, `[{"op":"replace"}]`, http.StatusBadRequest, problem.CodePatchInvalid},
+		{"plain JSON", admin, bob, echo.MIMEApplicationJSON, `{"name":"Bobby"}`, http.StatusUnsupportedMediaType, problem.CodePatchUnsupportedMediaType},
+		{"missing user", admin, uuid.New(), patch.MediaTypeMergePatch, `{"name":"Nobody"}`, http.StatusNotFound, problem.CodeUserNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := send(tc.actor, tc.target, tc.contentType, tc.body)
+			require.Equal(t, tc.status, rec.Code, rec.Body.String())
+			if tc.code != "" {
+				assert.Equal(t, tc.code, decodeProblem(t, rec).Code)
+			}
+		})
+	}
+
+	patched, err := users.GetByID(ctx, ann)
+	require.NoError(t, err)
+	assert.Equal(t, "Anna", patched.Name)
+	assert.Equal(t, domain.RoleUser, patched.Role, "rejected patches change nothing")
+	patched, err = users.GetByID(ctx, bob)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, patched.Role)
+	assert.False(t, patched.IsActive)
+
+	// Rejected before the transaction: another user's, and plain JSON. Every other patch locks the user in one.
+	assert.Equal(t, 11, txManager.calls)
+	assert.Equal(t, txManager.calls, repo.locks)
+
+	rec := send(admin, ann, patch.MediaTypeMergePatch, `{"locale":"en"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body response.UserResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "en", body.Locale)
+	assert.Equal(t, "Anna", body.Name)
+}
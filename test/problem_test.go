@@ -0,0 +1,360 @@
+// /test/problem_test.go
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"youGo/internal/api/problem"
+	"youGo/internal/domain"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/requestctx"
+	"youGo/internal/platform/validator"
+)
+
+// serveError returns the response to a request whose handler fails with err, and the logs.
+func serveError(t *testing.T, method string, err error) (*httptest.ResponseRecorder, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.ErrorLevel)
+	e := echo.New()
+	e.HTTPErrorHandler = problem.Handler(zap.New(core), i18n.Default(), nil)
+	e.Any("/things/:id", func(echo.Context) error { return err })
+
+	req := httptest.NewRequest(method, "/things/42", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec, logs
+}
+
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) problem.Details {
+	assert.Equal(t, problem.ContentType, rec.Header().Get(echo.HeaderContentType))
+	var p problem.Details
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	assert.Equal(t, rec.Code, p.Status)
+	return p
+}
+
+func TestProblemHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		typ    string
+		code   string
+		detail string
+		params map[string]string
+	}{
+		{"not found", fmt.Errorf("loading: %w", domain.ErrNotFound), http.StatusNotFound, problem.TypeNotFound,
+			problem.CodeNotFound, "The requested resource does not exist.", nil},
+		{"code", problem.WithCode(domain.ErrNotFound, problem.CodeUserNotFound), http.StatusNotFound, problem.TypeNotFound,
+			problem.CodeUserNotFound, "User not found.", nil},
+		{"duplicate", &domain.ConstraintError{Kind: domain.ErrDuplicateEntry, Column: "email"}, http.StatusConflict, problem.TypeDuplicateEntry,
+			problem.CodeDuplicateEntry, "A resource with the same unique value already exists.", map[string]string{"column": "email"}},
+		{"invalid reference", domain.ErrInvalidReference, http.StatusConflict, problem.TypeInvalidReference, problem.CodeInvalidReference, "", nil},
+		{"constraint", domain.ErrConstraintViolation, http.StatusUnprocessableEntity, problem.TypeConstraintViolation, problem.CodeConstraintViolation, "", nil},
+		{"permission", domain.ErrPermissionDenied, http.StatusForbidden, problem.TypePermissionDenied, problem.CodePermissionDenied, "", nil},
+		{"edit conflict", domain.ErrOptimisticLock, http.StatusConflict, problem.TypeEditConflict, problem.CodeEditConflict, "", nil},
+		{"invalid argument", &domain.InvalidArgumentError{ArgumentName: "cursor", Reason: "malformed"}, http.StatusBadRequest, problem.TypeInvalidArgument,
+			problem.CodeInvalidArgument, "Invalid cursor: malformed.", map[string]string{"argument": "cursor", "reason": "malformed"}},
+		{"translated reason", &domain.InvalidArgumentError{ArgumentName: "from", Reason: "bad time", Code: domain.ReasonTimestamp}, http.StatusBadRequest,
+			problem.TypeInvalidArgument, problem.CodeInvalidArgument, "Invalid from: must be an RFC 3339 timestamp.", nil},
+		{"http error", echo.NewHTTPError(http.StatusBadRequest, "Invalid request body"), http.StatusBadRequest, problem.TypeBlank,
+			problem.CodeBadRequest, "The request is malformed.", nil},
+		{"other status", echo.NewHTTPError(http.StatusTeapot), http.StatusTeapot, problem.TypeBlank,
+			problem.CodeHTTPError, "The request failed with status 418.", map[string]string{"status": "418"}},
+		{"bad request", problem.BadRequest(problem.CodeInvalidBody, errors.New("unexpected EOF")), http.StatusBadRequest, problem.TypeBlank,
+			problem.CodeInvalidBody, "The request body is malformed.", nil},
+		{"params", problem.Error(http.StatusForbidden, problem.CodeUserPatchPathForbidden, "path", "/role"), http.StatusForbidden, problem.TypeBlank,
+			problem.CodeUserPatchPathForbidden, "You may not change /role.", map[string]string{"path": "/role"}},
+		{"coded invalid argument", problem.WithCode(&domain.InvalidArgumentError{ArgumentName: "cursor", Reason: "malformed"}, problem.CodeInvalidArgument, "hint", "page"),
+			http.StatusBadRequest, problem.TypeInvalidArgument, problem.CodeInvalidArgument, "Invalid cursor: malformed.",
+			map[string]string{"argument": "cursor", "reason": "malformed", "hint": "page"}},
+		{"details", problem.New(http.StatusTeapot, "/problems/teapot", "teapot.short"), http.StatusTeapot, "/problems/teapot",
+			"teapot.short", "I'm a teapot", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, logs := serveError(t, http.MethodGet, tt.err)
+			require.Equal(t, tt.status, rec.Code)
+			p := decodeProblem(t, rec)
+			assert.Equal(t, tt.typ, p.Type)
+			assert.Equal(t, http.StatusText(tt.status), p.Title)
+			assert.Equal(t, tt.code, p.Code)
+			if tt.detail != "" {
+				assert.Equal(t, tt.detail, p.Detail, "message of the code, or else the title")
+			}
+			if tt.params != nil {
+				assert.Equal(t, tt.params, p.Params)
+			}
+			assert.Equal(t, "/things/42", p.Instance)
+			assert.Equal(t, "req-1", p.RequestID)
+			assert.Zero(t, logs.Len(), "client errors aren't logged")
+		})
+	}
+
+	t.Run("server errors hide their cause", func(t *testing.T) {
+		rec, logs := serveError(t, http.MethodPost, problem.WithCode(errors.New("dial tcp: connection refused"), problem.CodeUserNotFound))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		p := decodeProblem(t, rec)
+		assert.Equal(t, problem.TypeBlank, p.Type)
+		assert.Equal(t, problem.CodeInternal, p.Code, "the code of an unknown error isn't trusted")
+		assert.Equal(t, "An unexpected error occurred.", p.Detail)
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+
+		entries := logs.FilterMessage("Request failed").All()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "req-1", entries[0].ContextMap()["request_id"])
+	})
+
+	t.Run("missing route", func(t *testing.T) {
+		e := echo.New()
+		e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nowhere", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		p := decodeProblem(t, rec)
+		assert.Equal(t, problem.TypeBlank, p.Type)
+		assert.Equal(t, "Not Found", p.Title)
+	})
+
+	t.Run("returned details are left as they are", func(t *testing.T) {
+		shared := problem.New(http.StatusUnprocessableEntity, problem.TypeValidation, problem.CodeValidationFailed)
+		shared.Params = map[string]string{"form": "signup"}
+		shared.Errors = map[string][]string{"email": {"raw"}}
+		shared.Violations = []problem.Violation{{Field: "email", Code: "required", Message: "raw", Params: map[string]string{"field": "email"}}}
+
+		rec, _ := serveError(t, http.MethodGet, shared)
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.NotEqual(t, "raw", decodeProblem(t, rec).Violations[0].Message, "the problem is localized")
+		assert.Equal(t, map[string]string{"form": "signup"}, shared.Params)
+		assert.Equal(t, map[string][]string{"email": {"raw"}}, shared.Errors)
+		assert.Equal(t, []problem.Violation{{Field: "email", Code: "required", Message: "raw", Params: map[string]string{"field": "email"}}}, shared.Violations)
+		assert.Empty(t, shared.Detail)
+	})
+
+	t.Run("HEAD has no body", func(t *testing.T) {
+		rec, _ := serveError(t, http.MethodHead, domain.ErrNotFound)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+}
+
+type addressInput struct {
+	Zip string `json:"zip" validate:"len=5"`
+}
+
+type lineInput struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+type pagingInput struct {
+	Limit int `query:"limit" validate:"omitempty,max=100"`
+}
+
+type orderInput struct {
+	pagingInput
+	Email    string        `json:"email" validate:"required,email"`
+	Password string        `json:"password" validate:"required,min=8"`
+	Confirm  string        `json:"password_confirmation" validate:"eqfield=Password"`
+	Address  *addressInput `json:"address" validate:"required"`
+	Lines    []lineInput   `json:"lines" validate:"min=1,dive"`
+	Status   string        `json:"status" validate:"omitempty,oneof=draft placed"`
+}
+
+func TestValidationProblem(t *testing.T) {
+	invalid := &orderInput{
+		pagingInput: pagingInput{Limit: 500},
+		Email:       "nope",
+		Password:    "short",
+		Confirm:     "other",
+		Address:     &addressInput{Zip: "123"},
+		Lines:       []lineInput{{SKU: "A-1", Quantity: 1}, {Quantity: 0}},
+		Status:      "lost",
+	}
+	err := validator.NewValidator().Validate(invalid)
+	var validation *domain.ValidationError
+	require.ErrorAs(t, err, &validation)
+
+	codes := make(map[string]string)
+	for _, v := range validation.Violations {
+		codes[v.Path] = v.Code
+	}
+	assert.Equal(t, map[string]string{
+		"limit":                 "max",
+		"email":                 "email",
+		"password":              "min",
+		"password_confirmation": "eqfield",
+		"address.zip":           "len",
+		"lines[1].sku":          "required",
+		"lines[1].quantity":     "min",
+		"status":                "oneof",
+	}, codes, "JSON paths, embedded structs flattened")
+	assert.Equal(t, []string{"password_confirmation must match password"}, validation.Failures["password_confirmation"])
+	assert.Equal(t, []string{"status must be one of: draft, placed"}, validation.Failures["status"])
+	assert.Equal(t, []string{"lines[1].quantity must be at least 1"}, validation.Failures["lines[1].quantity"])
+
+	t.Run("problem", func(t *testing.T) {
+		rec, _ := serveError(t, http.MethodPost, err)
+		require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		p := decodeProblem(t, rec)
+		assert.Equal(t, problem.TypeValidation, p.Type)
+		assert.Equal(t, problem.CodeValidationFailed, p.Code)
+		assert.Equal(t, "The request contains 8 invalid fields.", p.Detail)
+		assert.Empty(t, p.Params)
+		assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+		assert.Equal(t, []string{"password must be at least 8 characters long"}, p.Errors["password"])
+		assert.Contains(t, p.Violations, problem.Violation{
+			Field: "password", Code: "min", Message: "password must be at least 8 characters long",
+			Params: map[string]string{"limit": "8", "kind": "string"},
+		})
+	})
+
+	t.Run("localized", func(t *testing.T) {
+		core, _ := observer.New(zapcore.ErrorLevel)
+		e := echo.New()
+		e.HTTPErrorHandler = problem.Handler(zap.New(core), i18n.Default(), nil)
+		e.POST("/orders", func(echo.Context) error { return err })
+		req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+		req.Header.Set("Accept-Language", "fr-FR, id-ID;q=0.9, en;q=0.5")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		p := decodeProblem(t, rec)
+		assert.Equal(t, "id", rec.Header().Get("Content-Language"))
+		assert.Equal(t, "Permintaan berisi 8 kolom yang tidak valid.", p.Detail)
+		assert.Equal(t, []string{"password minimal 8 karakter"}, p.Errors["password"])
+		assert.Equal(t, []string{"address.zip harus tepat 5 karakter"}, p.Errors["address.zip"])
+		assert.Equal(t, []string{"password must be at least 8 characters long"}, validation.Failures["password"], "the error is left untouched")
+	})
+
+	assert.NoError(t, validator.NewValidator().Validate(&orderInput{
+		Email: "jane@example.com", Password: "long enough", Confirm: "long enough",
+		Address: &addressInput{Zip: "80361"}, Lines: []lineInput{{SKU: "A-1", Quantity: 2}},
+	}))
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	messages := i18n.Default()
+	assert.Equal(t, []string{"en", "id"}, messages.Locales())
+	for header, want := range map[string]string{
+		"":                          "en",
+		"id":                        "id",
+		"ID-id":                     "id",
+		"fr, de;q=0.8":              "en",
+		"en;q=0.4, id;q=0.8":        "id",
+		"id;q=0, en":                "en",
+		"*":                         "en",
+		"fr-CH, fr;q=0.9, *;q=0.5":  "en",
+		"es;q=invalid, id-ID;q=0.1": "id",
+	} {
+		assert.Equal(t, want, messages.Negotiate(header), "Accept-Language: %q", header)
+	}
+
+	custom := i18n.NewCatalogue("en", map[string]map[string]i18n.Message{
+		"en": {"greeting": {Text: "Hello {name}"}, "farewell": {Text: "Bye"}},
+		"id": {"greeting": {Text: "Halo {name}"}},
+	})
+	msg, ok := custom.Message("id", "greeting", map[string]string{"name": "Jane"})
+	assert.True(t, ok)
+	assert.Equal(t, "Halo Jane", msg)
+	msg, _ = custom.Message("id", "farewell", nil)
+	assert.Equal(t, "Bye", msg, "missing messages fall back")
+	_, ok = custom.Message("id", "unknown", nil)
+	assert.False(t, ok)
+}
+
+func TestCatalogueIsComplete(t *testing.T) {
+	assert.NoError(t, i18n.Default().Check(append(problem.Codes(), validator.MessageKeys()...)...),
+		"every locale translates every code, with the plural forms of its language")
+
+	incomplete := i18n.NewCatalogue("en", map[string]map[string]i18n.Message{
+		"en": {"items": {Plural: map[string]string{"one": "{count} item", "other": "{count} items"}}, "hello": {Text: "Hello"}},
+		"fr": {"items": {Text: "{count} éléments"}},
+		"id": {"items": {Text: "{count} item"}, "hello": {Text: "Halo"}},
+	})
+	err := incomplete.Check("bye")
+	require.Error(t, err)
+	for _, missing := range []string{`en: missing "bye"`, `fr: missing "hello"`, `fr: "items" misses the plural form "one"`, `id: missing "bye"`} {
+		assert.ErrorContains(t, err, missing)
+	}
+	assert.NotContains(t, err.Error(), `id: "items"`, "a language without plural forms may use a plain message")
+}
+
+func TestPluralMessages(t *testing.T) {
+	var messages map[string]i18n.Message
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"plain": "Hello",
+		"items": {"one": "{count} item", "other": "{count} items"}
+	}`), &messages))
+	var incomplete map[string]i18n.Message
+	assert.Error(t, json.Unmarshal([]byte(`{"items": {"one": "{count} item"}}`), &incomplete), `an "other" form is required`)
+	custom := i18n.NewCatalogue("en", map[string]map[string]i18n.Message{
+		"en": messages,
+		"fr": {"items": {Plural: map[string]string{"one": "{count} élément", "other": "{count} éléments"}}},
+	})
+
+	for _, tt := range []struct {
+		locale, count, want string
+	}{
+		{"en", "1", "1 item"},
+		{"en", "0", "0 items"},
+		{"en", "2", "2 items"},
+		{"en", "", "{count} items"}, // No count
+		{"fr", "0", "0 élément"},
+		{"fr", "1", "1 élément"},
+		{"fr", "2", "2 éléments"},
+		{"id", "1", "1 item"}, // Falls back on the English forms, by English rules
+	} {
+		var params map[string]string
+		if tt.count != "" {
+			params = map[string]string{i18n.CountParam: tt.count}
+		}
+		msg, ok := custom.Message(tt.locale, "items", params)
+		assert.True(t, ok)
+		assert.Equal(t, tt.want, msg, "%s, count %q", tt.locale, tt.count)
+	}
+
+	msg, _ := i18n.Default().Message("en", "validation.min.string", map[string]string{"field": "name", i18n.CountParam: "1"})
+	assert.Equal(t, "name must be at least 1 character long", msg)
+}
+
+func TestActorLocalePreference(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), problem.ActorLocale)
+	e.GET("/users/:id", func(c echo.Context) error {
+		if locale := c.QueryParam("locale"); locale != "-" {
+			c.SetRequest(c.Request().WithContext(requestctx.WithLocale(c.Request().Context(), locale)))
+		}
+		return problem.WithCode(domain.ErrNotFound, problem.CodeUserNotFound)
+	})
+	serve := func(locale, acceptLanguage string) (string, string) {
+		req := httptest.NewRequest(http.MethodGet, "/users/42?locale="+locale, nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Header().Get("Content-Language"), decodeProblem(t, rec).Detail
+	}
+
+	language, detail := serve("id", "en")
+	assert.Equal(t, "id", language, "the user's choice wins over Accept-Language")
+	assert.Equal(t, "Pengguna tidak ditemukan.", detail)
+	language, detail = serve("", "id-ID, en;q=0.5")
+	assert.Equal(t, "id", language, "without a choice, Accept-Language applies")
+	assert.Equal(t, "Pengguna tidak ditemukan.", detail)
+	language, _ = serve("-", "en")
+	assert.Equal(t, "en", language, "anonymous requests")
+	language, _ = serve("xx", "")
+	assert.Equal(t, "en", language, "unavailable locales")
+}
@@ -0,0 +1,239 @@
+// /test/ratelimit_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/config"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/ratelimit"
+)
+
+// testClock is a settable clock for limiters and fake Redis servers.
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+var rateLimitStoreBackends = map[string]func(clock *testClock) ratelimit.Store{
+	"memory": func(*testClock) ratelimit.Store { return ratelimit.NewMemoryStore() },
+	"redis": func(clock *testClock) ratelimit.Store {
+		redis := ratelimit.NewFakeRedis()
+		redis.Now = clock.Now
+		return ratelimit.NewRedisStore(redis, "ratelimit:")
+	},
+}
+
+func TestRateLimiterSlidingWindow(t *testing.T) {
+	policy := ratelimit.Policy{Name: "test", Requests: 4, Window: time.Minute}
+	for name, newStore := range rateLimitStoreBackends {
+		t.Run(name, func(t *testing.T) {
+			clock := &testClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+			limiter := ratelimit.NewLimiter(newStore(clock))
+			limiter.Now = clock.Now
+			ctx := context.Background()
+
+			for i := range 4 {
+				result, err := limiter.Allow(ctx, policy, "a")
+				require.NoError(t, err)
+				assert.True(t, result.Allowed)
+				assert.Equal(t, 3-i, result.Remaining)
+			}
+			result, err := limiter.Allow(ctx, policy, "a")
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, time.Minute, result.Reset)
+			assert.Equal(t, 0, result.Remaining)
+			assert.Positive(t, result.RetryAfter)
+
+			result, err = limiter.Allow(ctx, policy, "b")
+			require.NoError(t, err)
+			assert.True(t, result.Allowed, "clients have their own counters")
+
+			// A quarter into the next window, 3/4 of the 5 requests counted still weigh: 3.75 + 1
+			clock.Advance(time.Minute + 15*time.Second)
+			result, err = limiter.Allow(ctx, policy, "a")
+			require.NoError(t, err)
+			assert.False(t, result.Allowed, "the previous window still counts")
+
+			// Halfway, the previous window weighs 2.5, and the rejected request counts: 2.5 + 2
+			clock.Advance(15 * time.Second)
+			result, err = limiter.Allow(ctx, policy, "a")
+			require.NoError(t, err)
+			assert.False(t, result.Allowed, "rejected requests count too")
+
+			clock.Advance(2 * time.Minute)
+			result, err = limiter.Allow(ctx, policy, "a")
+			require.NoError(t, err)
+			assert.True(t, result.Allowed, "old windows are forgotten")
+			assert.Equal(t, 3, result.Remaining)
+		})
+	}
+}
+
+func TestRateLimiterRetryAfter(t *testing.T) {
+	clock := &testClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+	limiter.Now = clock.Now
+	policy := ratelimit.Policy{Name: "test", Requests: 2, Window: time.Minute}
+	ctx := context.Background()
+
+	var result ratelimit.Result
+	for range 3 {
+		result, _ = limiter.Allow(ctx, policy, "a")
+	}
+	require.False(t, result.Allowed)
+	clock.Advance(result.RetryAfter)
+	result, err := limiter.Allow(ctx, policy, "a")
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "a request is allowed once Retry-After elapsed")
+}
+
+func TestRedisStoreExpiresCounters(t *testing.T) {
+	clock := &testClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	redis := ratelimit.NewFakeRedis()
+	redis.Now = clock.Now
+	limiter := ratelimit.NewLimiter(ratelimit.NewRedisStore(redis, "ratelimit:"))
+	limiter.Now = clock.Now
+	policy := ratelimit.Policy{Name: "test", Requests: 1, Window: time.Minute}
+
+	_, err := limiter.Allow(context.Background(), policy, "a")
+	require.NoError(t, err)
+	assert.Len(t, redis.Keys(), 1)
+	clock.Advance(2 * time.Minute)
+	assert.Empty(t, redis.Keys(), "counters expire once they no longer count")
+}
+
+// rateLimitedServer serves GET /api/auth/login and /api/things behind middleware.RateLimit,
+// identifying users by the X-User header.
+func rateLimitedServer(t *testing.T, store ratelimit.Store, policies ...config.RateLimitPolicy) *echo.Echo {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), nil)
+	e.IPExtractor = middleware.ClientIP(nil)
+	users := func(r *http.Request) (uuid.UUID, bool) {
+		userID, err := uuid.Parse(r.Header.Get("X-User"))
+		return userID, err == nil
+	}
+	apiKeys := func(r *http.Request) (string, bool) { // Issued keys: k1 and k2
+		apiKey := r.Header.Get(middleware.HeaderAPIKey)
+		return apiKey, apiKey == "k1" || apiKey == "k2"
+	}
+	rules := middleware.RateLimitRules(config.RateLimitConfig{Enabled: true, Policies: policies})
+	e.Use(middleware.RateLimit(ratelimit.NewLimiter(store), rules, users, apiKeys, zap.NewNop()))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	e.GET("/api/auth/login", ok)
+	e.GET("/api/things", ok)
+	e.GET("/health", ok)
+	return e
+}
+
+func getRateLimited(e *echo.Echo, path string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	auth := config.RateLimitPolicy{Name: "auth", Prefix: "/api/auth", Requests: 2, Window: "1m", By: "ip"}
+	api := config.RateLimitPolicy{Name: "api", Prefix: "/api/", Requests: 3, Window: "1m", By: "user"}
+
+	t.Run("headers and 429", func(t *testing.T) {
+		e := rateLimitedServer(t, ratelimit.NewMemoryStore(), auth, api)
+		rec := getRateLimited(e, "/api/auth/login")
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get(middleware.HeaderRateLimitLimit), "the most constraining policy")
+		assert.Equal(t, "1", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+		assert.Equal(t, "2;w=60, 3;w=60", rec.Header().Get(middleware.HeaderRateLimitPolicy))
+		assert.NotEmpty(t, rec.Header().Get(middleware.HeaderRateLimitReset))
+
+		getRateLimited(e, "/api/auth/login")
+		rec = getRateLimited(e, "/api/auth/login")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, problem.CodeRateLimited, decodeProblem(t, rec).Code)
+		assert.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+		assert.Equal(t, "0", rec.Header().Get(middleware.HeaderRateLimitRemaining))
+
+		rec = getRateLimited(e, "/health")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get(middleware.HeaderRateLimitLimit), "routes outside every prefix aren't limited")
+	})
+
+	t.Run("by user", func(t *testing.T) {
+		e := rateLimitedServer(t, ratelimit.NewMemoryStore(), api)
+		alice, bob := uuid.NewString(), uuid.NewString()
+		for range 3 {
+			require.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/things", "X-User", alice).Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, getRateLimited(e, "/api/things", "X-User", alice).Code)
+		assert.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/things", "X-User", bob).Code,
+			"users sharing an IP have their own limits")
+		assert.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/things").Code, "anonymous requests count by IP")
+	})
+
+	t.Run("by API key", func(t *testing.T) {
+		byKey := config.RateLimitPolicy{Name: "keys", Prefix: "/api", Requests: 1, Window: "1m", By: "api_key"}
+		e := rateLimitedServer(t, ratelimit.NewMemoryStore(), byKey)
+		assert.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/things", middleware.HeaderAPIKey, "k1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, getRateLimited(e, "/api/things", middleware.HeaderAPIKey, "k1").Code)
+		assert.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/things", middleware.HeaderAPIKey, "k2").Code)
+		assert.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/things", middleware.HeaderAPIKey, "forged-1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, getRateLimited(e, "/api/things", middleware.HeaderAPIKey, "forged-2").Code,
+			"keys that aren't verified count by IP")
+	})
+
+	t.Run("forwarded headers are not trusted", func(t *testing.T) {
+		e := rateLimitedServer(t, ratelimit.NewMemoryStore(), auth)
+		for i := range 2 {
+			require.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/auth/login", echo.HeaderXForwardedFor, fmt.Sprintf("203.0.113.%d", i)).Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, getRateLimited(e, "/api/auth/login", echo.HeaderXRealIP, "203.0.113.9").Code)
+	})
+
+	t.Run("fails open", func(t *testing.T) {
+		redis := ratelimit.NewFakeRedis()
+		redis.Err = errors.New("connection refused")
+		e := rateLimitedServer(t, ratelimit.NewRedisStore(redis, "ratelimit:"), auth)
+		for range 3 {
+			assert.Equal(t, http.StatusNoContent, getRateLimited(e, "/api/auth/login").Code)
+		}
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	realIP := func(extractor echo.IPExtractor, remoteAddr, forwardedFor string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		return extractor(req)
+	}
+
+	direct := middleware.ClientIP(nil)
+	assert.Equal(t, "192.0.2.1", realIP(direct, "192.0.2.1:1234", "203.0.113.7"), "without proxies, the connection")
+	assert.Equal(t, "10.0.0.5", realIP(direct, "10.0.0.5:1234", "203.0.113.7"), "private addresses aren't trusted by default")
+
+	proxied := middleware.ClientIP([]string{"10.0.0.0/8"})
+	assert.Equal(t, "203.0.113.7", realIP(proxied, "10.0.0.5:1234", "203.0.113.7"), "the client the proxy saw")
+	assert.Equal(t, "203.0.113.7", realIP(proxied, "10.0.0.5:1234", "198.51.100.1, 203.0.113.7"), "what the client sent is skipped")
+	assert.Equal(t, "192.0.2.1", realIP(proxied, "192.0.2.1:1234", "203.0.113.7"), "only trusted proxies forward")
+}
@@ -0,0 +1,145 @@
+// /test/replicas_test.go
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/app"
+	"youGo/internal/config"
+	"youGo/internal/modules"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/metrics"
+	repoImpl "youGo/internal/repository/postgres"
+)
+
+type noteModel struct {
+	ID     int64 `gorm:"primaryKey"`
+	Source string
+}
+
+func (noteModel) TableName() string { return "notes" }
+
+// openNotesDB opens a SQLite database whose notes table holds one row naming it.
+func openNotesDB(t *testing.T, name string) *gorm.DB {
+	db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: filepath.Join(t.TempDir(), name+".db")}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY, source TEXT NOT NULL)").Error)
+	require.NoError(t, db.Create(&noteModel{Source: name}).Error)
+	return db
+}
+
+// readSources returns the sources of the notes read through db.
+func readSources(t *testing.T, db *gorm.DB) []string {
+	var notes []noteModel
+	require.NoError(t, db.Order("id").Find(&notes).Error)
+	sources := make([]string, 0, len(notes))
+	for _, n := range notes {
+		sources = append(sources, n.Source)
+	}
+	return sources
+}
+
+func TestReplicaRouting(t *testing.T) {
+	primary, replicaDB := openNotesDB(t, "primary"), openNotesDB(t, "replica")
+	replicaConn, err := replicaDB.DB()
+	require.NoError(t, err)
+	set, err := database.UseReplicas(primary, []database.Replica{{Name: "replica", DB: replicaConn}}, time.Hour, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = set.Close() })
+	ctx := context.Background()
+
+	assert.Equal(t, []string{"replica"}, readSources(t, primary.WithContext(ctx)), "reads go to the replica")
+	var source string
+	require.NoError(t, primary.Raw("SELECT source FROM notes").Scan(&source).Error)
+	assert.Equal(t, "replica", source, "raw SELECTs too")
+
+	require.NoError(t, primary.Transaction(func(tx *gorm.DB) error {
+		assert.Equal(t, []string{"primary"}, readSources(t, tx), "transactions stay on the primary")
+		return nil
+	}))
+	assert.Equal(t, []string{"primary"}, readSources(t, primary.WithContext(database.UsePrimary(ctx))))
+
+	t.Run("read your writes", func(t *testing.T) {
+		request := database.WithReadYourWrites(ctx)
+		assert.Equal(t, []string{"replica"}, readSources(t, primary.WithContext(request)))
+
+		require.NoError(t, primary.WithContext(request).Create(&noteModel{Source: "written"}).Error, "writes go to the primary")
+		assert.Equal(t, []string{"primary", "written"}, readSources(t, primary.WithContext(request)),
+			"after a write, the request reads from the primary")
+		assert.Equal(t, []string{"replica"}, readSources(t, primary.WithContext(ctx)), "other requests don't")
+	})
+
+	t.Run("unhealthy replicas are ejected", func(t *testing.T) {
+		assert.Equal(t, []database.ReplicaStatus{{Name: "replica", Healthy: true}}, set.Status())
+		require.NoError(t, replicaConn.Close())
+		set.Check(ctx)
+
+		assert.Equal(t, []database.ReplicaStatus{{Name: "replica", Healthy: false}}, set.Status())
+		assert.Equal(t, []string{"primary", "written"}, readSources(t, primary.WithContext(ctx)), "reads fall back to the primary")
+	})
+}
+
+func TestConfigReplica(t *testing.T) {
+	db := config.Database{
+		Host: "primary", Port: "5432", User: "app", Password: "pw", DBName: "app", SSLMode: "require",
+		Replicas: []config.DatabaseReplica{{Host: "replica-1"}, {Host: "replica-2", Port: "6432", User: "reader", Password: "ro"}},
+	}
+	first := db.Replica(0)
+	assert.Equal(t, []string{"replica-1", "5432", "app", "pw", "app"}, []string{first.Host, first.Port, first.User, first.Password, first.DBName})
+	second := db.Replica(1)
+	assert.Equal(t, []string{"replica-2", "6432", "reader", "ro", "require"}, []string{second.Host, second.Port, second.User, second.Password, second.SSLMode})
+	assert.Empty(t, second.Replicas)
+
+	cfg := validConfig()
+	cfg.Database.Replicas = db.Replicas
+	redacted := cfg.Redacted()
+	assert.Equal(t, "[REDACTED]", redacted.Database.Replicas[1].Password)
+	assert.Equal(t, "ro", cfg.Database.Replicas[1].Password, "the original is left untouched")
+
+	cfg.Database.Replicas = append(cfg.Database.Replicas, config.DatabaseReplica{Port: "5432"})
+	cfg.Database.ReplicaCheckInterval = "often"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.replicas[2].host is required")
+	assert.Contains(t, err.Error(), `database.replica_check_interval: invalid duration "often"`)
+}
+
+func TestReplicaPoolMetrics(t *testing.T) {
+	primary := openNotesDB(t, "primary")
+	var replicas []database.Replica
+	for _, name := range []string{"replica-a", "replica-b"} {
+		conn, err := openNotesDB(t, name).DB()
+		require.NoError(t, err)
+		replicas = append(replicas, database.Replica{Name: name, DB: conn})
+	}
+	set, err := database.UseReplicas(primary, replicas, time.Hour, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = set.Close() })
+
+	cfg := validConfig()
+	c := app.NewContainer()
+	app.Supply(c, &cfg)
+	app.Supply(c, zap.NewNop())
+	app.Supply(c, primary)
+	app.Supply(c, repoImpl.NewTxManager(primary, 0))
+	app.Supply(c, set)
+	modules.Metrics().Provide(c)
+	m, err := app.Resolve[*metrics.Metrics](c)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, pool := range []string{"primary", "replica-1", "replica-2"} {
+		assert.Contains(t, rec.Body.String(), `go_sql_open_connections{db_name="`+pool+`"}`)
+	}
+}
@@ -0,0 +1,239 @@
+// /test/repository_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/query"
+)
+
+// newDryRunDB returns a Postgres GORM handle that builds statements without a server.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1 user=test dbname=test"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	return db
+}
+
+// statementRecorder collects the SQL of the statements run through db.
+type statementRecorder struct {
+	statements []string
+	vars       [][]any
+}
+
+func recordStatements(t *testing.T, db *gorm.DB) *statementRecorder {
+	rec := &statementRecorder{}
+	record := func(tx *gorm.DB) {
+		rec.statements = append(rec.statements, tx.Statement.SQL.String())
+		rec.vars = append(rec.vars, tx.Statement.Vars)
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:record_query", record))
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:record_create", record))
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:record_update", record))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:record_delete", record))
+	return rec
+}
+
+type widgetModel struct {
+	ID        uuid.UUID
+	Name      string
+	Status    string
+	Price     float64
+	DeletedBy *uuid.UUID
+	CreatedAt time.Time
+}
+
+func (widgetModel) TableName() string { return "widgets" }
+
+var (
+	widgetID        = query.NewColumn[uuid.UUID]("id")
+	widgetName      = query.NewText("name")
+	widgetStatus    = query.NewText("status")
+	widgetPrice     = query.NewColumn[float64]("price")
+	widgetDeletedBy = query.NewColumn[*uuid.UUID]("deleted_by")
+	widgetCreatedAt = query.NewColumn[time.Time]("created_at")
+)
+
+func renderQuery(t *testing.T, q query.Query) (string, []any) {
+	var models []widgetModel
+	stmt := q.Apply(newDryRunDB(t).Model(&widgetModel{})).Find(&models).Statement
+	return stmt.SQL.String(), stmt.Vars
+}
+
+func TestQueryDSL(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		q    query.Query
+		sql  string
+		vars []any
+	}{
+		{
+			name: "everything",
+			q:    query.Query{},
+			sql:  `SELECT * FROM "widgets"`,
+		},
+		{
+			name: "conditions are ANDed",
+			q:    query.Where(widgetStatus.Eq("active"), widgetPrice.Gte(10), widgetPrice.Lt(20)),
+			sql:  `SELECT * FROM "widgets" WHERE "status" = $1 AND "price" >= $2 AND "price" < $3`,
+			vars: []any{"active", 10.0, 20.0},
+		},
+		{
+			name: "or, not, in and null",
+			q: query.Where(
+				query.Or(widgetStatus.In("active", "trial"), query.Not(widgetName.Eq("x"))),
+				widgetDeletedBy.IsNull(),
+			),
+			sql:  `SELECT * FROM "widgets" WHERE ("status" IN ($1,$2) OR "name" <> $3) AND "deleted_by" IS NULL`,
+			vars: []any{"active", "trial", "x"},
+		},
+		{
+			name: "empty sets",
+			q:    query.Where(widgetStatus.NotIn(), widgetStatus.In()),
+			sql:  `SELECT * FROM "widgets" WHERE 1 = 0`,
+		},
+		{
+			name: "patterns are escaped",
+			q:    query.Where(widgetName.ContainsFold("50%_off"), widgetName.HasPrefix("a")),
+			sql:  `SELECT * FROM "widgets" WHERE LOWER("name") LIKE LOWER($1) ESCAPE '\' AND "name" LIKE $2 ESCAPE '\'`,
+			vars: []any{`%50\%\_off%`, `a%`},
+		},
+		{
+			name: "sort, projection and page",
+			q: query.Where(widgetCreatedAt.Gt(time.Time{})).
+				OrderBy(widgetStatus.Asc(), widgetCreatedAt.Desc()).
+				Select(widgetID, widgetName).
+				Limit(10).
+				Offset(20),
+			sql:  `SELECT "id","name" FROM "widgets" WHERE "created_at" > $1 ORDER BY "status","created_at" DESC LIMIT $2 OFFSET $3`,
+			vars: []any{time.Time{}, 10, 20},
+		},
+		{
+			name: "row lock",
+			q:    query.Where(widgetStatus.Eq("active")).ForUpdate(),
+			sql:  `SELECT * FROM "widgets" WHERE "status" = $1 FOR UPDATE`,
+			vars: []any{"active"},
+		},
+		{
+			name: "raw SQL for dialect-specific operators",
+			q:    query.Where(query.Raw("tags @> CAST(? AS jsonb)", `["a"]`)),
+			sql:  `SELECT * FROM "widgets" WHERE tags @> CAST($1 AS jsonb)`,
+			vars: []any{`["a"]`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, vars := renderQuery(t, tc.q)
+			assert.Equal(t, tc.sql, sql)
+			if tc.vars != nil {
+				assert.Equal(t, tc.vars, vars)
+			}
+		})
+	}
+}
+
+func TestQueryIsImmutable(t *testing.T) {
+	base := query.Where(widgetStatus.Eq("active"))
+	cheap := base.Where(widgetPrice.Lt(5))
+	expensive := base.Where(widgetPrice.Gte(5))
+
+	sql, _ := renderQuery(t, base)
+	assert.Equal(t, `SELECT * FROM "widgets" WHERE "status" = $1`, sql)
+	sql, _ = renderQuery(t, cheap)
+	assert.Contains(t, sql, `"price" < $2`)
+	sql, _ = renderQuery(t, expensive)
+	assert.Contains(t, sql, `"price" >= $2`)
+
+	// Filter drops everything but the conditions, e.g. for counting a page's total
+	sql, _ = renderQuery(t, base.OrderBy(widgetName.Asc()).Limit(5).Filter())
+	assert.Equal(t, `SELECT * FROM "widgets" WHERE "status" = $1`, sql)
+}
+
+func TestQueryPreload(t *testing.T) {
+	db := query.Query{}.Preload("Parts").Preload("Owner", widgetStatus.Eq("active")).Apply(newDryRunDB(t))
+	assert.Contains(t, db.Statement.Preloads, "Parts")
+	assert.Contains(t, db.Statement.Preloads, "Owner")
+	assert.Len(t, db.Statement.Preloads["Owner"], 1, "the related rows are filtered")
+}
+
+func TestMapError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want error
+	}{
+		{err: &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"}, want: domain.ErrDuplicateEntry},
+		{err: &pgconn.PgError{Code: "23503", ConstraintName: "orders_user_id_fkey"}, want: domain.ErrInvalidReference},
+		{err: &pgconn.PgError{Code: "23514", ConstraintName: "products_price_check"}, want: domain.ErrConstraintViolation},
+		{err: &pgconn.PgError{Code: "23502", ColumnName: "name"}, want: domain.ErrConstraintViolation},
+		{err: fmt.Errorf("wrapped: %w", &pgconn.PgError{Code: "23505"}), want: domain.ErrDuplicateEntry},
+		{err: gorm.ErrDuplicatedKey, want: domain.ErrDuplicateEntry},
+		{err: gorm.ErrForeignKeyViolated, want: domain.ErrInvalidReference},
+	} {
+		mapped := repoImpl.MapError(tc.err)
+		assert.ErrorIs(t, mapped, tc.want, tc.err.Error())
+		assert.True(t, domain.IsConstraintError(mapped), tc.err.Error())
+	}
+
+	mapped := repoImpl.MapError(&pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"})
+	var constraintErr *domain.ConstraintError
+	require.ErrorAs(t, mapped, &constraintErr)
+	assert.Equal(t, "users_email_key", constraintErr.Constraint)
+
+	assert.Equal(t, domain.ErrNotFound, repoImpl.MapError(fmt.Errorf("query: %w", gorm.ErrRecordNotFound)))
+	other := errors.New("connection reset")
+	assert.Equal(t, other, repoImpl.MapError(other), "other errors are returned as they are")
+	serializationErr := &pgconn.PgError{Code: "40001"}
+	assert.Equal(t, error(serializationErr), repoImpl.MapError(serializationErr))
+	assert.NoError(t, repoImpl.MapError(nil))
+}
+
+func TestRepositoryStatements(t *testing.T) {
+	db := newDryRunDB(t)
+	rec := recordStatements(t, db)
+	repo := repoImpl.NewUserRepository(db)
+	ctx := context.Background()
+	id := uuid.New()
+
+	_, err := repo.FindByEmail(ctx, "alice@example.com")
+	require.NoError(t, err, "a dry run finds nothing but reports no error")
+	assert.Equal(t, `SELECT * FROM "users" WHERE "email" = $1 LIMIT $2`, rec.statements[0])
+
+	// Every mutable column is written, including zero values, by primary key
+	err = repo.Update(ctx, &domain.User{ID: id, Name: "Alice", IsActive: false})
+	assert.ErrorIs(t, err, domain.ErrNotFound, "no row changed")
+	assert.Equal(t, `UPDATE "users" SET "name"=$1,"email"=$2,"password_hash"=$3,"is_active"=$4,"role"=$5,"locale"=$6,"updated_at"=$7 WHERE "id" = $8`,
+		rec.statements[1])
+	assert.Equal(t, false, rec.vars[1][3])
+	assert.Equal(t, id, rec.vars[1][7])
+
+	assert.ErrorIs(t, repo.Update(ctx, &domain.User{Name: "Never stored"}), domain.ErrNotFound)
+
+	assert.ErrorIs(t, repo.Delete(ctx, id), domain.ErrNotFound)
+	assert.Equal(t, `DELETE FROM "users" WHERE "id" = $1`, rec.statements[len(rec.statements)-1])
+}
+
+func TestRepositoryMapsWriteErrors(t *testing.T) {
+	db := newDryRunDB(t)
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:unique_violation", func(tx *gorm.DB) {
+		_ = tx.AddError(&pgconn.PgError{Code: "23505", ConstraintName: "users_email_key"})
+	}))
+	repo := repoImpl.NewUserRepository(db)
+
+	err := repo.Create(context.Background(), &domain.User{Name: "Alice", Email: "alice@example.com"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateEntry)
+	var constraintErr *domain.ConstraintError
+	require.ErrorAs(t, err, &constraintErr)
+	assert.Equal(t, "users_email_key", constraintErr.Constraint)
+}
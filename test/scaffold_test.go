@@ -0,0 +1,186 @@
+// /test/scaffold_test.go
+package test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"youGo/internal/scaffold"
+)
+
+func TestParseResourceNames(t *testing.T) {
+	for _, tc := range []struct {
+		name                                        string
+		resource, plural, camel, snakePlural, kebab string
+	}{
+		{name: "Product", resource: "Product", plural: "Products", camel: "product", snakePlural: "products", kebab: "products"},
+		{name: "blog_post", resource: "BlogPost", plural: "BlogPosts", camel: "blogPost", snakePlural: "blog_posts", kebab: "blog-posts"},
+		{name: "Category", resource: "Category", plural: "Categories", camel: "category", snakePlural: "categories", kebab: "categories"},
+		{name: "APIKey", resource: "APIKey", plural: "APIKeys", camel: "apiKey", snakePlural: "api_keys", kebab: "api-keys"},
+		{name: "Box", resource: "Box", plural: "Boxes", camel: "box", snakePlural: "boxes", kebab: "boxes"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := scaffold.ParseResource(tc.name, []string{"title:string"})
+			require.NoError(t, err)
+			assert.Equal(t, tc.resource, r.Name)
+			assert.Equal(t, tc.plural, r.Plural)
+			assert.Equal(t, tc.camel, r.Camel)
+			assert.Equal(t, tc.snakePlural, r.SnakePlural)
+			assert.Equal(t, tc.kebab, r.KebabPlural)
+		})
+	}
+}
+
+func TestParseResourceFields(t *testing.T) {
+	r, err := scaffold.ParseResource("Product", []string{"unit_price:float", "ownerID:uuid"})
+	require.NoError(t, err)
+	require.Len(t, r.Fields, 2)
+	assert.Equal(t, "UnitPrice", r.Fields[0].Name)
+	assert.Equal(t, "unitPrice", r.Fields[0].JSON)
+	assert.Equal(t, "unit_price", r.Fields[0].Column)
+	assert.Equal(t, "float64", r.Fields[0].GoType)
+	assert.Equal(t, "OwnerID", r.Fields[1].Name)
+	assert.Equal(t, "ownerId", r.Fields[1].JSON)
+	assert.Equal(t, "owner_id", r.Fields[1].Column)
+
+	for _, tc := range []struct {
+		name   string
+		fields []string
+		err    string
+	}{
+		{name: "Product", err: "at least one field"},
+		{name: "Product", fields: []string{"price"}, err: "expected name:type"},
+		{name: "Product", fields: []string{"price:money"}, err: "unknown type"},
+		{name: "Product", fields: []string{"id:uuid"}, err: "generated for every resource"},
+		{name: "Product", fields: []string{"unit_price:float", "unitPrice:int"}, err: "listed twice"},
+		{name: "9lives", fields: []string{"name:string"}, err: "invalid resource name"},
+		{name: "Request", fields: []string{"name:string"}, err: "clashes"},
+	} {
+		_, err := scaffold.ParseResource(tc.name, tc.fields)
+		assert.ErrorContains(t, err, tc.err, "%s %v", tc.name, tc.fields)
+	}
+}
+
+// newScaffoldProject writes the parts of a project the generator reads into a temporary directory.
+func newScaffoldProject(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/shop\n\ngo 1.24\n",
+		"internal/modules/modules.go": "package modules\n\nfunc Default() []app.Module {\n\treturn []app.Module{\n\t\tUsers(),\n\t\t" +
+			scaffold.ModulesMarker + "\n\t}\n}\n",
+		"migrations/users/000001_create_users_table.up.sql":   "",
+		"migrations/audit/000007_create_audit_table.up.sql":   "",
+		"migrations/audit/000007_create_audit_table.down.sql": "",
+	}
+	for path, content := range files {
+		target := filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+		require.NoError(t, os.WriteFile(target, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestGenerateResource(t *testing.T) {
+	dir := newScaffoldProject(t)
+	r, err := scaffold.ParseResource("BlogPost", []string{"title:string", "published_at:time", "author_id:uuid"})
+	require.NoError(t, err)
+
+	// A dry run reports the files without writing them
+	planned, err := scaffold.Generate(r, scaffold.Options{Dir: dir, DryRun: true})
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "internal/domain/blog_post.go"))
+
+	written, err := scaffold.Generate(r, scaffold.Options{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, planned, written)
+	assert.Contains(t, written, "migrations/blog_posts/000008_create_blog_posts_table.up.sql", "versions continue across modules")
+	for _, path := range written {
+		assert.FileExists(t, filepath.Join(dir, path))
+	}
+
+	handler, err := os.ReadFile(filepath.Join(dir, "internal/api/handler/blog_post_handler.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(handler), `"example.com/shop/internal/service"`, "imports use the module path of go.mod")
+	assert.Contains(t, string(handler), "// @Router       /blog-posts/{id} [put]")
+
+	modules, err := os.ReadFile(filepath.Join(dir, "internal/modules/modules.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(modules), "\t\tUsers(),\n\t\tBlogPosts(),\n\t\t"+scaffold.ModulesMarker)
+
+	// Existing files are kept unless forced; the shared test fakes are never overwritten
+	_, err = scaffold.Generate(r, scaffold.Options{Dir: dir})
+	assert.ErrorContains(t, err, "already exists")
+	forced, err := scaffold.Generate(r, scaffold.Options{Dir: dir, Force: true})
+	require.NoError(t, err)
+	assert.NotContains(t, forced, "test/fakes_test.go")
+	assert.NotContains(t, forced, "internal/modules/modules.go", "the module is registered once")
+	modules, err = os.ReadFile(filepath.Join(dir, "internal/modules/modules.go"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(modules), "BlogPosts()"))
+}
+
+func TestGenerateResourceNeedsModulesMarker(t *testing.T) {
+	dir := newScaffoldProject(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "internal/modules/modules.go"), []byte("package modules\n"), 0o644))
+	r, err := scaffold.ParseResource("Product", []string{"name:string"})
+	require.NoError(t, err)
+
+	_, err = scaffold.Generate(r, scaffold.Options{Dir: dir})
+	assert.ErrorContains(t, err, scaffold.ModulesMarker)
+	assert.NoFileExists(t, filepath.Join(dir, "internal/domain/product.go"), "nothing is written on failure")
+}
+
+func TestGenerateResourceKeepsDeclaredFakes(t *testing.T) {
+	r, err := scaffold.ParseResource("Product", []string{"name:string"})
+	require.NoError(t, err)
+	writeTest := func(dir, src string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "test"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "test/user_service_test.go"), []byte("package test\n\n"+src), 0o644))
+	}
+
+	dir := newScaffoldProject(t)
+	writeTest(dir, "type passthroughTxManager struct{}\n\ntype recordingAuditor struct{}\n")
+	written, err := scaffold.Generate(r, scaffold.Options{Dir: dir})
+	require.NoError(t, err)
+	assert.NotContains(t, written, "test/fakes_test.go", "the package declares the fakes already")
+	assert.NoFileExists(t, filepath.Join(dir, "test/fakes_test.go"))
+
+	dir = newScaffoldProject(t)
+	writeTest(dir, "type passthroughTxManager struct{}\n")
+	_, err = scaffold.Generate(r, scaffold.Options{Dir: dir})
+	assert.ErrorContains(t, err, "declares passthroughTxManager but not the rest")
+	assert.NoFileExists(t, filepath.Join(dir, "internal/domain/product.go"), "nothing is written on failure")
+}
+
+// TestGeneratedResourceCompiles generates a resource in a copy of this project, and builds and
+// tests the result.
+func TestGeneratedResourceCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a copy of the project")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.CopyFS(dir, os.DirFS("..")))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, ".git")))
+
+	r, err := scaffold.ParseResource("Product", []string{"name:string", "price:float"})
+	require.NoError(t, err)
+	_, err = scaffold.Generate(r, scaffold.Options{Dir: dir})
+	require.NoError(t, err)
+
+	for _, args := range [][]string{{"vet", "./..."}, {"test", "-count=1", "-run", "TestProductService", "./test/"}} {
+		cmd := exec.Command(goTool, args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "go %s:\n%s", strings.Join(args, " "), out)
+	}
+}
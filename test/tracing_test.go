@@ -0,0 +1,214 @@
+// /test/tracing_test.go
+package test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/problem"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/httpclient"
+	"youGo/internal/platform/i18n"
+	"youGo/internal/platform/requestctx"
+	"youGo/internal/platform/tracing"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+)
+
+// recordSpans installs a tracer provider recording the ended spans, and the W3C propagator,
+// for the duration of the test.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return exporter
+}
+
+// spanNamed returns the recorded span named name.
+func spanNamed(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no span %q among %d", name, len(exporter.GetSpans()))
+	return tracetest.SpanStub{}
+}
+
+// spanAttribute returns the value of the attribute key of span.
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := recordSpans(t)
+	core, logs := observer.New(zap.InfoLevel)
+	e := echo.New()
+	e.HTTPErrorHandler = problem.Handler(zap.NewNop(), i18n.Default(), nil)
+	e.Use(middleware.Tracing(), middleware.RequestContext(), middleware.RequestLogger(zap.New(core)))
+	var handlerTraceID string
+	e.GET("/api/things/:id", func(c echo.Context) error {
+		ctx, span := tracing.Start(c.Request().Context(), "ThingService.Get")
+		defer span.End()
+		handlerTraceID = requestctx.FromContext(ctx).TraceID
+		if c.Param("id") == "broken" {
+			return assert.AnError
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/api/things/1", nil)
+	req.Header.Set("traceparent", traceparent)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	server := spanNamed(t, exporter, "GET /api/things/:id")
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String(), "the caller's trace continues")
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Equal(t, int64(204), spanAttribute(server, "http.response.status_code").AsInt64())
+	assert.Equal(t, "/api/things/:id", spanAttribute(server, "http.route").AsString())
+	child := spanNamed(t, exporter, "ThingService.Get")
+	assert.Equal(t, server.SpanContext.SpanID(), child.Parent.SpanID())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerTraceID)
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", fields["trace_id"])
+	assert.Equal(t, server.SpanContext.SpanID().String(), fields["span_id"])
+
+	exporter.Reset()
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/things/broken", nil))
+	failed := spanNamed(t, exporter, "GET /api/things/:id")
+	assert.False(t, failed.Parent.IsValid(), "a new trace without traceparent")
+	assert.Equal(t, codes.Error, failed.Status.Code)
+	assert.Equal(t, int64(500), spanAttribute(failed, "http.response.status_code").AsInt64())
+}
+
+func TestTracingGORM(t *testing.T) {
+	exporter := recordSpans(t)
+	db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, sqlite.Migrate(context.Background(), db))
+	require.NoError(t, tracing.InstrumentGORM(db))
+	repo := repoImpl.NewUserRepository(db)
+
+	ctx, span := tracing.Start(context.Background(), "UserService.Create")
+	user := &domain.User{ID: uuid.New(), Name: "Ann", Email: "ann@example.com", PasswordHash: "x", IsActive: true, Role: domain.RoleUser}
+	require.NoError(t, repo.Create(ctx, user))
+	_, err = repo.FindByID(ctx, uuid.New())
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	span.End()
+
+	create := spanNamed(t, exporter, "gorm.create")
+	assert.Equal(t, span.SpanContext().SpanID(), create.Parent.SpanID(), "child of the span of the statement's context")
+	assert.Equal(t, trace.SpanKindClient, create.SpanKind)
+	assert.Equal(t, "sqlite", spanAttribute(create, "db.system").AsString())
+	assert.Equal(t, "users", spanAttribute(create, "db.collection.name").AsString())
+	assert.Contains(t, spanAttribute(create, "db.query.text").AsString(), "INSERT INTO")
+	assert.NotContains(t, spanAttribute(create, "db.query.text").AsString(), "ann@example.com", "no bind parameters")
+	assert.NotEqual(t, codes.Error, spanNamed(t, exporter, "gorm.query").Status.Code, "no row found isn't a failure")
+}
+
+func TestTracingHTTPClient(t *testing.T) {
+	exporter := recordSpans(t)
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, span := tracing.Start(context.Background(), "WebhookDispatcher.Deliver")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/hook?token=secret", nil)
+	require.NoError(t, err)
+	res, err := httpclient.NewHTTPClient(5 * time.Second).Do(req)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+	span.End()
+
+	client := spanNamed(t, exporter, "HTTP POST")
+	assert.Equal(t, trace.SpanKindClient, client.SpanKind)
+	assert.Equal(t, span.SpanContext().SpanID(), client.Parent.SpanID())
+	assert.Equal(t, server.URL+"/hook", spanAttribute(client, "url.full").AsString(), "the query may hold secrets")
+	assert.Equal(t, int64(502), spanAttribute(client, "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Error, client.Status.Code)
+	assert.Equal(t, "00-"+client.SpanContext.TraceID().String()+"-"+client.SpanContext.SpanID().String()+"-01", received)
+	assert.Empty(t, req.Header.Get("traceparent"), "the caller's request isn't modified")
+}
+
+func TestTracingSetup(t *testing.T) {
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	var out bytes.Buffer
+	previousStdout := tracing.Stdout
+	tracing.Stdout = &out
+	t.Cleanup(func() { tracing.Stdout = previousStdout })
+
+	_, err := tracing.Setup(context.Background(), config.TracingConfig{Enabled: true, Exporter: "jaeger"}, "test")
+	assert.Error(t, err)
+
+	shutdown, err := tracing.Setup(context.Background(), config.TracingConfig{Enabled: true, ServiceName: "you-go-test"}, "test")
+	require.NoError(t, err)
+	_, span := tracing.Start(context.Background(), "UserService.Create")
+	span.End()
+	require.NoError(t, shutdown(context.Background()), "flushes the pending spans")
+	assert.Contains(t, out.String(), `"Name": "UserService.Create"`)
+	assert.Contains(t, out.String(), "you-go-test")
+}
+
+func TestConfigValidateTracing(t *testing.T) {
+	cfg := validConfig()
+	cfg.Tracing = config.TracingConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, 1.0, cfg.Tracing.SampleRatioOrDefault())
+
+	cfg.Tracing = config.TracingConfig{Enabled: true, Exporter: "OTLP", SampleRatio: 1.5}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tracing.endpoint is required by the otlp exporter")
+	assert.Contains(t, err.Error(), "tracing.sample_ratio must be between 0 and 1")
+
+	cfg.Tracing = config.TracingConfig{Enabled: true, Exporter: "zipkin"}
+	assert.ErrorContains(t, cfg.Validate(), `tracing.exporter: unknown exporter "zipkin"`)
+}
@@ -0,0 +1,171 @@
+// /test/tx_manager_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"youGo/internal/domain"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+)
+
+// txBackends returns a database with the users table, and its user repository, for every
+// database the transaction manager runs on. Postgres runs when TEST_DATABASE_DSN is set.
+var txBackends = map[string]func(t *testing.T) (*gorm.DB, domain.UserRepository){
+	"sqlite": func(t *testing.T) (*gorm.DB, domain.UserRepository) {
+		db := openSchemaDB(t)
+		return db, sqlite.NewUserRepository(db)
+	},
+	"postgres": func(t *testing.T) (*gorm.DB, domain.UserRepository) {
+		db := openTestPostgres(t)
+		return db, repoImpl.NewUserRepository(db)
+	},
+}
+
+func TestTxManagerRepositoriesJoinTheTransaction(t *testing.T) {
+	for name, open := range txBackends {
+		t.Run(name, func(t *testing.T) {
+			db, repo := open(t)
+			txManager := repoImpl.NewTxManager(db, 0)
+			ctx := context.Background()
+			rollback := errors.New("rollback")
+
+			user := &domain.User{Name: "Ann", Email: "ann@example.com", Role: domain.RoleUser}
+			err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				require.NoError(t, repo.Create(ctx, user))
+				found, err := repo.FindByID(ctx, user.ID)
+				require.NoError(t, err, "reads in the transaction see its writes")
+				assert.Equal(t, "Ann", found.Name)
+				return rollback
+			})
+			require.ErrorIs(t, err, rollback)
+
+			_, err = repo.FindByID(ctx, user.ID)
+			assert.ErrorIs(t, err, domain.ErrNotFound, "the write was part of the rolled back transaction")
+		})
+	}
+}
+
+func TestTxManagerNestedUnitRollsBackToItsSavepoint(t *testing.T) {
+	for name, open := range txBackends {
+		t.Run(name, func(t *testing.T) {
+			db, repo := open(t)
+			txManager := repoImpl.NewTxManager(db, 0)
+			ctx := context.Background()
+			rollback := errors.New("rollback")
+
+			outer := &domain.User{Name: "Ann", Email: "ann@example.com", Role: domain.RoleUser}
+			inner := &domain.User{Name: "Bob", Email: "bob@example.com", Role: domain.RoleUser}
+			after := &domain.User{Name: "Cid", Email: "cid@example.com", Role: domain.RoleUser}
+			err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				require.NoError(t, repo.Create(ctx, outer))
+				nestedErr := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+					require.NoError(t, repo.Create(ctx, inner))
+					return rollback
+				})
+				require.ErrorIs(t, nestedErr, rollback)
+
+				// The outer transaction goes on
+				_, err := repo.FindByID(ctx, inner.ID)
+				assert.ErrorIs(t, err, domain.ErrNotFound, "the nested write was rolled back")
+				return repo.Create(ctx, after)
+			})
+			require.NoError(t, err)
+
+			for _, committed := range []*domain.User{outer, after} {
+				_, err := repo.FindByID(ctx, committed.ID)
+				assert.NoError(t, err, "%s was committed", committed.Name)
+			}
+			_, err = repo.FindByID(ctx, inner.ID)
+			assert.ErrorIs(t, err, domain.ErrNotFound)
+		})
+	}
+}
+
+func TestTxManagerRetriesSerializationFailures(t *testing.T) {
+	db, repo := txBackends["sqlite"](t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		failures   []error // Returned by the first calls, in order
+		maxRetries int
+		wantCalls  int
+		wantErr    bool
+	}{
+		{name: "serialization failure", failures: []error{&pgconn.PgError{Code: "40001"}}, maxRetries: 3, wantCalls: 2},
+		{name: "deadlock", failures: []error{&pgconn.PgError{Code: "40P01"}}, maxRetries: 3, wantCalls: 2},
+		{name: "wrapped", failures: []error{fmt.Errorf("updating user: %w", &pgconn.PgError{Code: "40001"})}, maxRetries: 3, wantCalls: 2},
+		{name: "until the retries run out", failures: []error{&pgconn.PgError{Code: "40001"}, &pgconn.PgError{Code: "40P01"}, &pgconn.PgError{Code: "40001"}},
+			maxRetries: 2, wantCalls: 3, wantErr: true},
+		{name: "unique violation", failures: []error{&pgconn.PgError{Code: "23505"}}, maxRetries: 3, wantCalls: 1, wantErr: true},
+		{name: "other error", failures: []error{errors.New("boom")}, maxRetries: 3, wantCalls: 1, wantErr: true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txManager := repoImpl.NewTxManager(db, tt.maxRetries)
+			calls := 0
+			var created []*domain.User
+			err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				calls++
+				user := &domain.User{Name: "User", Email: fmt.Sprintf("user%d-%d@example.com", i, calls), Role: domain.RoleUser}
+				require.NoError(t, repo.Create(ctx, user))
+				created = append(created, user)
+				if calls <= len(tt.failures) {
+					return tt.failures[calls-1]
+				}
+				return nil
+			})
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.failures[calls-1])
+			} else {
+				require.NoError(t, err)
+			}
+
+			// Only the write of a successful last attempt is kept
+			for j, user := range created {
+				_, err := repo.FindByID(ctx, user.ID)
+				if j == len(created)-1 && !tt.wantErr {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, domain.ErrNotFound, "attempt %d was rolled back", j+1)
+				}
+			}
+		})
+	}
+
+	t.Run("not in a nested unit", func(t *testing.T) {
+		txManager := repoImpl.NewTxManager(db, 3)
+		calls := 0
+		err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			return txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				calls++
+				return &pgconn.PgError{Code: "40001"}
+			})
+		})
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		assert.Equal(t, 4, calls, "the outermost unit reruns everything, the nested one doesn't retry on its own")
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		calls := 0
+		err := repoImpl.NewTxManager(db, 3).WithinTransaction(ctx, func(context.Context) error {
+			calls++
+			cancel()
+			return &pgconn.PgError{Code: "40001"}
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+}
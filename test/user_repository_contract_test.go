@@ -0,0 +1,252 @@
+// /test/user_repository_contract_test.go
+package test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/migrate"
+	"youGo/internal/repository/memory"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+	"youGo/migrations"
+)
+
+// userRepositoryBackends returns a constructor of an empty repository for every implementation.
+// Postgres runs when TEST_DATABASE_DSN is set, e.g. "host=localhost user=app password=... dbname=app_test";
+// its users table is emptied.
+var userRepositoryBackends = map[string]func(t *testing.T) domain.UserRepository{
+	"memory": func(t *testing.T) domain.UserRepository {
+		return memory.NewUserRepository()
+	},
+	"sqlite": func(t *testing.T) domain.UserRepository {
+		db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = sqlDB.Close() })
+		require.NoError(t, sqlite.Migrate(context.Background(), db))
+		return sqlite.NewUserRepository(db)
+	},
+	"postgres": func(t *testing.T) domain.UserRepository {
+		return repoImpl.NewUserRepository(openTestPostgres(t))
+	},
+}
+
+// openTestPostgres connects to TEST_DATABASE_DSN, skipping the test if it isn't set, and
+// returns the database with the users migrations applied and the users table emptied.
+func openTestPostgres(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	runner, err := migrate.NewRunner(sqlDB, []fs.FS{migrations.Users}, zap.NewNop())
+	require.NoError(t, err)
+	_, err = runner.Up(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("DELETE FROM users").Error)
+	return db
+}
+
+func newContractUser(email string) *domain.User {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return &domain.User{
+		ID:           uuid.New(),
+		Name:         "Alice",
+		Email:        email,
+		PasswordHash: "hash",
+		IsActive:     true,
+		Role:         domain.RoleUser,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// TestUserRepositoryContract runs the same expectations against every implementation.
+func TestUserRepositoryContract(t *testing.T) {
+	for name, newRepo := range userRepositoryBackends {
+		t.Run(name, func(t *testing.T) {
+			t.Run("create and find", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				user := newContractUser("alice@example.com")
+				require.NoError(t, repo.Create(ctx, user))
+
+				byID, err := repo.FindByID(ctx, user.ID)
+				require.NoError(t, err)
+				byEmail, err := repo.FindByEmail(ctx, "alice@example.com")
+				require.NoError(t, err)
+				for _, found := range []*domain.User{byID, byEmail} {
+					assert.Equal(t, user.ID, found.ID)
+					assert.Equal(t, "Alice", found.Name)
+					assert.Equal(t, "hash", found.PasswordHash)
+					assert.True(t, found.IsActive)
+					assert.Equal(t, domain.RoleUser, found.Role)
+					assert.WithinDuration(t, user.CreatedAt, found.CreatedAt, time.Millisecond)
+				}
+			})
+
+			t.Run("generated ID and timestamps", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				user := &domain.User{Name: "Bob", Email: "bob@example.com", PasswordHash: "hash", IsActive: true, Role: domain.RoleUser}
+				require.NoError(t, repo.Create(ctx, user))
+				assert.NotEqual(t, uuid.Nil, user.ID)
+				assert.WithinDuration(t, time.Now(), user.CreatedAt, time.Minute)
+
+				found, err := repo.FindByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "bob@example.com", found.Email)
+			})
+
+			t.Run("email is unique", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				require.NoError(t, repo.Create(ctx, newContractUser("alice@example.com")))
+
+				err := repo.Create(ctx, newContractUser("alice@example.com"))
+				assert.ErrorIs(t, err, domain.ErrDuplicateEntry)
+				assert.True(t, domain.IsConstraintError(err))
+				// Like the unique constraint, the comparison is case-sensitive
+				require.NoError(t, repo.Create(ctx, newContractUser("Alice@example.com")))
+
+				bob := newContractUser("bob@example.com")
+				require.NoError(t, repo.Create(ctx, bob))
+				bob.Email = "alice@example.com"
+				assert.ErrorIs(t, repo.Update(ctx, bob), domain.ErrDuplicateEntry)
+				found, err := repo.FindByEmail(ctx, "bob@example.com")
+				require.NoError(t, err, "a rejected update changes nothing")
+				assert.Equal(t, bob.ID, found.ID)
+			})
+
+			t.Run("concurrent creates of one email", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				const attempts = 8
+				errs := make([]error, attempts)
+				var wg sync.WaitGroup
+				for i := range attempts {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						errs[i] = repo.Create(ctx, newContractUser("race@example.com"))
+					}()
+				}
+				wg.Wait()
+
+				created := 0
+				for _, err := range errs {
+					if err == nil {
+						created++
+						continue
+					}
+					assert.ErrorIs(t, err, domain.ErrDuplicateEntry)
+				}
+				assert.Equal(t, 1, created)
+			})
+
+			t.Run("update", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				user := newContractUser("alice@example.com")
+				require.NoError(t, repo.Create(ctx, user))
+
+				user.Name = "Alice Smith"
+				user.Email = "alice.smith@example.com"
+				user.IsActive = false // Zero values are written too
+				user.Role = domain.RoleAdmin
+				require.NoError(t, repo.Update(ctx, user))
+
+				found, err := repo.FindByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "Alice Smith", found.Name)
+				assert.Equal(t, "alice.smith@example.com", found.Email)
+				assert.False(t, found.IsActive)
+				assert.Equal(t, domain.RoleAdmin, found.Role)
+				assert.WithinDuration(t, user.CreatedAt, found.CreatedAt, time.Millisecond)
+
+				_, err = repo.FindByEmail(ctx, "alice@example.com")
+				assert.ErrorIs(t, err, domain.ErrNotFound, "the old email is released")
+				require.NoError(t, repo.Create(ctx, newContractUser("alice@example.com")))
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				user := newContractUser("alice@example.com")
+				require.NoError(t, repo.Create(ctx, user))
+
+				require.NoError(t, repo.Delete(ctx, user.ID))
+				_, err := repo.FindByID(ctx, user.ID)
+				assert.ErrorIs(t, err, domain.ErrNotFound)
+				assert.ErrorIs(t, repo.Delete(ctx, user.ID), domain.ErrNotFound)
+				require.NoError(t, repo.Create(ctx, newContractUser("alice@example.com")), "the email is free again")
+			})
+
+			t.Run("count", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				count, err := repo.Count(ctx)
+				require.NoError(t, err)
+				assert.Zero(t, count)
+
+				alice, bob := newContractUser("alice@example.com"), newContractUser("bob@example.com")
+				require.NoError(t, repo.Create(ctx, alice))
+				require.NoError(t, repo.Create(ctx, bob))
+				require.NoError(t, repo.Delete(ctx, alice.ID))
+				count, err = repo.Count(ctx)
+				require.NoError(t, err)
+				assert.EqualValues(t, 1, count)
+			})
+
+			t.Run("find for update", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				user := newContractUser("alice@example.com")
+				require.NoError(t, repo.Create(ctx, user))
+
+				found, err := repo.FindByIDForUpdate(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "alice@example.com", found.Email)
+				_, err = repo.FindByIDForUpdate(ctx, uuid.New())
+				assert.ErrorIs(t, err, domain.ErrNotFound)
+			})
+
+			t.Run("missing users", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				_, err := repo.FindByID(ctx, uuid.New())
+				assert.ErrorIs(t, err, domain.ErrNotFound)
+				_, err = repo.FindByEmail(ctx, "nobody@example.com")
+				assert.ErrorIs(t, err, domain.ErrNotFound)
+				assert.ErrorIs(t, repo.Update(ctx, newContractUser("nobody@example.com")), domain.ErrNotFound)
+				assert.ErrorIs(t, repo.Delete(ctx, uuid.New()), domain.ErrNotFound)
+			})
+
+			t.Run("results are copies", func(t *testing.T) {
+				repo, ctx := newRepo(t), context.Background()
+				user := newContractUser("alice@example.com")
+				require.NoError(t, repo.Create(ctx, user))
+				user.Name = "Changed after create"
+
+				found, err := repo.FindByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "Alice", found.Name)
+				found.Name = "Changed after find"
+				again, err := repo.FindByID(ctx, user.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "Alice", again.Name)
+			})
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// /test/user_search_test.go
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youGo/internal/api/request"
+	"youGo/internal/config"
+	"youGo/internal/domain"
+	"youGo/internal/platform/database"
+	"youGo/internal/platform/textsearch"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/repository/sqlite"
+	"youGo/internal/service"
+)
+
+func TestTextsearch(t *testing.T) {
+	assert.Equal(t, []string{"jane", "doe", "example", "com"}, textsearch.Terms("  Jane.Doe@Example.com jane "))
+	assert.Empty(t, textsearch.Terms("@ -- ."))
+	assert.Len(t, textsearch.Terms("a b c d e f g h i j"), textsearch.MaxTerms)
+
+	assert.Equal(t, "<mark>Jane</mark> <mark>Do</mark>e", textsearch.Highlight("Jane Doe", []string{"jane", "do"}))
+	assert.Equal(t, "<mark>Ann</mark>a", textsearch.Highlight("Anna", []string{"an", "nn"}), "overlapping matches merge")
+	assert.Equal(t, "&lt;b&gt;<mark>Tom</mark> &amp; Jerry&lt;/b&gt;", textsearch.Highlight("<b>Tom & Jerry</b>", []string{"tom"}))
+	assert.Equal(t, "<mark>Çelik</mark>", textsearch.Highlight("Çelik", textsearch.Terms("çELIK")))
+}
+
+// userSearchBackends returns, for every implementation, a searcher and the database it searches.
+var userSearchBackends = map[string]func(t *testing.T) (domain.UserSearcher, *gorm.DB){
+	"like": func(t *testing.T) (domain.UserSearcher, *gorm.DB) {
+		db, err := database.NewGORMConnection(config.Database{Driver: config.DriverSQLite, DBName: ":memory:"}, zap.NewNop())
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = sqlDB.Close() })
+		require.NoError(t, sqlite.Migrate(context.Background(), db))
+		return sqlite.NewUserSearchRepository(db), db
+	},
+	"postgres": func(t *testing.T) (domain.UserSearcher, *gorm.DB) {
+		db := openTestPostgres(t)
+		return repoImpl.NewUserSearchRepository(db), db
+	},
+}
+
+func TestUserSearch(t *testing.T) {
+	for name, newSearcher := range userSearchBackends {
+		t.Run(name, func(t *testing.T) {
+			searcher, db := newSearcher(t)
+			users := repoImpl.NewUserRepository(db)
+			ctx := context.Background()
+			for _, u := range []struct{ name, email string }{
+				{"Jane Doe", "jane.doe@example.com"},
+				{"Janet Jackson", "janet@example.org"},
+				{"John Smith", "jsmith@acme.io"},
+			} {
+				user := newContractUser(u.email)
+				user.Name = u.name
+				require.NoError(t, users.Create(ctx, user))
+			}
+			names := func(hits []domain.UserSearchHit) []string {
+				var names []string
+				for _, hit := range hits {
+					names = append(names, hit.User.Name)
+				}
+				return names
+			}
+
+			hits, total, err := searcher.Search(ctx, domain.UserSearchQuery{Text: "JAN", Limit: 10})
+			require.NoError(t, err)
+			assert.EqualValues(t, 2, total)
+			assert.ElementsMatch(t, []string{"Jane Doe", "Janet Jackson"}, names(hits), "partial names")
+			for _, hit := range hits {
+				if hit.User.Name == "Jane Doe" {
+					assert.Equal(t, "<mark>Jan</mark>e Doe", hit.NameHighlight)
+					assert.Equal(t, "<mark>jan</mark>e.doe@example.com", hit.EmailHighlight)
+				}
+			}
+
+			page, total, err := searcher.Search(ctx, domain.UserSearchQuery{Text: "jan", Limit: 1, Offset: 1})
+			require.NoError(t, err)
+			assert.EqualValues(t, 2, total)
+			require.Len(t, page, 1)
+			assert.NotEqual(t, hits[0].User.ID, page[0].User.ID, "the second page")
+
+			hits, _, err = searcher.Search(ctx, domain.UserSearchQuery{Text: "example.com", Limit: 10})
+			require.NoError(t, err)
+			require.NotEmpty(t, hits)
+			assert.Equal(t, "Jane Doe", hits[0].User.Name, "email domain")
+
+			hits, total, err = searcher.Search(ctx, domain.UserSearchQuery{Text: "...", Limit: 10})
+			require.NoError(t, err)
+			assert.Empty(t, hits)
+			assert.Zero(t, total)
+		})
+	}
+}
+
+func TestUserSearchRanksTypos(t *testing.T) {
+	searcher, db := userSearchBackends["postgres"](t)
+	users := repoImpl.NewUserRepository(db)
+	ctx := context.Background()
+	for _, email := range []string{"jsmith@acme.io", "jones@acme.io"} {
+		require.NoError(t, users.Create(ctx, newContractUser(email)))
+	}
+
+	hits, _, err := searcher.Search(ctx, domain.UserSearchQuery{Text: "jsmiht@acme.io", Limit: 10})
+	require.NoError(t, err)
+	require.NotEmpty(t, hits)
+	assert.Equal(t, "jsmith@acme.io", hits[0].User.Email, "misspelled email")
+	assert.Positive(t, hits[0].Rank)
+}
+
+func TestUserSearchService(t *testing.T) {
+	searcher, _ := userSearchBackends["like"](t)
+	svc := service.NewUserSearchService(searcher, zap.NewNop())
+
+	_, err := svc.Search(context.Background(), &request.SearchUsersRequest{Query: "@."})
+	var argErr *domain.InvalidArgumentError
+	assert.True(t, errors.As(err, &argErr), "a query without words is invalid")
+
+	result, err := svc.Search(context.Background(), &request.SearchUsersRequest{Query: "nobody"})
+	require.NoError(t, err)
+	assert.Equal(t, 20, result.Limit, "default page size")
+	assert.NotNil(t, result.Items)
+	assert.Zero(t, result.Total)
+}
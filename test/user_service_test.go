@@ -0,0 +1,134 @@
+// /test/user_service_test.go
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/request"
+	"youGo/internal/auth"
+	"youGo/internal/domain"
+	"youGo/internal/repository/memory"
+	"youGo/internal/service"
+)
+
+// recordingOutbox keeps the appended events.
+type recordingOutbox struct {
+	events []*domain.DomainEvent
+}
+
+func (o *recordingOutbox) Append(_ context.Context, events ...*domain.DomainEvent) error {
+	o.events = append(o.events, events...)
+	return nil
+}
+
+func (o *recordingOutbox) ProcessPending(context.Context, int, domain.OutboxHandler) (int, error) {
+	return 0, nil
+}
+
+func (o *recordingOutbox) Requeue(context.Context, uuid.UUID) error {
+	return domain.ErrNotFound
+}
+
+func TestUserServiceWithMemoryRepository(t *testing.T) {
+	repo, outbox, auditor := memory.NewUserRepository(), &recordingOutbox{}, &recordingAuditor{}
+	users := service.NewUserService(repo, outbox, auditor, passthroughTxManager{}, zap.NewNop())
+	ctx := context.Background()
+
+	created, err := users.Create(ctx, &request.CreateUserRequest{Name: "Alice", Email: "alice@example.com", Password: "password123"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, created.Role)
+	id := uuid.MustParse(created.ID)
+	_, err = users.Create(ctx, &request.CreateUserRequest{Name: "Alice", Email: "alice@example.com", Password: "password123"})
+	assert.ErrorIs(t, err, domain.ErrDuplicateEntry)
+
+	inactive := false
+	updated, err := users.Update(ctx, id, &request.UpdateUserRequest{IsActive: &inactive})
+	require.NoError(t, err)
+	assert.False(t, updated.IsActive)
+	stored, err := repo.FindByID(ctx, id)
+	require.NoError(t, err)
+	assert.False(t, stored.IsActive)
+
+	require.NoError(t, users.Delete(ctx, id))
+	assert.ErrorIs(t, users.Delete(ctx, id), domain.ErrNotFound)
+
+	assert.Len(t, outbox.events, 3, "created, updated, deleted")
+	assert.Len(t, auditor.events, 3)
+}
+
+func TestUserServiceCreateWithRole(t *testing.T) {
+	repo, outbox, auditor := memory.NewUserRepository(), &recordingOutbox{}, &recordingAuditor{}
+	users := service.NewUserService(repo, outbox, auditor, passthroughTxManager{}, zap.NewNop())
+
+	admin, err := users.CreateWithRole(context.Background(), &request.CreateUserRequest{Name: "Root", Email: "root@example.com", Password: "password123"}, domain.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, admin.Role)
+	stored, err := repo.FindByID(context.Background(), uuid.MustParse(admin.ID))
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, stored.Role)
+
+	require.Len(t, outbox.events, 1, "a single created event")
+	assert.Equal(t, domain.EventUserCreated, outbox.events[0].Type)
+	require.Len(t, auditor.events, 1)
+	assert.Equal(t, domain.FieldChange{Before: nil, After: domain.RoleAdmin}, auditor.events[0].Changes["role"])
+}
+
+func TestAuthServiceWithMemoryRepository(t *testing.T) {
+	repo, outbox, auditor := memory.NewUserRepository(), &recordingOutbox{}, &recordingAuditor{}
+	users := service.NewUserService(repo, outbox, auditor, passthroughTxManager{}, zap.NewNop())
+	authSvc := auth.NewAuthService(repo, auditor, outbox, []byte("secret"), time.Minute, time.Hour)
+	ctx := context.Background()
+
+	created, err := users.Create(ctx, &request.CreateUserRequest{Name: "Alice", Email: "alice@example.com", Password: "password123"})
+	require.NoError(t, err)
+
+	access, _, err := authSvc.Login(ctx, &request.LoginRequest{Email: "alice@example.com", Password: "password123"})
+	require.NoError(t, err)
+	userID, err := authSvc.ValidateToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, userID.String())
+	claims, err := authSvc.TokenClaims(access)
+	require.NoError(t, err)
+	assert.Empty(t, claims.Locale, "no language chosen")
+
+	user, err := repo.FindByID(ctx, userID)
+	require.NoError(t, err)
+	user.Locale = "id"
+	require.NoError(t, repo.Update(ctx, user))
+	access, _, err = authSvc.Login(ctx, &request.LoginRequest{Email: "alice@example.com", Password: "password123"})
+	require.NoError(t, err)
+	claims, err = authSvc.TokenClaims(access)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, "id", claims.Locale, "the access token carries the user's language")
+
+	_, _, err = authSvc.Login(ctx, &request.LoginRequest{Email: "alice@example.com", Password: "wrong-password"})
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	_, _, err = authSvc.Login(ctx, &request.LoginRequest{Email: "nobody@example.com", Password: "password123"})
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+}
+
+func TestAuthServiceFallsBackToTheSubjectClaim(t *testing.T) {
+	authSvc := auth.NewAuthService(memory.NewUserRepository(), &recordingAuditor{}, &recordingOutbox{}, []byte("secret"), time.Minute, time.Hour)
+	sign := func(subject string) string {
+		claims := jwt.RegisteredClaims{Subject: subject, ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return token
+	}
+
+	userID := uuid.New()
+	validated, err := authSvc.ValidateToken(sign(userID.String()))
+	require.NoError(t, err)
+	assert.Equal(t, userID, validated, "tokens without a user_id claim name the user in their subject")
+	_, err = authSvc.ValidateToken(sign("alice"))
+	assert.Error(t, err, "a subject that isn't a user ID")
+}
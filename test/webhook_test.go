@@ -0,0 +1,338 @@
+// /test/webhook_test.go
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"youGo/internal/api/handler"
+	"youGo/internal/api/middleware"
+	"youGo/internal/api/request"
+	"youGo/internal/api/response"
+	"youGo/internal/domain"
+	"youGo/internal/platform/httpclient"
+	"youGo/internal/platform/validator"
+	"youGo/internal/platform/webhook"
+	repoImpl "youGo/internal/repository/postgres"
+	"youGo/internal/service"
+)
+
+// receivedDelivery is what the httptest receiver saw.
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// newWebhookReceiver starts a receiver that answers every request with status.
+func newWebhookReceiver(t *testing.T, status int) (*httptest.Server, func() []receivedDelivery) {
+	var mu sync.Mutex
+	var received []receivedDelivery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedDelivery{header: r.Header.Clone(), body: body})
+		mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(http.StatusText(status)))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []receivedDelivery {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedDelivery(nil), received...)
+	}
+}
+
+func TestWebhookSenderSignsDeliveries(t *testing.T) {
+	server, received := newWebhookReceiver(t, http.StatusNoContent)
+	sender := webhook.NewSender(httpclient.NewHTTPClient(5 * time.Second))
+	body := []byte(`{"id":"evt","type":"UserCreated"}`)
+
+	result, err := sender.Send(context.Background(), webhook.Request{
+		URL:        server.URL,
+		Secret:     "whsec_test_secret_value",
+		DeliveryID: "delivery-1",
+		EventID:    "evt",
+		EventType:  domain.EventUserCreated,
+		Body:       body,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, result.StatusCode)
+
+	got := received()
+	require.Len(t, got, 1)
+	assert.Equal(t, body, got[0].body)
+	assert.Equal(t, domain.EventUserCreated, got[0].header.Get(webhook.HeaderEventType))
+	assert.Equal(t, "delivery-1", got[0].header.Get(webhook.HeaderDelivery))
+
+	ts, sig := got[0].header.Get(webhook.HeaderTimestamp), got[0].header.Get(webhook.HeaderSignature)
+	assert.NoError(t, webhook.Verify("whsec_test_secret_value", ts, sig, got[0].body, webhook.DefaultTolerance, time.Now()))
+	assert.ErrorIs(t, webhook.Verify("other_secret", ts, sig, got[0].body, webhook.DefaultTolerance, time.Now()), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("whsec_test_secret_value", ts, sig, []byte(`{}`), webhook.DefaultTolerance, time.Now()), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify("whsec_test_secret_value", ts, sig, got[0].body, webhook.DefaultTolerance, time.Now().Add(time.Hour)), webhook.ErrTimestampOutOfRange)
+}
+
+func TestWebhookSenderReportsNon2xx(t *testing.T) {
+	server, _ := newWebhookReceiver(t, http.StatusBadGateway)
+	sender := webhook.NewSender(httpclient.NewHTTPClient(5 * time.Second))
+
+	result, err := sender.Send(context.Background(), webhook.Request{URL: server.URL, Secret: "s", Body: []byte(`{}`)})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, result.StatusCode)
+	assert.Equal(t, http.StatusText(http.StatusBadGateway), result.Body)
+}
+
+// fakeWebhookRepository keeps deliveries in memory. Only the methods the dispatcher uses are implemented.
+type fakeWebhookRepository struct {
+	domain.WebhookRepository
+	endpoint   *domain.WebhookEndpoint
+	deliveries map[uuid.UUID]*domain.WebhookDelivery
+}
+
+func (r *fakeWebhookRepository) FindEndpointByID(_ context.Context, owner string, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	if r.endpoint == nil || r.endpoint.ID != id || r.endpoint.Owner != owner {
+		return nil, domain.ErrNotFound
+	}
+	return r.endpoint, nil
+}
+
+func (r *fakeWebhookRepository) ClaimDueDeliveries(_ context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	var due []*domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == domain.WebhookDeliveryPending && len(due) < limit {
+			d.NextAttemptAt = time.Now().Add(lease)
+			copied := *d
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeWebhookRepository) UpdateDelivery(_ context.Context, delivery *domain.WebhookDelivery) error {
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+func TestWebhookDispatcherRetriesThenDeadLetters(t *testing.T) {
+	server, received := newWebhookReceiver(t, http.StatusInternalServerError)
+	owner := domain.UserSubject(uuid.New())
+	endpoint := &domain.WebhookEndpoint{ID: uuid.New(), Owner: owner, URL: server.URL, Secret: "whsec_test_secret_value", IsActive: true}
+	delivery := &domain.WebhookDelivery{
+		ID:         uuid.New(),
+		Owner:      owner,
+		EndpointID: endpoint.ID,
+		EventID:    uuid.New(),
+		EventType:  domain.EventUserUpdated,
+		Payload:    []byte(`{"type":"UserUpdated"}`),
+		Status:     domain.WebhookDeliveryPending,
+	}
+	repo := &fakeWebhookRepository{endpoint: endpoint, deliveries: map[uuid.UUID]*domain.WebhookDelivery{delivery.ID: delivery}}
+	sender := webhook.NewSender(httpclient.NewHTTPClient(5 * time.Second))
+	dispatcher := service.NewWebhookDispatcher(repo, sender, 2, time.Second, 10, zap.NewNop())
+
+	// First failure schedules a retry with backoff.
+	n, err := dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	got := repo.deliveries[delivery.ID]
+	assert.Equal(t, domain.WebhookDeliveryPending, got.Status)
+	assert.Equal(t, 1, got.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, got.LastStatusCode)
+	assert.NotEmpty(t, got.LastError)
+	assert.True(t, got.NextAttemptAt.After(time.Now()), "retry should be scheduled in the future")
+
+	// Second failure exhausts the budget.
+	_, err = dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	got = repo.deliveries[delivery.ID]
+	assert.Equal(t, domain.WebhookDeliveryDead, got.Status)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Len(t, received(), 2)
+
+	// Dead deliveries are not picked up again.
+	n, err = dispatcher.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+// ownedWebhookRepository keeps endpoints and deliveries in memory, scoped by owner like the database.
+// Only the methods Publish uses are implemented.
+type ownedWebhookRepository struct {
+	domain.WebhookRepository
+	endpoints  []*domain.WebhookEndpoint
+	deliveries []*domain.WebhookDelivery
+}
+
+func (r *ownedWebhookRepository) ListActiveEndpointsFor(_ context.Context, owner, eventType string) ([]*domain.WebhookEndpoint, error) {
+	var found []*domain.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		if endpoint.Owner == owner && endpoint.IsActive && endpoint.Subscribes(eventType) {
+			found = append(found, endpoint)
+		}
+	}
+	return found, nil
+}
+
+func (r *ownedWebhookRepository) CreateDeliveries(_ context.Context, deliveries ...*domain.WebhookDelivery) error {
+	r.deliveries = append(r.deliveries, deliveries...)
+	return nil
+}
+
+func TestWebhookServicePublishesToTheEventOwner(t *testing.T) {
+	ann, bob := uuid.New(), uuid.New()
+	annEndpoint := &domain.WebhookEndpoint{ID: uuid.New(), Owner: domain.UserSubject(ann), EventTypes: []string{domain.WebhookAllEvents}, IsActive: true}
+	bobEndpoint := &domain.WebhookEndpoint{ID: uuid.New(), Owner: domain.UserSubject(bob), EventTypes: []string{domain.WebhookAllEvents}, IsActive: true}
+	repo := &ownedWebhookRepository{endpoints: []*domain.WebhookEndpoint{annEndpoint, bobEndpoint}}
+	webhooks := service.NewWebhookService(repo, &recordingAuditor{}, passthroughTxManager{}, httpclient.CheckPublicURL, zap.NewNop())
+
+	event, err := domain.NewUserEvent(domain.EventUserUpdated, &domain.User{ID: ann, Name: "Ann"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserSubject(ann), event.Owner, "user events belong to the user")
+	require.NoError(t, webhooks.Publish(context.Background(), event))
+	require.Len(t, repo.deliveries, 1)
+	assert.Equal(t, annEndpoint.ID, repo.deliveries[0].EndpointID)
+	assert.Equal(t, annEndpoint.Owner, repo.deliveries[0].Owner)
+
+	ownerless, err := domain.NewDomainEvent(domain.AggregateUser, ann.String(), domain.EventUserUpdated, nil)
+	require.NoError(t, err)
+	require.NoError(t, webhooks.Publish(context.Background(), ownerless))
+	assert.Len(t, repo.deliveries, 1, "events without an owner go to no endpoint")
+}
+
+func TestWebhookRepositoryScopesQueriesToTheOwner(t *testing.T) {
+	db := newDryRunDB(t)
+	rec := recordStatements(t, db)
+	repo := repoImpl.NewWebhookRepository(db)
+	ctx := context.Background()
+	owner, id := "org:acme", uuid.New()
+
+	_, _ = repo.FindEndpointByID(ctx, owner, id)
+	_, _ = repo.ListEndpoints(ctx, owner)
+	_, _ = repo.ListActiveEndpointsFor(ctx, owner, domain.EventUserCreated)
+	_ = repo.DeleteEndpoint(ctx, owner, id)
+	_, _ = repo.FindDeliveryByID(ctx, owner, id)
+	_, _, _ = repo.ListDeliveries(ctx, domain.WebhookDeliveryFilter{Owner: owner, Limit: 10})
+
+	require.Len(t, rec.statements, 7, "ListDeliveries counts, then selects")
+	for i, statement := range rec.statements {
+		assert.Contains(t, statement, `WHERE "owner" = $1`, "statement %d", i)
+		require.NotEmpty(t, rec.vars[i])
+		assert.Equal(t, owner, rec.vars[i][0], "statement %d", i)
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"8.8.8.8":          true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false, // Cloud metadata
+		"fd00:ec2::254":    false, // Cloud metadata, IPv6
+		"100.100.100.200":  false, // Carrier-grade NAT
+		"0.0.0.0":          false,
+		"::ffff:127.0.0.1": false, // IPv4-mapped
+		"224.0.0.1":        false,
+		"fe80::1":          false,
+	} {
+		assert.Equal(t, public, httpclient.PublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestCheckPublicURL(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, httpclient.CheckPublicURL(ctx, "https://8.8.8.8/hooks"))
+	for _, rawURL := range []string{"http://127.0.0.1:9090/metrics", "http://169.254.169.254/latest/meta-data", "http://[::1]/", "http://localhost/"} {
+		assert.ErrorIs(t, httpclient.CheckPublicURL(ctx, rawURL), httpclient.ErrNonPublicAddress, rawURL)
+	}
+	assert.Error(t, httpclient.CheckPublicURL(ctx, "file:///etc/passwd"))
+}
+
+func TestPublicHTTPClient(t *testing.T) {
+	server, received := newWebhookReceiver(t, http.StatusNoContent)
+	client := httpclient.NewPublicHTTPClient(5 * time.Second)
+
+	// The receiver listens on a loopback address, whatever the URL says
+	sender := webhook.NewSender(client)
+	_, err := sender.Send(context.Background(), webhook.Request{URL: server.URL, Secret: "whsec_test", Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, httpclient.ErrNonPublicAddress)
+	assert.Empty(t, received(), "no connection was made")
+
+	assert.ErrorIs(t, client.CheckRedirect(nil, nil), http.ErrUseLastResponse, "redirects aren't followed")
+}
+
+func TestWebhookServiceRefusesNonPublicURLs(t *testing.T) {
+	webhooks := service.NewWebhookService(&ownedWebhookRepository{}, &recordingAuditor{}, passthroughTxManager{}, httpclient.CheckPublicURL, zap.NewNop())
+	owner := domain.UserSubject(uuid.New())
+
+	_, err := webhooks.CreateEndpoint(context.Background(), owner, &request.CreateWebhookEndpointRequest{
+		URL: "http://169.254.169.254/latest/meta-data", EventTypes: []string{domain.WebhookAllEvents},
+	})
+	var argErr *domain.InvalidArgumentError
+	require.ErrorAs(t, err, &argErr)
+	assert.Equal(t, "url", argErr.ArgumentName)
+	assert.Equal(t, domain.ReasonPublicURL, argErr.Code)
+}
+
+// deliveryLogService returns a delivery log whose last attempt got a response body.
+type deliveryLogService struct{ service.WebhookService }
+
+func (deliveryLogService) ListDeliveries(context.Context, string, uuid.UUID, *request.ListWebhookDeliveriesRequest) (*response.WebhookDeliveryListResponse, error) {
+	return &response.WebhookDeliveryListResponse{Items: []response.WebhookDeliveryResponse{
+		{ID: uuid.NewString(), LastStatusCode: http.StatusOK, LastResponse: `{"AccessKeyId":"..."}`},
+	}, Total: 1, Limit: 50}, nil
+}
+
+// roleUserService knows the role of every user.
+type roleUserService struct {
+	service.UserService
+	role string
+}
+
+func (s roleUserService) GetByID(_ context.Context, id uuid.UUID) (*response.UserResponse, error) {
+	return &response.UserResponse{ID: id.String(), Role: s.role}, nil
+}
+
+func TestWebhookDeliveryResponsesAreShownToAdminsOnly(t *testing.T) {
+	for role, shown := range map[string]bool{domain.RoleUser: false, domain.RoleAdmin: true} {
+		t.Run(role, func(t *testing.T) {
+			h := handler.NewWebhookHandler(deliveryLogService{}, roleUserService{role: role})
+			e := echo.New()
+			e.Validator = validator.NewValidator()
+			e.GET("/webhooks/:id/deliveries", h.ListDeliveries, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error { // Stands in for JWTAuth
+					c.Set(string(middleware.UserIDContextKey), uuid.New())
+					return next(c)
+				}
+			})
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks/"+uuid.NewString()+"/deliveries", nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var body struct {
+				Data response.WebhookDeliveryListResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Len(t, body.Data.Items, 1)
+			assert.Equal(t, http.StatusOK, body.Data.Items[0].LastStatusCode)
+			assert.Equal(t, shown, body.Data.Items[0].LastResponse != "")
+		})
+	}
+}